@@ -4,17 +4,21 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	ioutils "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/buildinfo"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/container"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/curl"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/doctor"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/dotnet"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/oc"
 	containerutils "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/permissiontarget"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/replication"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/repository"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/accessreport"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildadddependencies"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildaddgit"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildappend"
@@ -25,6 +29,7 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpromote"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpublish"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildscan"
+	cleanupdocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/cleanup"
 	copydocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/copy"
 	curldocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/curl"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/delete"
@@ -33,23 +38,32 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpromote"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpull"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerpush"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/dockerverify"
+	doctordocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/doctor"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/download"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/gitlfsclean"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/gitlfsmigrate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/move"
 	nugettree "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/nugetdepstree"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ocstartbuild"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/permissiontargetdiff"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/permissiontargetexport"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/ping"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/podmanpull"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/podmanpush"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationcreate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationdelete"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationexport"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationstatus"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationtemplate"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/replicationupdate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repocreate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repodelete"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repotemplate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/repoupdate"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/search"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/setprops"
+	syncdocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/sync"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/upload"
 	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/commandWrappers"
@@ -79,6 +93,7 @@ const (
 	buildCategory    = "Build Info"
 	repoCategory     = "Repository Management"
 	replicCategory   = "Replication"
+	permCategory     = "Permission Targets"
 	otherCategory    = "Other"
 	releaseBundlesV2 = "release-bundles-v2"
 )
@@ -139,6 +154,22 @@ func GetCommands() []components.Command {
 			Action:      deleteCmd,
 			Category:    filesCategory,
 		},
+		{
+			Name:        "sync",
+			Flags:       flagkit.GetCommandFlags(flagkit.RtSync),
+			Description: syncdocs.GetDescription(),
+			Arguments:   syncdocs.GetArguments(),
+			Action:      syncCmd,
+			Category:    filesCategory,
+		},
+		{
+			Name:        "cleanup",
+			Flags:       flagkit.GetCommandFlags(flagkit.RtCleanup),
+			Description: cleanupdocs.GetDescription(),
+			Arguments:   cleanupdocs.GetArguments(),
+			Action:      cleanupCmd,
+			Category:    filesCategory,
+		},
 		{
 			Name:        "search",
 			Flags:       flagkit.GetCommandFlags(flagkit.Search),
@@ -150,7 +181,7 @@ func GetCommands() []components.Command {
 		},
 		{
 			Name:        "set-props",
-			Flags:       flagkit.GetCommandFlags(flagkit.Properties),
+			Flags:       flagkit.GetCommandFlags(flagkit.SetProperties),
 			Aliases:     []string{"sp"},
 			Description: setprops.GetDescription(),
 			Arguments:   setprops.GetArguments(),
@@ -257,6 +288,15 @@ func GetCommands() []components.Command {
 			Action:      gitLfsCleanCmd,
 			Category:    otherCategory,
 		},
+		{
+			Name:        "git-lfs-migrate",
+			Flags:       flagkit.GetCommandFlags(flagkit.GitLfsMigrate),
+			Aliases:     []string{"glm"},
+			Description: gitlfsmigrate.GetDescription(),
+			Arguments:   gitlfsmigrate.GetArguments(),
+			Action:      gitLfsMigrateCmd,
+			Category:    otherCategory,
+		},
 		{
 			Name:        "docker-promote",
 			Flags:       flagkit.GetCommandFlags(flagkit.DockerPromote),
@@ -266,6 +306,15 @@ func GetCommands() []components.Command {
 			Action:      dockerPromoteCmd,
 			Category:    buildCategory,
 		},
+		{
+			Name:        "docker-verify",
+			Flags:       flagkit.GetCommandFlags(flagkit.DockerVerify),
+			Aliases:     []string{"dv"},
+			Description: dockerverify.GetDescription(),
+			Arguments:   dockerverify.GetArguments(),
+			Action:      dockerVerifyCmd,
+			Category:    buildCategory,
+		},
 		{
 			Name:        "docker-push",
 			Hidden:      true,
@@ -342,6 +391,12 @@ func GetCommands() []components.Command {
 			Description: ping.GetDescription(),
 			Action:      pingCmd,
 		},
+		{
+			Name:        "doctor",
+			Flags:       flagkit.GetCommandFlags(flagkit.RtDoctor),
+			Description: doctordocs.GetDescription(),
+			Action:      doctorCmd,
+		},
 		{
 			Name:            "curl",
 			Flags:           flagkit.GetCommandFlags(flagkit.RtCurl),
@@ -413,6 +468,59 @@ func GetCommands() []components.Command {
 			Action:      replicationDeleteCmd,
 			Category:    replicCategory,
 		},
+		{
+			Name:        "replication-update",
+			Aliases:     []string{"rplu"},
+			Flags:       flagkit.GetCommandFlags(flagkit.TemplateConsumer),
+			Description: replicationupdate.GetDescription(),
+			Arguments:   replicationupdate.GetArguments(),
+			Action:      replicationUpdateCmd,
+			Category:    replicCategory,
+		},
+		{
+			Name:        "replication-export",
+			Aliases:     []string{"rplexp"},
+			Flags:       flagkit.GetCommandFlags(flagkit.ReplicationExport),
+			Description: replicationexport.GetDescription(),
+			Arguments:   replicationexport.GetArguments(),
+			Action:      replicationExportCmd,
+			Category:    replicCategory,
+		},
+		{
+			Name:        "replication-status",
+			Aliases:     []string{"rpls"},
+			Flags:       flagkit.GetCommandFlags(flagkit.ReplicationStatus),
+			Description: replicationstatus.GetDescription(),
+			Arguments:   replicationstatus.GetArguments(),
+			Action:      replicationStatusCmd,
+			Category:    replicCategory,
+		},
+		{
+			Name:        "permission-target-export",
+			Aliases:     []string{"ptexp"},
+			Flags:       flagkit.GetCommandFlags(flagkit.PermissionTargetExport),
+			Description: permissiontargetexport.GetDescription(),
+			Arguments:   permissiontargetexport.GetArguments(),
+			Action:      permissionTargetExportCmd,
+			Category:    permCategory,
+		},
+		{
+			Name:        "permission-target-diff",
+			Aliases:     []string{"ptdiff"},
+			Flags:       flagkit.GetCommandFlags(flagkit.TemplateConsumer),
+			Description: permissiontargetdiff.GetDescription(),
+			Arguments:   permissiontargetdiff.GetArguments(),
+			Action:      permissionTargetDiffCmd,
+			Category:    permCategory,
+		},
+		{
+			Name:        "access-report",
+			Flags:       flagkit.GetCommandFlags(flagkit.AccessReport),
+			Description: accessreport.GetDescription(),
+			Arguments:   accessreport.GetArguments(),
+			Action:      accessReportCmd,
+			Category:    permCategory,
+		},
 	}
 
 	return commands
@@ -485,6 +593,23 @@ func dockerPromoteCmd(c *components.Context) error {
 	return commands.Exec(dockerPromoteCommand)
 }
 
+func dockerVerifyCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildConfiguration := common.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	dockerVerifyCommand := container.NewDockerVerifyCommand()
+	dockerVerifyCommand.SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(dockerVerifyCommand)
+}
+
 func containerPushCmd(c *components.Context, containerManagerType containerutils.ContainerManagerType) (err error) {
 	if c.GetNumberOfArgs() != 2 {
 		return common.WrongNumberOfArgumentsHandler(c)
@@ -509,6 +634,7 @@ func containerPushCmd(c *components.Context, containerManagerType containerutils
 	}
 	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), c.GetBoolFlagValue("detailed-summary")
 	dockerPushCommand.SetThreads(threads).SetDetailedSummary(detailedSummary || printDeploymentView).SetCmdParams([]string{"push", imageTag}).SetSkipLogin(skipLogin).SetBuildConfiguration(buildConfiguration).SetRepo(targetRepo).SetServerDetails(artDetails).SetImageTag(imageTag).SetValidateSha(validateSha)
+	dockerPushCommand.SetImagePath(c.GetStringFlagValue("image-path"))
 	err = commandWrappers.ShowDockerDeprecationMessageIfNeeded(containerManagerType, dockerPushCommand.IsGetRepoSupported)
 	if err != nil {
 		return
@@ -539,6 +665,7 @@ func containerPullCmd(c *components.Context, containerManagerType containerutils
 	}
 	dockerPullCommand := container.NewPullCommand(containerManagerType)
 	dockerPullCommand.SetCmdParams([]string{"pull", imageTag}).SetSkipLogin(skipLogin).SetImageTag(imageTag).SetRepo(sourceRepo).SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	dockerPullCommand.SetVerify(c.GetBoolFlagValue("verify"))
 	err = commandWrappers.ShowDockerDeprecationMessageIfNeeded(containerManagerType, dockerPullCommand.IsGetRepoSupported)
 	if err != nil {
 		return err
@@ -564,9 +691,11 @@ func BuildDockerCreateCmd(c *components.Context) error {
 		return err
 	}
 	buildDockerCreateCommand := container.NewBuildDockerCreateCommand()
-	if err = buildDockerCreateCommand.SetImageNameWithDigest(imageNameWithDigestFile); err != nil {
+	if err = buildDockerCreateCommand.SetImageFile(imageNameWithDigestFile, c.GetStringFlagValue("image-name")); err != nil {
 		return err
 	}
+	buildDockerCreateCommand.SetExtractAttestations(c.GetBoolFlagValue("extract-attestations")).SetIncludeSignatures(c.GetBoolFlagValue("include-signatures")).
+		SetDockerfilePath(c.GetStringFlagValue("dockerfile"))
 	buildDockerCreateCommand.SetRepo(sourceRepo).SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
 	return commands.Exec(buildDockerCreateCommand)
 }
@@ -646,6 +775,22 @@ func pingCmd(c *components.Context) error {
 	return err
 }
 
+func doctorCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 0 {
+		return common.PrintHelpAndReturnError("No arguments should be sent.", c)
+	}
+	format, err := doctor.GetOutputFormat(c.GetStringFlagValue("format"))
+	if err != nil {
+		return err
+	}
+	artDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	doctorCmd := doctor.NewDoctorCommand().SetServerDetails(artDetails).SetRepo(c.GetStringFlagValue("repo")).SetFormat(format)
+	return commands.Exec(doctorCmd)
+}
+
 func prepareDownloadCommand(c *components.Context) (*spec.SpecFiles, error) {
 	if c.GetNumberOfArgs() > 0 && c.IsFlagSet("spec") {
 		return nil, common.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -775,6 +920,7 @@ func directDownloadCmd(c *components.Context) error {
 
 	directDownloadCommand := generic.NewDirectDownloadCommand()
 	directDownloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.GetBoolFlagValue("dry-run")).SetSyncDeletesPath(c.GetStringFlagValue("sync-deletes")).SetQuiet(common.GetQuietValue(c)).SetDetailedSummary(c.GetBoolFlagValue("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	directDownloadCommand.SetResume(c.GetBoolFlagValue("resume")).SetCacheDir(c.GetStringFlagValue("cache-dir"))
 
 	if directDownloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
@@ -932,18 +1078,43 @@ func uploadCmd(c *components.Context) (err error) {
 	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), common.GetDetailedSummary(c)
 	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.GetBoolFlagValue("dry-run")).SetSyncDeletesPath(c.GetStringFlagValue("sync-deletes")).SetQuiet(common.GetQuietValue(c)).SetDetailedSummary(detailedSummary || printDeploymentView).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
+	watch := c.GetBoolFlagValue("watch")
+	if watch {
+		var watchDebounce time.Duration
+		if watchDebounce, err = getWatchDebounce(c); err != nil {
+			return
+		}
+		uploadCmd.SetWatch(true).SetWatchDebounce(watchDebounce).SetWatchStopFile(c.GetStringFlagValue("watch-stop-file"))
+	}
+
 	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(uploadCmd)
+	if watch {
+		// The progress bar is not displayed while watching, since the total number of files is unknown.
+		err = commands.Exec(uploadCmd)
+	} else {
+		err = progressbar.ExecWithProgress(uploadCmd)
+	}
 	result := uploadCmd.Result()
 	defer common.CleanupResult(result, &err)
 	err = common.PrintCommandSummary(uploadCmd.Result(), detailedSummary, printDeploymentView, common.IsFailNoOp(c), err)
 	return
 }
 
+func getWatchDebounce(c *components.Context) (time.Duration, error) {
+	if !c.IsFlagSet("watch-debounce") {
+		return generic.DefaultWatchDebounce, nil
+	}
+	watchDebounce, err := time.ParseDuration(c.GetStringFlagValue("watch-debounce"))
+	if err != nil || watchDebounce < 0 {
+		return 0, errorutils.CheckErrorf("the '--watch-debounce' option should be a non-negative duration, such as 500ms or 5s")
+	}
+	return watchDebounce, nil
+}
+
 func prepareCopyMoveCommand(c *components.Context) (*spec.SpecFiles, error) {
 	if c.GetNumberOfArgs() > 0 && c.IsFlagSet("spec") {
 		return nil, common.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1087,6 +1258,71 @@ func deleteCmd(c *components.Context) error {
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 }
 
+func syncCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	mode, err := generic.GetSyncMode(c.GetStringFlagValue("mode"))
+	if err != nil {
+		return err
+	}
+	conflictPolicy, err := generic.GetSyncConflictPolicy(c.GetStringFlagValue("conflict-policy"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	syncCommand := generic.NewSyncCommand()
+	syncCommand.SetLocalDir(c.GetArgumentAt(0)).SetTarget(c.GetArgumentAt(1)).SetMode(mode).SetConflictPolicy(conflictPolicy).
+		SetThreads(threads).SetStateDir(c.GetStringFlagValue("state-dir")).SetDeleteAdded(c.GetBoolFlagValue("delete"))
+	syncCommand.SetQuiet(common.GetQuietValue(c)).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(syncCommand)
+	result := syncCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), false, err)
+}
+
+func cleanupCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	cleanupCommand := generic.NewCleanupCommand().SetPoliciesPath(c.GetArgumentAt(0))
+	cleanupCommand.SetThreads(threads)
+	cleanupCommand.SetQuiet(common.GetQuietValue(c)).SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(cleanupCommand)
+	result := cleanupCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), false, err)
+}
+
 func prepareSearchCommand(c *components.Context) (*spec.SpecFiles, error) {
 	if c.GetNumberOfArgs() > 0 && c.IsFlagSet("spec") {
 		return nil, common.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1129,6 +1365,22 @@ func searchCmd(c *components.Context) (err error) {
 	if err != nil {
 		return
 	}
+	format, err := generic.GetSearchOutputFormat(c.GetStringFlagValue("format"))
+	if err != nil {
+		return
+	}
+	fields, err := generic.ParseSearchFields(c.GetStringFlagValue("fields"))
+	if err != nil {
+		return
+	}
+	aggregation := &generic.SearchAggregation{GroupBy: c.GetStringFlagValue("group-by"), Count: c.GetBoolFlagValue("count"), SumSize: c.GetBoolFlagValue("sum-size")}
+	// The count of all the results is printed as a plain number, unless it's grouped or formatted.
+	aggregate := aggregation.SumSize || aggregation.GroupBy != "" || (aggregation.Count && format != generic.SearchOutputJson)
+	if aggregate {
+		if err = aggregation.Validate(); err != nil {
+			return
+		}
+	}
 	searchCmd := generic.NewSearchCommand()
 	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 	err = commands.Exec(searchCmd)
@@ -1145,11 +1397,17 @@ func searchCmd(c *components.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	if !c.GetBoolFlagValue("count") {
+	if aggregate {
+		return aggregation.WriteAggregation(os.Stdout, reader, format)
+	}
+	if aggregation.Count {
+		log.Output(length)
+		return nil
+	}
+	if format == generic.SearchOutputJson && len(fields) == 0 {
 		return utils.PrintSearchResults(reader)
 	}
-	log.Output(length)
-	return nil
+	return generic.WriteSearchResults(os.Stdout, reader, format, fields)
 }
 
 func preparePropsCmd(c *components.Context) (*generic.PropsCommand, error) {
@@ -1199,6 +1457,9 @@ func preparePropsCmd(c *components.Context) (*generic.PropsCommand, error) {
 }
 
 func setPropsCmd(c *components.Context) error {
+	if c.IsFlagSet("manifest") {
+		return setPropsFromManifestCmd(c)
+	}
 	cmd, err := preparePropsCmd(c)
 	if err != nil {
 		return err
@@ -1218,6 +1479,35 @@ func setPropsCmd(c *components.Context) error {
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
 }
 
+func setPropsFromManifestCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 0 || c.IsFlagSet("spec") {
+		return common.PrintHelpAndReturnError("No arguments or spec should be sent when the manifest option is used.", c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	cmd := generic.NewPropsCommand().SetThreads(threads)
+	cmd.SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails)
+	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd).SetManifestPath(c.GetStringFlagValue("manifest")).SetReportPath(c.GetStringFlagValue("report"))
+	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(propsCmd)
+	result := propsCmd.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), common.IsFailNoOp(c), err)
+}
+
 func deletePropsCmd(c *components.Context) error {
 	cmd, err := preparePropsCmd(c)
 	if err != nil {
@@ -1457,6 +1747,37 @@ func gitLfsCleanCmd(c *components.Context) error {
 	return commands.Exec(gitLfsCmd)
 }
 
+func gitLfsMigrateCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := common.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	gitLfsMigrateCmd := generic.NewGitLfsMigrateCommand().SetRepo(c.GetStringFlagValue("repo")).SetRefs(c.GetStringFlagValue("refs")).
+		SetSourceUrl(c.GetStringFlagValue("source-url")).SetThreads(threads)
+	if c.GetNumberOfArgs() == 1 {
+		gitLfsMigrateCmd.SetGitPath(c.GetArgumentAt(0))
+	}
+	gitLfsMigrateCmd.SetDryRun(c.GetBoolFlagValue("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(gitLfsMigrateCmd)
+	result := gitLfsMigrateCmd.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), false, err)
+}
+
 func curlCmd(c *components.Context) error {
 	if show, err := common.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
 		return err
@@ -1592,6 +1913,104 @@ func replicationDeleteCmd(c *components.Context) error {
 	return commands.Exec(replicationDeleteCmd)
 }
 
+func replicationUpdateCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	replicationUpdateCmd := replication.NewReplicationUpdateCommand()
+	replicationUpdateCmd.SetTemplatePath(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetVars(c.GetStringFlagValue("vars"))
+	return commands.Exec(replicationUpdateCmd)
+}
+
+func replicationExportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	replicationExportCmd := replication.NewReplicationExportCommand()
+	replicationExportCmd.SetRepoPattern(c.GetArgumentAt(0)).SetTargetDir(c.GetArgumentAt(1)).SetServerDetails(rtDetails)
+	return commands.Exec(replicationExportCmd)
+}
+
+func replicationStatusCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := replication.GetStatusFormat(c.GetStringFlagValue("format"))
+	if err != nil {
+		return err
+	}
+	var failIfLagging time.Duration
+	if c.GetStringFlagValue("fail-if-lagging") != "" {
+		if failIfLagging, err = time.ParseDuration(c.GetStringFlagValue("fail-if-lagging")); err != nil || failIfLagging <= 0 {
+			return errors.New("The '--fail-if-lagging' option should have a positive duration value, for example '30m' or '2h'. " + common.GetDocumentationMessage())
+		}
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	replicationStatusCmd := replication.NewReplicationStatusCommand().SetServerDetails(rtDetails).SetFormat(format).SetFailIfLagging(failIfLagging)
+	if c.GetNumberOfArgs() == 1 {
+		replicationStatusCmd.SetRepoPattern(c.GetArgumentAt(0))
+	}
+	return commands.Exec(replicationStatusCmd)
+}
+
+func permissionTargetExportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	var names []string
+	if c.GetStringFlagValue("names") != "" {
+		names = strings.Split(c.GetStringFlagValue("names"), ",")
+	}
+	permissionTargetExportCmd := permissiontarget.NewPermissionTargetExportCommand()
+	permissionTargetExportCmd.SetTargetDir(c.GetArgumentAt(0)).SetNames(names).SetServerDetails(rtDetails)
+	return commands.Exec(permissionTargetExportCmd)
+}
+
+func permissionTargetDiffCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	permissionTargetDiffCmd := permissiontarget.NewPermissionTargetDiffCommand()
+	permissionTargetDiffCmd.SetTemplatePath(c.GetArgumentAt(0)).SetServerDetails(rtDetails).SetVars(c.GetStringFlagValue("vars"))
+	return commands.Exec(permissionTargetDiffCmd)
+}
+
+func accessReportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 0 {
+		return common.PrintHelpAndReturnError("No arguments should be sent.", c)
+	}
+	format, err := permissiontarget.GetAccessReportFormat(c.GetStringFlagValue("format"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	accessReportCmd := permissiontarget.NewAccessReportCommand().SetServerDetails(rtDetails).SetFormat(format).
+		SetUser(c.GetStringFlagValue("for-user")).SetGroup(c.GetStringFlagValue("for-group")).SetRepo(c.GetStringFlagValue("for-repo"))
+	return commands.Exec(accessReportCmd)
+}
+
 func createDefaultCopyMoveSpec(c *components.Context) (*spec.SpecFiles, error) {
 	offset, limit, err := getOffsetAndLimitValues(c)
 	if err != nil {
@@ -1760,6 +2179,7 @@ func createGitLfsCleanConfiguration(c *components.Context) (gitLfsCleanConfigura
 
 	gitLfsCleanConfiguration.Repo = c.GetStringFlagValue("repo")
 	gitLfsCleanConfiguration.Quiet = common.GetQuietValue(c)
+	gitLfsCleanConfiguration.Report = c.GetBoolFlagValue("report")
 	dotGitPath := ""
 	if c.GetNumberOfArgs() == 1 {
 		dotGitPath = c.GetArgumentAt(0)
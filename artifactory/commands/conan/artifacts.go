@@ -66,6 +66,17 @@ func (ac *ArtifactCollector) CollectArtifactsForPath(exactPath string) ([]entiti
 	return ac.searchArtifacts(query)
 }
 
+// CollectArtifactsForFiles collects the artifacts with the given exact paths (path/name) in a single search.
+func (ac *ArtifactCollector) CollectArtifactsForFiles(filePaths []string) ([]entities.Artifact, error) {
+	if ac.serverDetails == nil {
+		return nil, fmt.Errorf("server details not initialized")
+	}
+	if len(filePaths) == 0 {
+		return nil, nil
+	}
+	return ac.searchArtifacts(buildFilesQuery(ac.targetRepo, filePaths))
+}
+
 // searchArtifacts executes an AQL query and returns matching artifacts.
 func (ac *ArtifactCollector) searchArtifacts(aqlQuery string) ([]entities.Artifact, error) {
 	servicesManager, err := utils.CreateServiceManager(ac.serverDetails, -1, 0, false)
@@ -172,6 +183,15 @@ func buildArtifactQuery(repo string, pkg *ConanPackageInfo) string {
 		repo, pkg.User, pkg.Name, pkg.Version, pkg.Channel)
 }
 
+// buildFilesQuery creates an AQL query matching any of the given exact file paths in the repository.
+func buildFilesQuery(repo string, filePaths []string) string {
+	criteria := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		criteria = append(criteria, fmt.Sprintf(`{"$and": [{"path": "%s"}, {"name": "%s"}]}`, path.Dir(filePath), path.Base(filePath)))
+	}
+	return fmt.Sprintf(`{"repo": "%s", "$or": [%s]}`, repo, strings.Join(criteria, ", "))
+}
+
 // BuildPropertySetter sets build properties on Conan artifacts in Artifactory.
 // This is required to link artifacts to build info in Artifactory UI.
 type BuildPropertySetter struct {
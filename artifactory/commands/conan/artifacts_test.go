@@ -150,6 +150,16 @@ func TestBuildArtifactQuery(t *testing.T) {
 	}
 }
 
+func TestBuildFilesQuery(t *testing.T) {
+	query := buildFilesQuery("conan-remote", []string{
+		"_/zlib/1.3.1/_/f52e03ae/package/b647c43b/0d9a7a7f/conan_package.tgz",
+		"acme/gtest/1.14.0/stable/4c5d6e7f/export/conanmanifest.txt",
+	})
+	assert.Equal(t, `{"repo": "conan-remote", "$or": [`+
+		`{"$and": [{"path": "_/zlib/1.3.1/_/f52e03ae/package/b647c43b/0d9a7a7f"}, {"name": "conan_package.tgz"}]}, `+
+		`{"$and": [{"path": "acme/gtest/1.14.0/stable/4c5d6e7f/export"}, {"name": "conanmanifest.txt"}]}]}`, query)
+}
+
 func TestBuildPropertySetter_FormatBuildProperties(t *testing.T) {
 	tests := []struct {
 		name        string
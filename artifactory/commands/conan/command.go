@@ -65,6 +65,7 @@ var commandsNeedingRemoteAccess = []string{
 	"upload",
 	"search",
 	"list",
+	"graph",
 }
 
 // needsRemoteAccess checks if a command might need remote access.
@@ -97,6 +98,11 @@ func (c *ConanCommand) Run() error {
 		return c.runUploadCommand()
 	}
 
+	// Graph info resolves the dependencies and their binaries without building
+	if isGraphInfoCommand(c.commandName, c.args) {
+		return c.runGraphInfoCommand()
+	}
+
 	// Run other Conan commands
 	return c.runConanCommand()
 }
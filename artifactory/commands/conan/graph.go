@@ -0,0 +1,550 @@
+package conan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// conan.lock is used by Conan when it exists next to the conanfile and no --lockfile is set.
+	defaultLockfileName = "conan.lock"
+	// Settings of the host profile recorded as properties of the build-info module.
+	settingsPropertyPrefix = "conan.settings."
+)
+
+// Key settings of the host profile, which identify the binaries of the build.
+var keySettings = []string{"os", "arch", "compiler", "compiler.version", "compiler.libcxx", "compiler.cppstd", "build_type"}
+
+// ConanGraphInfoOutput represents the output of 'conan graph info --format=json'.
+type ConanGraphInfoOutput struct {
+	Graph struct {
+		Nodes map[string]ConanGraphInfoNode `json:"nodes"`
+	} `json:"graph"`
+}
+
+// ConanGraphInfoNode is a recipe in the graph, along with the binary package resolved for it.
+type ConanGraphInfoNode struct {
+	Ref             string                            `json:"ref"`
+	Name            string                            `json:"name"`
+	Version         string                            `json:"version"`
+	User            string                            `json:"user"`
+	Channel         string                            `json:"channel"`
+	Context         string                            `json:"context"`
+	RecipeRevision  string                            `json:"rrev"`
+	PackageId       string                            `json:"package_id"`
+	PackageRevision string                            `json:"prev"`
+	Binary          string                            `json:"binary"`
+	Remote          string                            `json:"remote"`
+	BinaryRemote    string                            `json:"binary_remote"`
+	Settings        map[string]string                 `json:"settings"`
+	Dependencies    map[string]ConanGraphInfoNodeEdge `json:"dependencies"`
+}
+
+// ConanGraphInfoNodeEdge is a requirement of a node, keyed by the ID of the required node.
+type ConanGraphInfoNodeEdge struct {
+	Ref    string `json:"ref"`
+	Direct bool   `json:"direct"`
+	Build  bool   `json:"build"`
+	Test   bool   `json:"test"`
+}
+
+// ConanLockfile represents the content of a Conan 2.x lockfile.
+type ConanLockfile struct {
+	Version        string   `json:"version"`
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+}
+
+// The root node of the graph is the consumer conanfile.
+const graphRootNodeId = "0"
+
+// isGraphInfoCommand checks if the command is 'conan graph info'.
+func isGraphInfoCommand(commandName string, args []string) bool {
+	return commandName == "graph" && len(args) > 0 && args[0] == "info"
+}
+
+// runGraphInfoCommand runs 'conan graph info' and collects the dependencies of the resolved graph into the build-info.
+// The graph is resolved without building, so the build-info is collected without re-running the build.
+func (c *ConanCommand) runGraphInfoCommand() error {
+	log.Info(fmt.Sprintf("Running Conan %s %s", c.commandName, c.args[0]))
+	if c.buildConfiguration == nil {
+		if err := gofrogcmd.RunCmd(c); err != nil {
+			return fmt.Errorf("conan %s failed: %w", c.commandName, err)
+		}
+		return nil
+	}
+	if format := getFormatFlagValue(c.args); format != "json" {
+		return c.runGraphInfoWithRenderedFormat(format)
+	}
+	var graphJson []byte
+	if outFile := extractOutFilePath(c.args); outFile != "" {
+		if err := gofrogcmd.RunCmd(c); err != nil {
+			return fmt.Errorf("conan %s failed: %w", c.commandName, err)
+		}
+		data, err := os.ReadFile(outFile)
+		if err != nil {
+			return fmt.Errorf("could not read graph info output file %s: %w", outFile, err)
+		}
+		graphJson = data
+	} else {
+		output, err := gofrogcmd.RunCmdOutput(c)
+		if err != nil {
+			return fmt.Errorf("conan %s failed: %w", c.commandName, err)
+		}
+		graphJson = []byte(output)
+	}
+	return c.processGraphInfoJSON(graphJson)
+}
+
+// runGraphInfoWithRenderedFormat resolves the graph once in the JSON format, for the build-info collection,
+// and renders the output in the format requested by the user.
+func (c *ConanCommand) runGraphInfoWithRenderedFormat(format string) error {
+	if format == "" {
+		format = graphFormatText
+	}
+	log.Debug(fmt.Sprintf("Running conan graph info with --format=json and rendering the %s format for build info collection", format))
+	jsonCmd := *c
+	jsonCmd.args = append(removeFlags(c.args, "--format", "-f", "--out-file", "--filter", "--package-filter"), "--format=json")
+	output, err := gofrogcmd.RunCmdOutput(&jsonCmd)
+	if err != nil {
+		return fmt.Errorf("conan %s failed: %w", c.commandName, err)
+	}
+	rendered, err := renderGraphInfo([]byte(output), format, getGraphInfoFilters(c.args))
+	if err != nil {
+		return err
+	}
+	if outFile := extractOutFilePath(c.args); outFile != "" {
+		if err = os.WriteFile(outFile, []byte(rendered), 0644); err != nil {
+			return fmt.Errorf("could not write graph info output file %s: %w", outFile, err)
+		}
+	} else {
+		log.Output(strings.TrimSuffix(rendered, "\n"))
+	}
+	return c.processGraphInfoJSON([]byte(output))
+}
+
+// getFormatFlagValue returns the value of --format or -f, or "" if absent.
+func getFormatFlagValue(args []string) string {
+	format := ""
+	for i, arg := range args {
+		format = extractFlagValue(arg, i, args, "--format", format)
+		format = extractFlagValue(arg, i, args, "-f", format)
+	}
+	return format
+}
+
+// removeFlags returns the args without the given flags and their values, set either as --flag=value or as --flag value.
+func removeFlags(args []string, flags ...string) []string {
+	var result []string
+	for i := 0; i < len(args); i++ {
+		removed := false
+		for _, flag := range flags {
+			if args[i] == flag {
+				// Skip the value too.
+				i++
+				removed = true
+				break
+			}
+			if strings.HasPrefix(args[i], flag+"=") {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, args[i])
+		}
+	}
+	return result
+}
+
+// processGraphInfoJSON creates the build-info from the graph info JSON and the lockfile, and saves it locally.
+func (c *ConanCommand) processGraphInfoJSON(graphJson []byte) error {
+	buildName, buildNumber, _ := c.getBuildNameAndNumber()
+	if buildName == "" || buildNumber == "" {
+		return nil
+	}
+	var graph ConanGraphInfoOutput
+	if err := json.Unmarshal(graphJson, &graph); err != nil {
+		return fmt.Errorf("could not parse graph info JSON output: %w", err)
+	}
+	lockfilePath := c.getLockfilePath()
+	var lockfile *ConanLockfile
+	if lockfilePath != "" {
+		var err error
+		if lockfile, err = readLockfile(lockfilePath); err != nil {
+			return err
+		}
+	}
+	collector := newGraphDependencyCollector(&graph, lockfile)
+	if lockfilePath != "" {
+		collector.lockfileName = filepath.Base(lockfilePath)
+	}
+	collector.checksumResolver = c.newPackageChecksumResolver()
+	module, err := collector.createModule(c.buildConfiguration.GetModule())
+	if err != nil {
+		return err
+	}
+	buildInfo := &entities.BuildInfo{
+		Name:       buildName,
+		Number:     buildNumber,
+		Started:    time.Now().Format(entities.TimeFormat),
+		BuildAgent: &entities.Agent{Name: "Conan"},
+		Modules:    []entities.Module{*module},
+	}
+	if err = saveBuildInfoLocally(buildInfo, c.buildConfiguration.GetProject()); err != nil {
+		return fmt.Errorf("failed to save build info: %w", err)
+	}
+	log.Info(fmt.Sprintf("Conan build info collected with %d dependencies. Use 'jf rt bp %s %s' to publish it.", len(module.Dependencies), buildName, buildNumber))
+	return nil
+}
+
+// getLockfilePath returns the lockfile set by --lockfile, or conan.lock next to the conanfile if it exists.
+func (c *ConanCommand) getLockfilePath() string {
+	for i, arg := range c.args {
+		if value := extractFlagValue(arg, i, c.args, "--lockfile", ""); value != "" {
+			if !filepath.IsAbs(value) {
+				value = filepath.Join(c.workingDir, value)
+			}
+			return value
+		}
+	}
+	recipeDir := extractRecipePathFromArgs(c.workingDir, c.args[1:])
+	if recipeDir == "" {
+		recipeDir = c.workingDir
+	}
+	defaultLockfile := filepath.Join(recipeDir, defaultLockfileName)
+	if _, err := os.Stat(defaultLockfile); err != nil {
+		return ""
+	}
+	return defaultLockfile
+}
+
+func readLockfile(lockfilePath string) (*ConanLockfile, error) {
+	data, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read lockfile %s: %w", lockfilePath, err)
+	}
+	var lockfile ConanLockfile
+	if err = json.Unmarshal(data, &lockfile); err != nil {
+		return nil, fmt.Errorf("could not parse lockfile %s: %w", lockfilePath, err)
+	}
+	return &lockfile, nil
+}
+
+// packageChecksumResolver returns the checksums of the binary packages of the nodes, or of their recipes if the package IDs are unknown.
+// The checksums are keyed by the package references. Nodes without a checksum are omitted.
+type packageChecksumResolver func(nodes []*ConanGraphInfoNode) map[string]entities.Checksum
+
+// graphDependencyCollector creates build-info dependencies from the nodes of a Conan graph.
+type graphDependencyCollector struct {
+	graph    *ConanGraphInfoOutput
+	lockfile *ConanLockfile
+	// The name of the lockfile, recorded as a property of the module.
+	lockfileName string
+	// Optional, to add the checksums of the packages in Artifactory to the dependencies.
+	checksumResolver packageChecksumResolver
+}
+
+func newGraphDependencyCollector(graph *ConanGraphInfoOutput, lockfile *ConanLockfile) *graphDependencyCollector {
+	return &graphDependencyCollector{graph: graph, lockfile: lockfile}
+}
+
+// createModule creates the module of the consumer conanfile, with a dependency for each of the resolved packages.
+// If moduleId is empty, the module ID is taken from the root node of the graph.
+func (gdc *graphDependencyCollector) createModule(moduleId string) (*entities.Module, error) {
+	root, ok := gdc.graph.Graph.Nodes[graphRootNodeId]
+	if !ok {
+		return nil, fmt.Errorf("the graph info output has no root node")
+	}
+	if moduleId == "" {
+		moduleId = getModuleId(&root)
+	}
+	requestedBy := gdc.getRequestedBy(moduleId)
+	lockedRefs := gdc.getLockedRecipeRefs()
+	var nodeIds []string
+	var nodes []*ConanGraphInfoNode
+	for _, nodeId := range gdc.getSortedNodeIds() {
+		if nodeId != graphRootNodeId {
+			node := gdc.graph.Graph.Nodes[nodeId]
+			nodeIds = append(nodeIds, nodeId)
+			nodes = append(nodes, &node)
+		}
+	}
+	var checksums map[string]entities.Checksum
+	if gdc.checksumResolver != nil {
+		checksums = gdc.checksumResolver(nodes)
+	}
+	var dependencies []entities.Dependency
+	var unlocked []string
+	for i, node := range nodes {
+		dependency := entities.Dependency{
+			Id:          getPackageReference(node),
+			Scopes:      gdc.getScopes(nodeIds[i], node),
+			RequestedBy: requestedBy[nodeIds[i]],
+			Checksum:    checksums[getPackageReference(node)],
+		}
+		dependencies = append(dependencies, dependency)
+		if lockedRefs != nil && !lockedRefs[getRecipeReference(node)] {
+			unlocked = append(unlocked, getRecipeReference(node))
+		}
+	}
+	properties := getSettingsProperties(gdc.getRootSettings(&root))
+	if gdc.lockfileName != "" {
+		properties["conan.lockfile"] = gdc.lockfileName
+	}
+	if len(unlocked) > 0 {
+		log.Warn(fmt.Sprintf("The following recipes were resolved, but are missing from the lockfile: %s", strings.Join(unlocked, ", ")))
+	}
+	return &entities.Module{
+		Id:           moduleId,
+		Type:         entities.Conan,
+		Properties:   properties,
+		Dependencies: dependencies,
+	}, nil
+}
+
+// Node IDs are numeric strings, sorted by their numeric value to keep the graph order.
+func (gdc *graphDependencyCollector) getSortedNodeIds() []string {
+	nodeIds := make([]string, 0, len(gdc.graph.Graph.Nodes))
+	for nodeId := range gdc.graph.Graph.Nodes {
+		nodeIds = append(nodeIds, nodeId)
+	}
+	sort.Slice(nodeIds, func(i, j int) bool {
+		if len(nodeIds[i]) != len(nodeIds[j]) {
+			return len(nodeIds[i]) < len(nodeIds[j])
+		}
+		return nodeIds[i] < nodeIds[j]
+	})
+	return nodeIds
+}
+
+// Return the IDs of the dependents of each node, up to the root module.
+func (gdc *graphDependencyCollector) getRequestedBy(moduleId string) map[string][][]string {
+	requestedBy := make(map[string][][]string)
+	for _, parentId := range gdc.getSortedNodeIds() {
+		parent := gdc.graph.Graph.Nodes[parentId]
+		parentRef := moduleId
+		if parentId != graphRootNodeId {
+			parentRef = getPackageReference(&parent)
+		}
+		childIds := make([]string, 0, len(parent.Dependencies))
+		for childId := range parent.Dependencies {
+			childIds = append(childIds, childId)
+		}
+		sort.Strings(childIds)
+		for _, childId := range childIds {
+			requestedBy[childId] = append(requestedBy[childId], []string{parentRef})
+		}
+	}
+	return requestedBy
+}
+
+// A dependency is in the build scope if it's required as a tool or resolved in the build context, and in the test scope
+// if it's only required as a test requirement.
+func (gdc *graphDependencyCollector) getScopes(nodeId string, node *ConanGraphInfoNode) []string {
+	if node.Context == "build" {
+		return []string{"build"}
+	}
+	isTest := false
+	for _, parent := range gdc.graph.Graph.Nodes {
+		if edge, ok := parent.Dependencies[nodeId]; ok {
+			if edge.Build {
+				return []string{"build"}
+			}
+			if !edge.Test {
+				return []string{"runtime"}
+			}
+			isTest = true
+		}
+	}
+	if isTest {
+		return []string{"test"}
+	}
+	return []string{"runtime"}
+}
+
+// Return the recipe references with revisions (name/version[@user/channel]#rrev) of the lockfile.
+// Return nil if no lockfile is used.
+func (gdc *graphDependencyCollector) getLockedRecipeRefs() map[string]bool {
+	if gdc.lockfile == nil {
+		return nil
+	}
+	lockedRefs := make(map[string]bool)
+	for _, refs := range [][]string{gdc.lockfile.Requires, gdc.lockfile.BuildRequires, gdc.lockfile.PythonRequires} {
+		for _, ref := range refs {
+			// Locked references are suffixed by the revision timestamp: name/version#rrev%timestamp
+			ref, _, _ = strings.Cut(ref, "%")
+			lockedRefs[ref] = true
+		}
+	}
+	return lockedRefs
+}
+
+// Return the recipe reference with its revision: name/version[@user/channel]#rrev
+func getRecipeReference(node *ConanGraphInfoNode) string {
+	ref := node.Name + "/" + node.Version
+	if node.Name == "" {
+		// Older Conan 2 versions don't output the reference fields.
+		ref, _, _ = strings.Cut(node.Ref, "#")
+	}
+	if node.User != "" || node.Channel != "" {
+		ref += "@" + orUnderscore(node.User) + "/" + orUnderscore(node.Channel)
+	}
+	if node.RecipeRevision != "" {
+		ref += "#" + node.RecipeRevision
+	}
+	return ref
+}
+
+// Return the full package reference of the binary: name/version[@user/channel]#rrev:package_id#prev
+func getPackageReference(node *ConanGraphInfoNode) string {
+	ref := getRecipeReference(node)
+	if node.PackageId != "" {
+		ref += ":" + node.PackageId
+		if node.PackageRevision != "" {
+			ref += "#" + node.PackageRevision
+		}
+	}
+	return ref
+}
+
+// Return the module ID of the consumer conanfile, in the format used by the Conan build-info collection.
+func getModuleId(root *ConanGraphInfoNode) string {
+	switch {
+	case root.Name == "":
+		return "unknown"
+	case root.Version == "":
+		return root.Name
+	case root.User != "" && root.Channel != "":
+		return fmt.Sprintf("%s/%s@%s/%s", root.Name, root.Version, root.User, root.Channel)
+	}
+	return fmt.Sprintf("%s:%s", root.Name, root.Version)
+}
+
+// getRootSettings returns the settings of the roots of the graph. When the root node is a virtual consumer without settings,
+// as with 'conan graph info --requires', the roots are the host packages it requires directly.
+// Settings which differ between the roots are joined with commas.
+func (gdc *graphDependencyCollector) getRootSettings(root *ConanGraphInfoNode) map[string][]string {
+	roots := []*ConanGraphInfoNode{root}
+	if len(root.Settings) == 0 {
+		roots = nil
+		for _, nodeId := range gdc.getSortedNodeIds() {
+			node := gdc.graph.Graph.Nodes[nodeId]
+			if edge, ok := root.Dependencies[nodeId]; ok && edge.Direct && !edge.Build && node.Context != "build" {
+				roots = append(roots, &node)
+			}
+		}
+	}
+	settings := make(map[string][]string)
+	for _, node := range roots {
+		for key, value := range node.Settings {
+			if value != "" && !slices.Contains(settings[key], value) {
+				settings[key] = append(settings[key], value)
+			}
+		}
+	}
+	return settings
+}
+
+func getSettingsProperties(settings map[string][]string) map[string]string {
+	properties := make(map[string]string)
+	for _, key := range keySettings {
+		if values := settings[key]; len(values) > 0 {
+			properties[settingsPropertyPrefix+key] = strings.Join(values, ",")
+		}
+	}
+	return properties
+}
+
+func orUnderscore(value string) string {
+	if value == "" {
+		return "_"
+	}
+	return value
+}
+
+// newPackageChecksumResolver returns a resolver of the package checksums from the Artifactory repositories of the Conan remotes.
+// Return nil if the server details are unknown.
+func (c *ConanCommand) newPackageChecksumResolver() packageChecksumResolver {
+	if c.serverDetails == nil {
+		return nil
+	}
+	return newArtifactoryChecksumResolver(c.serverDetails, ExtractRemoteName(c.args))
+}
+
+// The checksums of all the nodes are searched with a single query per repository.
+func newArtifactoryChecksumResolver(serverDetails *config.ServerDetails, defaultRemote string) packageChecksumResolver {
+	return func(nodes []*ConanGraphInfoNode) map[string]entities.Checksum {
+		reposByRemote := make(map[string]string)
+		filesByRepo := make(map[string][]string)
+		for _, node := range nodes {
+			remote := firstNonEmpty(node.BinaryRemote, node.Remote, defaultRemote)
+			if remote == "" {
+				continue
+			}
+			repo, ok := reposByRemote[remote]
+			if !ok {
+				var err error
+				if repo, err = GetRepoNameForRemote(remote); err != nil {
+					log.Debug(fmt.Sprintf("Could not resolve the repository of remote '%s': %v", remote, err))
+				}
+				reposByRemote[remote] = repo
+			}
+			if repo != "" {
+				folder, fileName := getChecksumFile(node)
+				filesByRepo[repo] = append(filesByRepo[repo], folder+"/"+fileName)
+			}
+		}
+		checksumsByFile := make(map[string]entities.Checksum)
+		for repo, filePaths := range filesByRepo {
+			artifacts, err := NewArtifactCollector(serverDetails, repo).CollectArtifactsForFiles(filePaths)
+			if err != nil {
+				log.Debug(fmt.Sprintf("Could not collect the checksums of the packages in %s: %v", repo, err))
+				continue
+			}
+			for _, artifact := range artifacts {
+				checksumsByFile[artifact.Path] = artifact.Checksum
+			}
+		}
+		checksums := make(map[string]entities.Checksum)
+		for _, node := range nodes {
+			folder, fileName := getChecksumFile(node)
+			if checksum, found := checksumsByFile[folder+"/"+fileName]; found {
+				checksums[getPackageReference(node)] = checksum
+			}
+		}
+		return checksums
+	}
+}
+
+// Return the folder and the file in Artifactory which identify the binary package, or the recipe if the package ID is unknown.
+//   - Recipe: {user}/{name}/{version}/{channel}/{rrev}/export/conanmanifest.txt
+//   - Package: {user}/{name}/{version}/{channel}/{rrev}/package/{package_id}/{prev}/conan_package.tgz
+func getChecksumFile(node *ConanGraphInfoNode) (folder, fileName string) {
+	recipeFolder := fmt.Sprintf("%s/%s/%s/%s/%s", orUnderscore(node.User), node.Name, node.Version, orUnderscore(node.Channel), node.RecipeRevision)
+	if node.PackageId == "" || node.PackageRevision == "" {
+		return recipeFolder + "/export", "conanmanifest.txt"
+	}
+	return fmt.Sprintf("%s/package/%s/%s", recipeFolder, node.PackageId, node.PackageRevision), "conan_package.tgz"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,206 @@
+package conan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGraphInfoJSON = `{
+  "graph": {
+    "nodes": {
+      "0": {
+        "ref": "app/1.0",
+        "name": "app",
+        "version": "1.0",
+        "context": "host",
+        "settings": {"os": "Linux", "arch": "x86_64", "compiler": "gcc", "compiler.version": "13", "build_type": "Release", "compiler.libcxx": "libstdc++11"},
+        "dependencies": {
+          "1": {"ref": "zlib/1.3.1", "direct": true},
+          "2": {"ref": "cmake/3.28.1", "direct": true, "build": true},
+          "3": {"ref": "gtest/1.14.0", "direct": true, "test": true}
+        }
+      },
+      "1": {
+        "ref": "zlib/1.3.1#f52e03ae3d251dec704634230cd806a2",
+        "name": "zlib",
+        "version": "1.3.1",
+        "context": "host",
+        "rrev": "f52e03ae3d251dec704634230cd806a2",
+        "package_id": "b647c43bfefae3f830561ca202b6cfd935b56205",
+        "prev": "0d9a7a7fb4c4d3e7bd5d1a0e5b5f8a31",
+        "remote": "conan-remote"
+      },
+      "2": {
+        "ref": "cmake/3.28.1#2ac4d1e4a8b6a5f7c4a2b5e9e0f1c3d4",
+        "name": "cmake",
+        "version": "3.28.1",
+        "context": "build",
+        "rrev": "2ac4d1e4a8b6a5f7c4a2b5e9e0f1c3d4",
+        "package_id": "63fead0844576fc02943e16909f08fcdddd6f44b",
+        "prev": "5e5f0d1f2c7b4a1e9f3d2c8b7a6e5d4c"
+      },
+      "3": {
+        "ref": "gtest/1.14.0@acme/stable#4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f",
+        "name": "gtest",
+        "version": "1.14.0",
+        "user": "acme",
+        "channel": "stable",
+        "context": "host",
+        "rrev": "4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f",
+        "package_id": "9a4eb3c8701508aa9458b1a73d0633783ecc2270",
+        "dependencies": {
+          "1": {"ref": "zlib/1.3.1"}
+        }
+      }
+    }
+  }
+}`
+
+func parseTestGraph(t *testing.T) *ConanGraphInfoOutput {
+	var graph ConanGraphInfoOutput
+	require.NoError(t, json.Unmarshal([]byte(testGraphInfoJSON), &graph))
+	return &graph
+}
+
+func TestIsGraphInfoCommand(t *testing.T) {
+	assert.True(t, isGraphInfoCommand("graph", []string{"info", "."}))
+	assert.False(t, isGraphInfoCommand("graph", []string{"build-order", "."}))
+	assert.False(t, isGraphInfoCommand("graph", nil))
+	assert.False(t, isGraphInfoCommand("install", []string{"info"}))
+}
+
+func TestGetFormatFlagValue(t *testing.T) {
+	assert.Equal(t, "json", getFormatFlagValue([]string{"info", ".", "--format=json"}))
+	assert.Equal(t, "html", getFormatFlagValue([]string{"info", ".", "-f", "html"}))
+	assert.Equal(t, "dot", getFormatFlagValue([]string{"info", "--format", "dot", "."}))
+	assert.Empty(t, getFormatFlagValue([]string{"info", "."}))
+}
+
+func TestRemoveFlags(t *testing.T) {
+	args := []string{"info", ".", "--format", "html", "--out-file=graph.html", "-s", "build_type=Debug"}
+	assert.Equal(t, []string{"info", ".", "-s", "build_type=Debug"}, removeFlags(args, "--format", "-f", "--out-file"))
+	assert.Equal(t, []string{"info", "."}, removeFlags([]string{"info", "-f=dot", "."}, "--format", "-f"))
+}
+
+func TestGraphDependencyCollector_CreateModule(t *testing.T) {
+	collector := newGraphDependencyCollector(parseTestGraph(t), nil)
+	resolverCalls := 0
+	collector.checksumResolver = func(nodes []*ConanGraphInfoNode) map[string]entities.Checksum {
+		resolverCalls++
+		assert.Len(t, nodes, 3)
+		checksums := make(map[string]entities.Checksum)
+		for _, node := range nodes {
+			if node.Name == "zlib" {
+				checksums[getPackageReference(node)] = entities.Checksum{Sha1: "sha1", Md5: "md5", Sha256: "sha256"}
+			}
+		}
+		return checksums
+	}
+	module, err := collector.createModule("")
+	require.NoError(t, err)
+	// The checksums of all the nodes are resolved at once.
+	assert.Equal(t, 1, resolverCalls)
+
+	assert.Equal(t, "app:1.0", module.Id)
+	assert.Equal(t, entities.Conan, module.Type)
+	assert.Equal(t, map[string]string{
+		"conan.settings.os":               "Linux",
+		"conan.settings.arch":             "x86_64",
+		"conan.settings.compiler":         "gcc",
+		"conan.settings.compiler.version": "13",
+		"conan.settings.compiler.libcxx":  "libstdc++11",
+		"conan.settings.build_type":       "Release",
+	}, module.Properties)
+
+	require.Len(t, module.Dependencies, 3)
+	zlib := module.Dependencies[0]
+	assert.Equal(t, "zlib/1.3.1#f52e03ae3d251dec704634230cd806a2:b647c43bfefae3f830561ca202b6cfd935b56205#0d9a7a7fb4c4d3e7bd5d1a0e5b5f8a31", zlib.Id)
+	assert.Equal(t, []string{"runtime"}, zlib.Scopes)
+	assert.Equal(t, "sha256", zlib.Sha256)
+	gtestId := "gtest/1.14.0@acme/stable#4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f:9a4eb3c8701508aa9458b1a73d0633783ecc2270"
+	assert.Equal(t, [][]string{{"app:1.0"}, {gtestId}}, zlib.RequestedBy)
+
+	cmake := module.Dependencies[1]
+	assert.Equal(t, []string{"build"}, cmake.Scopes)
+	assert.Empty(t, cmake.Sha1)
+
+	gtest := module.Dependencies[2]
+	assert.Equal(t, gtestId, gtest.Id)
+	assert.Equal(t, []string{"test"}, gtest.Scopes)
+}
+
+func TestGraphDependencyCollector_CreateModuleWithLockfile(t *testing.T) {
+	lockfile := &ConanLockfile{
+		Version:       "0.5",
+		Requires:      []string{"zlib/1.3.1#f52e03ae3d251dec704634230cd806a2%1702683583.3411012"},
+		BuildRequires: []string{"cmake/3.28.1#2ac4d1e4a8b6a5f7c4a2b5e9e0f1c3d4%1702683584.1"},
+	}
+	collector := newGraphDependencyCollector(parseTestGraph(t), lockfile)
+	collector.lockfileName = "conan.lock"
+	assert.Equal(t, map[string]bool{
+		"zlib/1.3.1#f52e03ae3d251dec704634230cd806a2":   true,
+		"cmake/3.28.1#2ac4d1e4a8b6a5f7c4a2b5e9e0f1c3d4": true,
+	}, collector.getLockedRecipeRefs())
+
+	module, err := collector.createModule("my-module")
+	require.NoError(t, err)
+	assert.Equal(t, "my-module", module.Id)
+	assert.Equal(t, "conan.lock", module.Properties.(map[string]string)["conan.lockfile"])
+	assert.Equal(t, [][]string{{"my-module"}}, module.Dependencies[1].RequestedBy)
+}
+
+func TestGraphDependencyCollector_MissingRoot(t *testing.T) {
+	_, err := newGraphDependencyCollector(&ConanGraphInfoOutput{}, nil).createModule("")
+	assert.ErrorContains(t, err, "no root node")
+}
+
+func TestGetChecksumFile(t *testing.T) {
+	node := &ConanGraphInfoNode{Name: "zlib", Version: "1.3.1", RecipeRevision: "rrev", PackageId: "pkgid", PackageRevision: "prev"}
+	folder, fileName := getChecksumFile(node)
+	assert.Equal(t, "_/zlib/1.3.1/_/rrev/package/pkgid/prev", folder)
+	assert.Equal(t, "conan_package.tgz", fileName)
+
+	node = &ConanGraphInfoNode{Name: "gtest", Version: "1.14.0", User: "acme", Channel: "stable", RecipeRevision: "rrev"}
+	folder, fileName = getChecksumFile(node)
+	assert.Equal(t, "acme/gtest/1.14.0/stable/rrev/export", folder)
+	assert.Equal(t, "conanmanifest.txt", fileName)
+}
+
+func TestConanCommand_GetLockfilePath(t *testing.T) {
+	workingDir := t.TempDir()
+	command := &ConanCommand{workingDir: workingDir, args: []string{"info", "."}}
+	assert.Empty(t, command.getLockfilePath())
+
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, defaultLockfileName), []byte("{}"), 0644))
+	assert.Equal(t, filepath.Join(workingDir, defaultLockfileName), command.getLockfilePath())
+
+	command.args = []string{"info", ".", "--lockfile", "locks/release.lock"}
+	assert.Equal(t, filepath.Join(workingDir, "locks", "release.lock"), command.getLockfilePath())
+}
+
+func TestGraphDependencyCollector_SettingsOfMultipleRoots(t *testing.T) {
+	graph := &ConanGraphInfoOutput{}
+	graph.Graph.Nodes = map[string]ConanGraphInfoNode{
+		"0": {Ref: "cli", Dependencies: map[string]ConanGraphInfoNodeEdge{
+			"1": {Ref: "zlib/1.3.1", Direct: true},
+			"2": {Ref: "openssl/3.2.0", Direct: true},
+			"3": {Ref: "cmake/3.28.1", Direct: true, Build: true},
+		}},
+		"1": {Name: "zlib", Version: "1.3.1", Context: "host", Settings: map[string]string{"os": "Linux", "arch": "x86_64", "build_type": "Release"}},
+		"2": {Name: "openssl", Version: "3.2.0", Context: "host", Settings: map[string]string{"os": "Linux", "arch": "x86_64", "build_type": "Debug"}},
+		"3": {Name: "cmake", Version: "3.28.1", Context: "build", Settings: map[string]string{"os": "Macos", "arch": "armv8"}},
+	}
+	module, err := newGraphDependencyCollector(graph, nil).createModule("my-module")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"conan.settings.os":         "Linux",
+		"conan.settings.arch":       "x86_64",
+		"conan.settings.build_type": "Release,Debug",
+	}, module.Properties)
+}
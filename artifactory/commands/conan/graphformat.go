@@ -0,0 +1,263 @@
+package conan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/gofrog/stringutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Formats of 'conan graph info' which are rendered from its JSON output, so the graph is resolved only once.
+const (
+	graphFormatText = "text"
+	graphFormatDot  = "dot"
+	graphFormatHtml = "html"
+)
+
+// jsonField is a field of a JSON object. Objects are decoded as ordered fields, to render them in the order Conan outputs them.
+type jsonField struct {
+	key   string
+	value any
+}
+
+// graphInfoFilters holds the --filter and --package-filter values of 'conan graph info'.
+// Conan applies them to the JSON output too, so they are removed from the JSON command and applied when rendering.
+type graphInfoFilters struct {
+	fields   []string
+	packages []string
+}
+
+func getGraphInfoFilters(args []string) (filters graphInfoFilters) {
+	for i, arg := range args {
+		if value := extractFlagValue(arg, i, args, "--filter", ""); value != "" {
+			filters.fields = append(filters.fields, value)
+		}
+		if value := extractFlagValue(arg, i, args, "--package-filter", ""); value != "" {
+			filters.packages = append(filters.packages, value)
+		}
+	}
+	return
+}
+
+// renderGraphInfo renders the graph info JSON output in the given format, the way 'conan graph info' does.
+func renderGraphInfo(graphJson []byte, format string, filters graphInfoFilters) (string, error) {
+	nodes, err := decodeGraphNodes(graphJson)
+	if err != nil {
+		return "", err
+	}
+	if nodes, err = filterGraphNodes(nodes, filters.packages); err != nil {
+		return "", err
+	}
+	switch format {
+	case graphFormatText:
+		return renderGraphText(nodes, filters.fields), nil
+	case graphFormatDot:
+		return renderGraphDot(nodes), nil
+	case graphFormatHtml:
+		return renderGraphHtml(nodes), nil
+	}
+	return "", errorutils.CheckErrorf("unsupported conan graph info format: %s", format)
+}
+
+// Decode the nodes of the graph as ordered fields, keyed by their node IDs.
+func decodeGraphNodes(graphJson []byte) ([]jsonField, error) {
+	decoder := json.NewDecoder(bytes.NewReader(graphJson))
+	decoder.UseNumber()
+	output, err := decodeOrderedJson(decoder)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("could not parse graph info JSON output: %s", err.Error())
+	}
+	graph, _ := getJsonField(output, "graph").([]jsonField)
+	nodes, _ := getJsonField(graph, "nodes").([]jsonField)
+	return nodes, nil
+}
+
+func decodeOrderedJson(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	if delim == '[' {
+		items := []any{}
+		for decoder.More() {
+			item, err := decodeOrderedJson(decoder)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = decoder.Token()
+		return items, err
+	}
+	fields := []jsonField{}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeOrderedJson(decoder)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, jsonField{key: fmt.Sprint(key), value: value})
+	}
+	_, err = decoder.Token()
+	return fields, err
+}
+
+func getJsonField(object any, key string) any {
+	fields, _ := object.([]jsonField)
+	for _, field := range fields {
+		if field.key == key {
+			return field.value
+		}
+	}
+	return nil
+}
+
+func getJsonString(object any, key string) string {
+	value, _ := getJsonField(object, key).(string)
+	return value
+}
+
+// Keep the nodes whose references match any of the package filter patterns.
+func filterGraphNodes(nodes []jsonField, packageFilters []string) ([]jsonField, error) {
+	if len(packageFilters) == 0 {
+		return nodes, nil
+	}
+	var filtered []jsonField
+	for _, node := range nodes {
+		for _, pattern := range packageFilters {
+			regExp, err := clientUtils.GetRegExp(stringutils.WildcardPatternToRegExp(pattern))
+			if err != nil {
+				return nil, err
+			}
+			if regExp.MatchString(getJsonString(node.value, "ref")) {
+				filtered = append(filtered, node)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// The text format lists the fields of each node, with nested objects indented.
+func renderGraphText(nodes []jsonField, fieldFilters []string) string {
+	var text strings.Builder
+	for _, node := range nodes {
+		text.WriteString(getJsonString(node.value, "ref") + ":\n")
+		fields, _ := node.value.([]jsonField)
+		writeTextFields(&text, fields, fieldFilters, "  ")
+	}
+	return text.String()
+}
+
+func writeTextFields(text *strings.Builder, fields []jsonField, fieldFilters []string, indent string) {
+	for _, field := range fields {
+		if len(fieldFilters) > 0 && !slices.Contains(fieldFilters, field.key) {
+			continue
+		}
+		if object, ok := field.value.([]jsonField); ok {
+			text.WriteString(indent + field.key + ":\n")
+			writeTextFields(text, object, nil, indent+"  ")
+			continue
+		}
+		text.WriteString(indent + field.key + ": " + toPythonString(field.value, false) + "\n")
+	}
+}
+
+// Values are printed the way Python prints them, as Conan does.
+func toPythonString(value any, quoted bool) string {
+	switch v := value.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case string:
+		if quoted {
+			return "'" + v + "'"
+		}
+		return v
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, toPythonString(item, true))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []jsonField:
+		items := make([]string, 0, len(v))
+		for _, field := range v {
+			items = append(items, "'"+field.key+"': "+toPythonString(field.value, true))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(value)
+}
+
+// The dot format has an edge for each direct requirement, between the node labels.
+func renderGraphDot(nodes []jsonField) string {
+	labels := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		labels[node.key] = getNodeLabel(node.value)
+	}
+	var dot strings.Builder
+	dot.WriteString("digraph {\n")
+	for _, node := range nodes {
+		dependencies, _ := getJsonField(node.value, "dependencies").([]jsonField)
+		for _, dependency := range dependencies {
+			label, found := labels[dependency.key]
+			if direct, _ := getJsonField(dependency.value, "direct").(bool); !direct || !found {
+				continue
+			}
+			dot.WriteString(fmt.Sprintf("        %q -> %q\n", labels[node.key], label))
+		}
+	}
+	dot.WriteString("}\n")
+	return dot.String()
+}
+
+func getNodeLabel(node any) string {
+	if label := getJsonString(node, "label"); label != "" {
+		return label
+	}
+	return getJsonString(node, "ref")
+}
+
+// The html format is a page with a table of the nodes and their direct requirements.
+func renderGraphHtml(nodes []jsonField) string {
+	labels := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		labels[node.key] = getNodeLabel(node.value)
+	}
+	var page strings.Builder
+	page.WriteString("<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>Conan graph info</title></head>\n<body>\n<table>\n")
+	page.WriteString("<tr><th>Reference</th><th>Context</th><th>Binary</th><th>Package ID</th><th>Requires</th></tr>\n")
+	for _, node := range nodes {
+		var requires []string
+		dependencies, _ := getJsonField(node.value, "dependencies").([]jsonField)
+		for _, dependency := range dependencies {
+			if direct, _ := getJsonField(dependency.value, "direct").(bool); direct {
+				requires = append(requires, html.EscapeString(labels[dependency.key]))
+			}
+		}
+		page.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(labels[node.key]), html.EscapeString(getJsonString(node.value, "context")),
+			html.EscapeString(getJsonString(node.value, "binary")), html.EscapeString(getJsonString(node.value, "package_id")),
+			strings.Join(requires, "<br>")))
+	}
+	page.WriteString("</table>\n</body>\n</html>\n")
+	return page.String()
+}
@@ -0,0 +1,49 @@
+package conan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGraphFormatJSON = `{"graph": {"nodes": {
+  "0": {"ref": "", "id": "0", "label": "conanfile.py (app/1.0)", "context": "host", "binary": null, "test": false,
+    "dependencies": {"1": {"ref": "zlib/1.3.1", "direct": true}, "2": {"ref": "bzip2/1.0.8", "direct": false}}},
+  "1": {"ref": "zlib/1.3.1#rrev", "id": "1", "label": "zlib/1.3.1", "context": "host", "binary": "Cache", "cpp_info": {"root": {"libs": ["z"]}},
+    "dependencies": {"2": {"ref": "bzip2/1.0.8", "direct": true}}},
+  "2": {"ref": "bzip2/1.0.8#rrev", "id": "2", "label": "bzip2/1.0.8", "context": "host", "binary": "Download", "dependencies": {}}
+}}}`
+
+func TestGetGraphInfoFilters(t *testing.T) {
+	filters := getGraphInfoFilters([]string{"info", ".", "--filter", "binary", "--filter=context", "--package-filter", "zlib/*"})
+	assert.Equal(t, graphInfoFilters{fields: []string{"binary", "context"}, packages: []string{"zlib/*"}}, filters)
+}
+
+func TestRenderGraphInfoText(t *testing.T) {
+	text, err := renderGraphInfo([]byte(testGraphFormatJSON), graphFormatText, graphInfoFilters{})
+	require.NoError(t, err)
+	assert.Contains(t, text, ":\n  ref: \n  id: 0\n  label: conanfile.py (app/1.0)\n  context: host\n  binary: None\n  test: False\n  dependencies:\n    1:\n      ref: zlib/1.3.1\n      direct: True\n")
+	assert.Contains(t, text, "zlib/1.3.1#rrev:\n")
+	assert.Contains(t, text, "  cpp_info:\n    root:\n      libs: ['z']\n")
+
+	// The field and package filters are applied when rendering.
+	text, err = renderGraphInfo([]byte(testGraphFormatJSON), graphFormatText, graphInfoFilters{fields: []string{"binary"}, packages: []string{"zlib/*"}})
+	require.NoError(t, err)
+	assert.Equal(t, "zlib/1.3.1#rrev:\n  binary: Cache\n", text)
+}
+
+func TestRenderGraphInfoDot(t *testing.T) {
+	dot, err := renderGraphInfo([]byte(testGraphFormatJSON), graphFormatDot, graphInfoFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, "digraph {\n        \"conanfile.py (app/1.0)\" -> \"zlib/1.3.1\"\n        \"zlib/1.3.1\" -> \"bzip2/1.0.8\"\n}\n", dot)
+}
+
+func TestRenderGraphInfoHtml(t *testing.T) {
+	page, err := renderGraphInfo([]byte(testGraphFormatJSON), graphFormatHtml, graphInfoFilters{})
+	require.NoError(t, err)
+	assert.Contains(t, page, "<tr><td>zlib/1.3.1</td><td>host</td><td>Cache</td><td></td><td>bzip2/1.0.8</td></tr>")
+
+	_, err = renderGraphInfo([]byte(testGraphFormatJSON), "yaml", graphInfoFilters{})
+	assert.ErrorContains(t, err, "unsupported conan graph info format")
+}
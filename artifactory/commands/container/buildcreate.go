@@ -3,6 +3,7 @@ package container
 import (
 	"strings"
 
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/container/dockerfileutils"
 	container "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -14,7 +15,10 @@ import (
 
 type BuildDockerCreateCommand struct {
 	ContainerCommandBase
-	manifestSha256 string
+	images              []container.ImageWithDigest
+	dockerfilePath      string
+	extractAttestations bool
+	includeSignatures   bool
 }
 
 func NewBuildDockerCreateCommand() *BuildDockerCreateCommand {
@@ -25,11 +29,37 @@ func NewBuildDockerCreateCommand() *BuildDockerCreateCommand {
 // This file can be generated by Kaniko using the '--image-name-with-digest-file' flag
 // or by buildx CLI using '--metadata-file' flag.
 // Tag and Sha256 will be used later on to search the image in Artifactory.
-func (bdc *BuildDockerCreateCommand) SetImageNameWithDigest(filePath string) (err error) {
-	bdc.image, bdc.manifestSha256, err = container.GetImageTagWithDigest(filePath)
+func (bdc *BuildDockerCreateCommand) SetImageNameWithDigest(filePath string) error {
+	return bdc.SetImageFile(filePath, "")
+}
+
+// Set the images and their manifest sha256 from the file written by the image builder.
+// Kaniko '--digest-file' and Buildah '--digestfile' include the digest only, so the image name must be provided.
+// See container.ParseImageFile for all the supported formats.
+func (bdc *BuildDockerCreateCommand) SetImageFile(filePath, imageName string) (err error) {
+	if bdc.images, err = container.ParseImageFile(filePath, imageName); err != nil {
+		return
+	}
+	bdc.image = bdc.images[0].Image
 	return
 }
 
+// Set the Dockerfile the image was built from, to record its base images as dependencies.
+func (bdc *BuildDockerCreateCommand) SetDockerfilePath(dockerfilePath string) *BuildDockerCreateCommand {
+	bdc.dockerfilePath = dockerfilePath
+	return bdc
+}
+
+func (bdc *BuildDockerCreateCommand) SetExtractAttestations(extractAttestations bool) *BuildDockerCreateCommand {
+	bdc.extractAttestations = extractAttestations
+	return bdc
+}
+
+func (bdc *BuildDockerCreateCommand) SetIncludeSignatures(includeSignatures bool) *BuildDockerCreateCommand {
+	bdc.includeSignatures = includeSignatures
+	return bdc
+}
+
 func (bdc *BuildDockerCreateCommand) Run() error {
 	if err := bdc.init(); err != nil {
 		return err
@@ -55,8 +85,23 @@ func (bdc *BuildDockerCreateCommand) Run() error {
 		return err
 	}
 
+	var baseImages []container.DockerImage
+	if bdc.dockerfilePath != "" {
+		if baseImages, err = dockerfileutils.ParseDockerfileBaseImages(bdc.dockerfilePath); err != nil {
+			return errorutils.CheckErrorf("failed to parse Dockerfile: %s", err.Error())
+		}
+	}
+	for _, imageWithDigest := range bdc.images {
+		if err = bdc.createBuildInfo(imageWithDigest, baseImages, buildName, buildNumber, project, serviceManager); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bdc *BuildDockerCreateCommand) createBuildInfo(imageWithDigest container.ImageWithDigest, baseImages []container.DockerImage, buildName, buildNumber, project string, serviceManager artifactory.ArtifactoryServicesManager) error {
 	// Handle multiple tags from comma-separated image name
-	images := SplitMultiTagDockerImageStringWithComma(bdc.image)
+	images := SplitMultiTagDockerImageStringWithComma(imageWithDigest.Image)
 	if len(images) == 0 {
 		return errorutils.CheckErrorf("no valid images found in image file")
 	}
@@ -79,10 +124,13 @@ func (bdc *BuildDockerCreateCommand) Run() error {
 			log.Debug("Repository extracted from image name '%s': '%s'. The mandatory repository CLI argument is not used.", image.Name(), repo)
 		}
 
-		builder, err := container.NewRemoteAgentBuildInfoBuilder(image, repo, buildName, buildNumber, project, serviceManager, bdc.manifestSha256)
+		builder, err := container.NewRemoteAgentBuildInfoBuilder(image, repo, buildName, buildNumber, project, serviceManager, imageWithDigest.ManifestSha256)
 		if err != nil {
 			return errorutils.CheckErrorf("build info creation failed: %s", err.Error())
 		}
+		builder.SetExtractAttestations(bdc.extractAttestations)
+		builder.SetIncludeSignatures(bdc.includeSignatures)
+		builder.SetBaseImages(baseImages)
 		buildInfo, err := builder.Build(bdc.BuildConfiguration().GetModule())
 		if err != nil {
 			return errorutils.CheckErrorf("build info creation failed: %s", err.Error())
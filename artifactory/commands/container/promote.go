@@ -1,9 +1,20 @@
 package container
 
 import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	container "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type DockerPromoteCommand struct {
@@ -21,8 +32,200 @@ func (dp *DockerPromoteCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	// Promote docker
-	return servicesManager.PromoteDocker(dp.params)
+	// The source image may be referenced by digest: <image>@sha256:<hex>
+	imageName, digest, isDigest := strings.Cut(dp.params.SourceDockerImage, "@")
+	reference := dp.params.SourceTag
+	if isDigest {
+		if !container.IsDigestReference(digest) {
+			return errorutils.CheckErrorf("invalid source docker image %s. Expecting <image>@sha256:<digest>", dp.params.SourceDockerImage)
+		}
+		if reference != "" {
+			return errorutils.CheckErrorf("the source tag cannot be set when promoting an image by digest")
+		}
+		reference = digest
+	}
+	if reference == "" {
+		// Promote the entire docker repository.
+		return servicesManager.PromoteDocker(dp.params)
+	}
+	targetImage, targetReference, err := dp.getTarget(imageName)
+	if err != nil {
+		return err
+	}
+	plan, err := container.ResolveImageForPromotion(dp.params.SourceRepo, imageName, reference, servicesManager)
+	if err != nil {
+		return err
+	}
+	if !isDigest && !plan.IsMultiPlatform() {
+		// Promote docker
+		if err = servicesManager.PromoteDocker(dp.params); err != nil {
+			return err
+		}
+		return verifyPromotedImage(dp.params.TargetRepo, targetImage, targetReference, plan.Digest, servicesManager)
+	}
+	// Everything is checked before the first change, so that an invalid promotion leaves both repositories untouched.
+	var foldersToDelete []string
+	if !dp.params.Copy {
+		var sharedFolders []string
+		if foldersToDelete, sharedFolders, err = container.GetFoldersToDelete(dp.params.SourceRepo, imageName, plan, servicesManager); err != nil {
+			return err
+		}
+		for _, folder := range sharedFolders {
+			log.Info(fmt.Sprintf("%s is referenced by other images and will be kept in %s", folder, dp.params.SourceRepo))
+		}
+	}
+	promoted, err := isAlreadyPromoted(dp.params.TargetRepo, targetImage, targetReference, plan.Digest, servicesManager)
+	if err != nil {
+		return err
+	}
+	if promoted {
+		log.Info(fmt.Sprintf("%s/%s:%s already resolves to %s", dp.params.TargetRepo, targetImage, targetReference, plan.Digest))
+	} else {
+		foldersToPromote := getFoldersToPromote(plan, dp.params.SourceRepo, path.Join(dp.params.TargetRepo, targetImage), targetReference)
+		if err = dp.copyImageFolders(foldersToPromote, targetImage, targetReference, plan.Digest, servicesManager); err != nil {
+			return err
+		}
+	}
+	if len(foldersToDelete) == 0 {
+		return nil
+	}
+	// The source image is deleted only once the whole image is copied and verified.
+	// The lead folder is deleted first, so that the source reference never resolves to a partial image.
+	log.Info("Deleting the promoted image from", dp.params.SourceRepo)
+	var sourceFolders []string
+	for _, folder := range foldersToDelete {
+		sourceFolders = append(sourceFolders, path.Join(dp.params.SourceRepo, folder))
+	}
+	if err = deleteFolders(sourceFolders, servicesManager); err != nil {
+		return fmt.Errorf("the image was promoted to %s, but deleting it from %s failed: %w", dp.params.TargetRepo, dp.params.SourceRepo, err)
+	}
+	return nil
+}
+
+// Return the target image name and the target tag. An image promoted by digest has no source tag, so its target tag must be set.
+func (dp *DockerPromoteCommand) getTarget(imageName string) (targetImage, targetReference string, err error) {
+	targetImage = dp.params.TargetDockerImage
+	if targetImage == "" {
+		targetImage = imageName
+	}
+	targetReference = dp.params.TargetTag
+	if targetReference == "" {
+		targetReference = dp.params.SourceTag
+	}
+	if targetReference == "" {
+		err = errorutils.CheckErrorf("the target tag must be set when promoting an image by digest")
+	}
+	return
+}
+
+// Return true if the target reference already resolves to the promoted image, as when running a move again after the deletion failed.
+// A target reference resolving to a different image is an error, since copying over it would mix the files of both images.
+func isAlreadyPromoted(targetRepo, targetImage, targetReference, digest string, servicesManager artifactory.ArtifactoryServicesManager) (bool, error) {
+	exists, err := folderExists(path.Join(targetRepo, targetImage, targetReference), servicesManager)
+	if err != nil || !exists {
+		return false, err
+	}
+	existing, err := container.ResolveImageForPromotion(targetRepo, targetImage, targetReference, servicesManager)
+	if err != nil {
+		return false, err
+	}
+	if existing.Digest != digest {
+		return false, errorutils.CheckErrorf("%s/%s:%s already exists and resolves to %s. Delete it or choose another target tag", targetRepo, targetImage, targetReference, existing.Digest)
+	}
+	return true, nil
+}
+
+// Copy the image folders to the target repository. The referenced platform manifests, layers and attestations are copied
+// before the manifest or fat-manifest, so the target reference never resolves to a partial image.
+// The referenced folders are named by their digests, so they're kept if a later copy fails, and copying them again is harmless.
+// The target reference didn't exist before the promotion, so it's removed if its copy or verification fails.
+func (dp *DockerPromoteCommand) copyImageFolders(foldersToPromote []promotedFolder, targetImage, targetReference, digest string, servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	for _, folder := range foldersToPromote {
+		log.Info(fmt.Sprintf("Copying %s to %s", folder.source, folder.target))
+		params := services.NewMoveCopyParams()
+		params.Pattern = folder.source + "/*"
+		params.Target = folder.target + "/"
+		params.Flat = true
+		if err = copyFolder(params, servicesManager); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = verifyPromotedImage(dp.params.TargetRepo, targetImage, targetReference, digest, servicesManager)
+	}
+	if err != nil {
+		leadFolder := foldersToPromote[len(foldersToPromote)-1].target
+		log.Warn("Promotion failed. Removing", leadFolder)
+		err = errors.Join(err, deleteFolders([]string{leadFolder}, servicesManager))
+	}
+	return
+}
+
+type promotedFolder struct {
+	source string
+	target string
+}
+
+// Return the folders to copy, ordered so that the manifest or fat-manifest is copied last.
+// Referenced manifests keep their digest folder names, while the lead manifest is copied to the target reference.
+func getFoldersToPromote(plan *container.ImagePromotionPlan, sourceRepo, targetImagePath, targetReference string) []promotedFolder {
+	var folders []promotedFolder
+	for _, folder := range plan.ReferencedFolders {
+		folders = append(folders, promotedFolder{source: path.Join(sourceRepo, folder), target: path.Join(targetImagePath, path.Base(folder))})
+	}
+	return append(folders, promotedFolder{source: path.Join(sourceRepo, plan.LeadFolder), target: path.Join(targetImagePath, targetReference)})
+}
+
+func copyFolder(params services.MoveCopyParams, servicesManager artifactory.ArtifactoryServicesManager) error {
+	succeeded, failed, err := servicesManager.Copy(params)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || succeeded == 0 {
+		return errorutils.CheckErrorf("failed to copy %s: %d files were copied and %d failed", params.Pattern, succeeded, failed)
+	}
+	return nil
+}
+
+func folderExists(folder string, servicesManager artifactory.ArtifactoryServicesManager) (exists bool, err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &clientUtils.CommonParams{Pattern: folder + "/*"}
+	searchParams.Recursive = false
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return false, err
+	}
+	defer ioutils.Close(reader, &err)
+	length, err := reader.Length()
+	return length > 0, err
+}
+
+func deleteFolders(folders []string, servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	for _, folder := range folders {
+		deleteParams := services.NewDeleteParams()
+		deleteParams.Pattern = folder + "/"
+		reader, searchErr := servicesManager.GetPathsToDelete(deleteParams)
+		if searchErr != nil {
+			err = errors.Join(err, searchErr)
+			continue
+		}
+		_, deleteErr := servicesManager.DeleteFiles(reader)
+		err = errors.Join(err, deleteErr, reader.Close())
+	}
+	return
+}
+
+// Verify that the target reference resolves to the promoted manifest digest.
+func verifyPromotedImage(targetRepo, targetImage, targetReference, digest string, servicesManager artifactory.ArtifactoryServicesManager) error {
+	promoted, err := container.ResolveImageForPromotion(targetRepo, targetImage, targetReference, servicesManager)
+	if err != nil {
+		return fmt.Errorf("failed to verify the promoted image: %w", err)
+	}
+	if promoted.Digest != digest {
+		return errorutils.CheckErrorf("promotion verification failed: %s/%s:%s resolves to %s instead of %s", targetRepo, targetImage, targetReference, promoted.Digest, digest)
+	}
+	log.Info(fmt.Sprintf("Verified that %s/%s:%s resolves to %s", targetRepo, targetImage, targetReference, digest))
+	return nil
 }
 
 func (dp *DockerPromoteCommand) CommandName() string {
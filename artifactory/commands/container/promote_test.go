@@ -0,0 +1,51 @@
+package container
+
+import (
+	"testing"
+
+	container "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFoldersToPromote(t *testing.T) {
+	plan := &container.ImagePromotionPlan{
+		Digest:            "sha256:list",
+		LeadFolder:        "org/app/1.0",
+		ReferencedFolders: []string{"org/app/sha256:amd64", "org/app/sha256:arm64", "org/app/sha256:attestation"},
+	}
+	assert.Equal(t, []promotedFolder{
+		{source: "docker-dev/org/app/sha256:amd64", target: "docker-prod/app/sha256:amd64"},
+		{source: "docker-dev/org/app/sha256:arm64", target: "docker-prod/app/sha256:arm64"},
+		{source: "docker-dev/org/app/sha256:attestation", target: "docker-prod/app/sha256:attestation"},
+		{source: "docker-dev/org/app/1.0", target: "docker-prod/app/release"},
+	}, getFoldersToPromote(plan, "docker-dev", "docker-prod/app", "release"))
+}
+
+func TestGetPromotionTarget(t *testing.T) {
+	tests := []struct {
+		name              string
+		params            services.DockerPromoteParams
+		expectedImage     string
+		expectedReference string
+		expectedErr       string
+	}{
+		{"Digest without target tag", services.DockerPromoteParams{}, "", "", "the target tag must be set when promoting an image by digest"},
+		{"Digest with target tag", services.DockerPromoteParams{TargetTag: "1.0"}, "app", "1.0", ""},
+		{"Source tag", services.DockerPromoteParams{SourceTag: "latest"}, "app", "latest", ""},
+		{"Target image", services.DockerPromoteParams{SourceTag: "latest", TargetDockerImage: "prod/app"}, "prod/app", "latest", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := NewDockerPromoteCommand().SetParams(tt.params)
+			targetImage, targetReference, err := dp.getTarget("app")
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedImage, targetImage)
+			assert.Equal(t, tt.expectedReference, targetReference)
+		})
+	}
+}
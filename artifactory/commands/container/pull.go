@@ -11,6 +11,8 @@ import (
 
 type PullCommand struct {
 	ContainerCommand
+	// If true, verify the pulled image matches the image in Artifactory.
+	verify bool
 }
 
 func NewPullCommand(containerManagerType container.ContainerManagerType) *PullCommand {
@@ -21,6 +23,11 @@ func NewPullCommand(containerManagerType container.ContainerManagerType) *PullCo
 	}
 }
 
+func (pc *PullCommand) SetVerify(verify bool) *PullCommand {
+	pc.verify = verify
+	return pc
+}
+
 func (pc *PullCommand) Run() error {
 	if err := pc.init(); err != nil {
 		return err
@@ -46,30 +53,38 @@ func (pc *PullCommand) Run() error {
 		return err
 	}
 	toCollect, err := pc.buildConfiguration.IsCollectBuildInfo()
-	if err != nil || !toCollect {
+	if err != nil || (!toCollect && !pc.verify) {
 		return err
 	}
-	buildName, err := pc.buildConfiguration.GetBuildName()
+	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
 	if err != nil {
 		return err
 	}
-	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	repo, err := pc.GetRepo()
 	if err != nil {
 		return err
 	}
-	project := pc.BuildConfiguration().GetProject()
-	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	// Verify the pulled image before recording it in the build-info.
+	var resolvedImage *container.ResolvedImage
+	if pc.verify {
+		if resolvedImage, err = container.VerifyPulledImage(pc.image, repo, cm, serviceManager); err != nil || !toCollect {
+			return err
+		}
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
 	if err != nil {
 		return err
 	}
-	repo, err := pc.GetRepo()
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
 	if err != nil {
 		return err
 	}
+	project := pc.BuildConfiguration().GetProject()
 	builder, err := container.NewLocalAgentBuildInfoBuilder(pc.image, repo, buildName, buildNumber, project, serviceManager, container.Pull, cm)
 	if err != nil {
 		return err
 	}
+	builder.SetResolvedImage(resolvedImage)
 	if err := build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -21,7 +23,9 @@ type PushCommand struct {
 	ContainerCommand
 	threads         int
 	detailedSummary bool
-	result          *commandsutils.Result
+	// Path to an OCI image layout or tarball to push the image from, without a container daemon.
+	imagePath string
+	result    *commandsutils.Result
 }
 
 func NewPushCommand(containerManagerType containerutils.ContainerManagerType) *PushCommand {
@@ -50,6 +54,11 @@ func (pc *PushCommand) IsDetailedSummary() bool {
 	return pc.detailedSummary
 }
 
+func (pc *PushCommand) SetImagePath(imagePath string) *PushCommand {
+	pc.imagePath = imagePath
+	return pc
+}
+
 func (pc *PushCommand) SetValidateSha(validateSha bool) *PushCommand {
 	pc.ContainerCommandBase.SetValidateSha(validateSha)
 	return pc
@@ -72,6 +81,9 @@ func (pc *PushCommand) Run() error {
 	if err := pc.init(); err != nil {
 		return err
 	}
+	if pc.imagePath != "" {
+		return pc.pushImageLayout()
+	}
 	if pc.containerManagerType == containerutils.DockerClient {
 		err := containerutils.ValidateClientApiVersion()
 		if err != nil {
@@ -220,6 +232,78 @@ func (pc *PushCommand) Run() error {
 	return nil
 }
 
+// Push the image from an OCI image layout or tarball through the registry API of Artifactory, without a container daemon.
+func (pc *PushCommand) pushImageLayout() (err error) {
+	serverDetails, err := pc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	repo, err := pc.GetRepo()
+	if err != nil {
+		return err
+	}
+	imageName, err := pc.image.GetImageLongNameWithoutRepoAndTag()
+	if err != nil {
+		return err
+	}
+	tag, err := pc.image.GetImageTag()
+	if err != nil {
+		return err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	layoutImage, err := containerutils.ReadImageLayout(pc.imagePath, tag, tempDir)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, pc.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	if err = containerutils.PushLayoutImage(layoutImage, repo, imageName, tag, pc.threads, serviceManager); err != nil {
+		return err
+	}
+	toCollect, err := pc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || (!toCollect && !pc.IsDetailedSummary()) {
+		return err
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	builder, err := containerutils.NewRemoteAgentBuildInfoBuilder(pc.image, repo, buildName, buildNumber, pc.BuildConfiguration().GetProject(), serviceManager, layoutImage.Lead.Digest)
+	if err != nil {
+		return err
+	}
+	if toCollect {
+		if err = build.SaveBuildGeneralDetails(buildName, buildNumber, pc.buildConfiguration.GetProject()); err != nil {
+			return err
+		}
+		buildInfoModule, err := builder.Build(pc.BuildConfiguration().GetModule())
+		if err != nil || buildInfoModule == nil {
+			return err
+		}
+		if err = build.SaveBuildInfo(buildName, buildNumber, pc.BuildConfiguration().GetProject(), buildInfoModule); err != nil {
+			return err
+		}
+	} else if _, err = builder.Build(""); err != nil {
+		return err
+	}
+	if pc.IsDetailedSummary() {
+		return pc.layersMapToFileTransferDetails(serverDetails.ArtifactoryUrl, builder.GetLayers())
+	}
+	return nil
+}
+
 func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, layers *[]servicesutils.ResultItem) error {
 	var details []clientutils.FileTransferDetails
 	for _, layer := range *layers {
@@ -0,0 +1,147 @@
+package container
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	container "github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DockerVerifyCommand verifies that every image in a published build is signed with cosign or Notation.
+type DockerVerifyCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+// buildImage describes an image recorded in a build, identified by the digest of its manifest or fat-manifest.
+type buildImage struct {
+	moduleId string
+	repo     string
+	path     string
+	digest   string
+}
+
+func NewDockerVerifyCommand() *DockerVerifyCommand {
+	return &DockerVerifyCommand{}
+}
+
+func (dv *DockerVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerVerifyCommand {
+	dv.serverDetails = serverDetails
+	return dv
+}
+
+func (dv *DockerVerifyCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *DockerVerifyCommand {
+	dv.buildConfiguration = buildConfiguration
+	return dv
+}
+
+func (dv *DockerVerifyCommand) CommandName() string {
+	return "rt_docker_verify"
+}
+
+func (dv *DockerVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dv.serverDetails, nil
+}
+
+func (dv *DockerVerifyCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dv.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := dv.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := dv.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{
+		BuildName:   buildName,
+		BuildNumber: buildNumber,
+		ProjectKey:  dv.buildConfiguration.GetProject(),
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	images, err := getBuildImages(publishedBuildInfo.BuildInfo.Modules)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return errorutils.CheckErrorf("no docker images were found in build %s/%s", buildName, buildNumber)
+	}
+	var unsigned []string
+	for _, image := range images {
+		signatures, err := container.SearchImageSignatures(image.repo, image.path, image.digest, servicesManager)
+		if err != nil {
+			return err
+		}
+		if len(signatures) == 0 {
+			log.Warn(fmt.Sprintf("Image %s (%s) is not signed", image.moduleId, image.digest))
+			unsigned = append(unsigned, image.moduleId)
+			continue
+		}
+		log.Info(fmt.Sprintf("Image %s (%s) is signed", image.moduleId, image.digest))
+	}
+	if len(unsigned) > 0 {
+		return errorutils.CheckErrorf("%d of %d images of build %s/%s are not signed: %s", len(unsigned), len(images), buildName, buildNumber, strings.Join(unsigned, ", "))
+	}
+	log.Info(fmt.Sprintf("All %d images of build %s/%s are signed", len(images), buildName, buildNumber))
+	return nil
+}
+
+// Return the images of the build. Platform and attestation modules, which have a parent module, are covered by
+// the signature of their fat-manifest, so only top-level docker modules are returned.
+func getBuildImages(modules []buildinfo.Module) ([]buildImage, error) {
+	var images []buildImage
+	for _, module := range modules {
+		if module.Type != buildinfo.Docker || module.Parent != "" {
+			continue
+		}
+		manifest := getImageManifestArtifact(module.Artifacts)
+		if manifest == nil {
+			// Modules of pulled images have no artifacts.
+			continue
+		}
+		if manifest.Sha256 == "" || manifest.OriginalDeploymentRepo == "" {
+			return nil, errorutils.CheckErrorf("the manifest of image %s is missing its sha256 or repository in the build-info", module.Id)
+		}
+		images = append(images, buildImage{
+			moduleId: module.Id,
+			repo:     manifest.OriginalDeploymentRepo,
+			// The manifest path is <image path>/<tag>/<manifest file>.
+			path:   path.Dir(path.Dir(manifest.Path)),
+			digest: "sha256:" + manifest.Sha256,
+		})
+	}
+	return images, nil
+}
+
+// Return the fat-manifest artifact, or the manifest artifact of a single platform image.
+// The image manifest precedes the manifests of its signatures, which are recorded as artifacts of the same module.
+func getImageManifestArtifact(artifacts []buildinfo.Artifact) *buildinfo.Artifact {
+	var manifest *buildinfo.Artifact
+	for i := range artifacts {
+		switch artifacts[i].Name {
+		case "list.manifest.json":
+			return &artifacts[i]
+		case container.ManifestJsonFile:
+			if manifest == nil {
+				manifest = &artifacts[i]
+			}
+		}
+	}
+	return manifest
+}
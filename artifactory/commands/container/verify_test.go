@@ -0,0 +1,53 @@
+package container
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBuildImages(t *testing.T) {
+	modules := []buildinfo.Module{
+		{
+			Id:   "app:1.0",
+			Type: buildinfo.Docker,
+			Artifacts: []buildinfo.Artifact{
+				{Name: "manifest.json", Path: "org/app/1.0/manifest.json", OriginalDeploymentRepo: "docker-local", Checksum: buildinfo.Checksum{Sha256: "app-sha"}},
+				{Name: "manifest.json", Path: "org/app/sha256-app-sha.sig/manifest.json", OriginalDeploymentRepo: "docker-local", Checksum: buildinfo.Checksum{Sha256: "sig-sha"}},
+			},
+		},
+		{
+			Id:   "multi:2.0",
+			Type: buildinfo.Docker,
+			Artifacts: []buildinfo.Artifact{
+				{Name: "list.manifest.json", Path: "multi/2.0/list.manifest.json", OriginalDeploymentRepo: "docker-local", Checksum: buildinfo.Checksum{Sha256: "list-sha"}},
+			},
+		},
+		{
+			Id:        "linux/amd64/multi:2.0",
+			Type:      buildinfo.Docker,
+			Parent:    "multi:2.0",
+			Artifacts: []buildinfo.Artifact{{Name: "manifest.json", Path: "multi/sha256:amd64/manifest.json", Checksum: buildinfo.Checksum{Sha256: "amd64"}}},
+		},
+		{Id: "base:latest", Type: buildinfo.Docker},
+		{Id: "lib", Type: buildinfo.Go, Artifacts: []buildinfo.Artifact{{Name: "manifest.json"}}},
+	}
+	images, err := getBuildImages(modules)
+	require.NoError(t, err)
+	assert.Equal(t, []buildImage{
+		{moduleId: "app:1.0", repo: "docker-local", path: "org/app", digest: "sha256:app-sha"},
+		{moduleId: "multi:2.0", repo: "docker-local", path: "multi", digest: "sha256:list-sha"},
+	}, images)
+}
+
+func TestGetBuildImagesMissingChecksum(t *testing.T) {
+	modules := []buildinfo.Module{{
+		Id:        "app:1.0",
+		Type:      buildinfo.Docker,
+		Artifacts: []buildinfo.Artifact{{Name: "manifest.json", Path: "app/1.0/manifest.json", OriginalDeploymentRepo: "docker-local"}},
+	}}
+	_, err := getBuildImages(modules)
+	assert.ErrorContains(t, err, "app:1.0")
+}
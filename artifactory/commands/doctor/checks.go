@@ -0,0 +1,549 @@
+package doctor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/container"
+	lifecycle "github.com/jfrog/jfrog-cli-artifactory/lifecycle/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	networkTimeout = 10 * time.Second
+	// Certificates and tokens expiring within this period are reported as warnings.
+	expiryWarningPeriod  = 14 * 24 * time.Hour
+	latencySamples       = 3
+	slowLatency          = 500 * time.Millisecond
+	throughputSampleSize = 1024 * 1024
+	// Below this throughput, in bytes per second, transfers are reported as slow.
+	slowThroughput = 1024 * 1024
+	// The minimal Artifactory version supported by the CLI commands.
+	minSupportedArtifactoryVersion = "6.2.0"
+)
+
+// The features which require a minimal Artifactory version, as required by their commands.
+var features = []struct {
+	name       string
+	minVersion string
+}{
+	{"Container repository detection", container.MinRtVersionForRepoFetching},
+	{"Release lifecycle", lifecycle.MinimalLifecycleArtifactoryVersion},
+	{"Release bundles from multiple sources", lifecycle.MinArtifactoryVersionForMultiSourceAndPackagesSupport},
+	{"Draft release bundles", lifecycle.MinArtifactoryVersionForDraftBundleSupport},
+}
+
+type check func() []CheckResult
+
+type checker struct {
+	serverDetails   *config.ServerDetails
+	repo            string
+	rtUrl           *url.URL
+	servicesManager artifactory.ArtifactoryServicesManager
+	resolved        bool
+	reachable       bool
+	authenticated   bool
+	version         string
+}
+
+func newChecker(serverDetails *config.ServerDetails, repo string) (*checker, error) {
+	rtUrl, err := url.Parse(serverDetails.ArtifactoryUrl)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid Artifactory URL '%s': %s", serverDetails.ArtifactoryUrl, err.Error())
+	}
+	// Retries would hide the actual latency and failures.
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 0, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return &checker{serverDetails: serverDetails, repo: repo, rtUrl: rtUrl, servicesManager: servicesManager}, nil
+}
+
+func (c *checker) getChecks() []check {
+	return []check{
+		c.single(c.checkResolution),
+		c.single(c.checkProxy),
+		c.single(c.checkTls),
+		c.single(c.checkClientCertificate),
+		c.single(c.checkLatency),
+		c.single(c.checkAuthentication),
+		c.single(c.checkVersion),
+		c.checkRepoPermissions,
+	}
+}
+
+func (c *checker) single(singleCheck func() CheckResult) check {
+	return func() []CheckResult {
+		return []CheckResult{singleCheck()}
+	}
+}
+
+func (c *checker) getHost() string {
+	return c.rtUrl.Hostname()
+}
+
+func (c *checker) getAddress() string {
+	port := c.rtUrl.Port()
+	if port == "" {
+		port = "443"
+		if c.rtUrl.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(c.getHost(), port)
+}
+
+func (c *checker) getProxy() (*url.URL, error) {
+	return http.ProxyFromEnvironment(&http.Request{URL: c.rtUrl})
+}
+
+func (c *checker) checkResolution() CheckResult {
+	result := CheckResult{Name: "DNS resolution"}
+	if net.ParseIP(c.getHost()) != nil {
+		c.resolved = true
+		result.Status, result.Message = Pass, c.getHost()+" is an IP address"
+		return result
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), networkTimeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupHost(ctx, c.getHost())
+	if err != nil {
+		if proxy, proxyErr := c.getProxy(); proxyErr == nil && proxy != nil {
+			// Behind a proxy, the host may only be resolvable by the proxy.
+			c.resolved = true
+			result.Status, result.Message = Warn, fmt.Sprintf("%s could not be resolved locally, and is resolved by the proxy: %s", c.getHost(), err.Error())
+			return result
+		}
+		result.Status, result.Message = Fail, fmt.Sprintf("%s could not be resolved: %s", c.getHost(), err.Error())
+		result.Remediation = "Check the Artifactory URL in the server configuration ('jf config show'), and the DNS settings of this machine."
+		return result
+	}
+	c.resolved = true
+	result.Status, result.Message = Pass, fmt.Sprintf("%s resolved to %s", c.getHost(), strings.Join(addresses, ", "))
+	return result
+}
+
+func (c *checker) checkProxy() CheckResult {
+	result := CheckResult{Name: "Proxy"}
+	for _, name := range []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"} {
+		for _, envName := range []string{name, strings.ToLower(name)} {
+			if value, ok := os.LookupEnv(envName); ok {
+				result.Details = append(result.Details, fmt.Sprintf("%s=%s", envName, redactUrlCredentials(value)))
+			}
+		}
+	}
+	proxy, err := c.getProxy()
+	if err != nil {
+		result.Status, result.Message = Fail, "Invalid proxy configuration: "+err.Error()
+		result.Remediation = "Fix the HTTP_PROXY and HTTPS_PROXY environment variables."
+		return result
+	}
+	if proxy == nil {
+		result.Status, result.Message = Pass, "No proxy is used for "+c.getHost()
+		return result
+	}
+	proxyAddress := proxy.Host
+	if proxy.Port() == "" {
+		proxyAddress = net.JoinHostPort(proxy.Hostname(), "80")
+	}
+	connection, err := net.DialTimeout("tcp", proxyAddress, networkTimeout)
+	if err != nil {
+		result.Status, result.Message = Fail, fmt.Sprintf("The proxy %s is not reachable: %s", proxy.Redacted(), err.Error())
+		result.Remediation = "Check the proxy address, or add " + c.getHost() + " to NO_PROXY if it should be accessed directly."
+		return result
+	}
+	_ = connection.Close()
+	result.Status, result.Message = Pass, "Connecting through the proxy "+proxy.Redacted()
+	return result
+}
+
+func (c *checker) checkTls() CheckResult {
+	result := CheckResult{Name: "TLS"}
+	if c.rtUrl.Scheme != "https" {
+		result.Status, result.Message = Warn, "The connection to Artifactory is not encrypted"
+		result.Remediation = "Use an https:// Artifactory URL."
+		return result
+	}
+	if !c.resolved {
+		return skipped(result, "the host could not be resolved")
+	}
+	roots, certsDir := loadTrustedCertificates()
+	dialer := &net.Dialer{Timeout: networkTimeout}
+	// The certificate is verified below, to report its details even if it is not trusted.
+	connection, err := tls.DialWithDialer(dialer, "tcp", c.getAddress(), &tls.Config{ServerName: c.getHost(), InsecureSkipVerify: true}) // #nosec G402
+	if err != nil {
+		if proxy, proxyErr := c.getProxy(); proxyErr == nil && proxy != nil {
+			result.Status, result.Message = Warn, "The certificate could not be inspected, since Artifactory is accessed through a proxy: "+err.Error()
+			return result
+		}
+		result.Status, result.Message = Fail, "TLS handshake failed: "+err.Error()
+		result.Remediation = "Check that the URL's port serves HTTPS."
+		return result
+	}
+	defer func() {
+		_ = connection.Close()
+	}()
+	state := connection.ConnectionState()
+	leaf := state.PeerCertificates[0]
+	result.Details = []string{
+		"Version: " + tls.VersionName(state.Version),
+		"Subject: " + leaf.Subject.String(),
+		"Issuer: " + leaf.Issuer.String(),
+		"Valid until: " + leaf.NotAfter.Format(time.RFC3339),
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	if _, err = leaf.Verify(x509.VerifyOptions{DNSName: c.getHost(), Roots: roots, Intermediates: intermediates}); err != nil {
+		if c.serverDetails.InsecureTls {
+			result.Status, result.Message = Warn, "The certificate is not trusted, and is accepted because of --insecure-tls: "+err.Error()
+			return result
+		}
+		result.Status, result.Message = Fail, "The certificate is not trusted: "+err.Error()
+		result.Remediation = fmt.Sprintf("Add the CA certificate of the server in PEM format to %s, or use --insecure-tls (not recommended).", certsDir)
+		return result
+	}
+	return checkExpiry(result, "The certificate", leaf.NotAfter, "Renew the server's certificate.")
+}
+
+// Load the system's trusted certificates, with the certificates added to the JFrog CLI certificates directory.
+func loadTrustedCertificates() (*x509.CertPool, string) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return roots, "the JFrog CLI certificates directory"
+	}
+	entries, err := os.ReadDir(certsDir)
+	if err != nil {
+		return roots, certsDir
+	}
+	for _, entry := range entries {
+		if content, err := os.ReadFile(filepath.Join(certsDir, entry.Name())); err == nil {
+			roots.AppendCertsFromPEM(content)
+		}
+	}
+	return roots, certsDir
+}
+
+func (c *checker) checkClientCertificate() CheckResult {
+	result := CheckResult{Name: "Client certificate"}
+	if c.serverDetails.ClientCertPath == "" {
+		return skipped(result, "no client certificate is configured")
+	}
+	keyPath := c.serverDetails.ClientCertKeyPath
+	if keyPath == "" {
+		keyPath = c.serverDetails.ClientCertPath
+	}
+	certificate, err := tls.LoadX509KeyPair(c.serverDetails.ClientCertPath, keyPath)
+	if err != nil {
+		result.Status, result.Message = Fail, "The client certificate could not be loaded: "+err.Error()
+		result.Remediation = "Check --client-cert-path and --client-cert-key-path. Both should be in PEM format."
+		return result
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		result.Status, result.Message = Fail, "The client certificate could not be parsed: "+err.Error()
+		return result
+	}
+	result.Details = []string{"Subject: " + leaf.Subject.String(), "Valid until: " + leaf.NotAfter.Format(time.RFC3339)}
+	return checkExpiry(result, "The client certificate", leaf.NotAfter, "Renew the client certificate.")
+}
+
+func checkExpiry(result CheckResult, subject string, notAfter time.Time, remediation string) CheckResult {
+	switch left := time.Until(notAfter); {
+	case left <= 0:
+		result.Status, result.Message, result.Remediation = Fail, subject+" has expired", remediation
+	case left < expiryWarningPeriod:
+		result.Status, result.Message, result.Remediation = Warn, fmt.Sprintf("%s expires in %d hours", subject, int(left.Hours())), remediation
+	default:
+		result.Status, result.Message = Pass, subject+" is valid"
+	}
+	return result
+}
+
+func (c *checker) checkLatency() CheckResult {
+	result := CheckResult{Name: "Latency"}
+	if !c.resolved {
+		return skipped(result, "the host could not be resolved")
+	}
+	var total, maxLatency time.Duration
+	for i := 0; i < latencySamples; i++ {
+		start := time.Now()
+		resp, body, err := c.sendRequest(http.MethodGet, "api/system/ping", nil)
+		latency := time.Since(start)
+		if err != nil {
+			result.Status, result.Message = Fail, "Artifactory is not reachable: "+err.Error()
+			result.Remediation = "Check the network connection and the proxy settings, and that Artifactory is up."
+			return result
+		}
+		if resp.StatusCode != http.StatusOK {
+			result.Status, result.Message = Fail, fmt.Sprintf("Artifactory is not healthy: %s %s", resp.Status, strings.TrimSpace(string(body)))
+			result.Remediation = "Check the Artifactory URL. It should end with /artifactory/ if Artifactory is accessed through the JFrog Platform URL."
+			return result
+		}
+		total += latency
+		maxLatency = max(maxLatency, latency)
+	}
+	c.reachable = true
+	average := total / latencySamples
+	result.Details = []string{fmt.Sprintf("Average: %s, max: %s, over %d pings", average.Round(time.Millisecond), maxLatency.Round(time.Millisecond), latencySamples)}
+	if average > slowLatency {
+		result.Status, result.Message = Warn, "Artifactory is reachable, with a high latency"
+		result.Remediation = "Check the network route to Artifactory. A closer Artifactory edge or a remote repository may help."
+		return result
+	}
+	result.Status, result.Message = Pass, "Artifactory is reachable"
+	return result
+}
+
+func (c *checker) getAuthType() string {
+	switch {
+	case c.serverDetails.AccessToken != "":
+		return "access token"
+	case c.serverDetails.SshKeyPath != "":
+		return "SSH key"
+	case c.serverDetails.User != "" && c.serverDetails.Password != "":
+		return "username and password"
+	}
+	return ""
+}
+
+func (c *checker) checkAuthentication() CheckResult {
+	result := CheckResult{Name: "Authentication"}
+	authType := c.getAuthType()
+	if authType == "" {
+		result.Details = []string{"No credentials are configured"}
+	} else {
+		result.Details = []string{"Authenticating with " + authType}
+	}
+	if c.serverDetails.AccessToken != "" {
+		var unusable bool
+		if result, unusable = c.checkTokenExpiry(result); unusable {
+			return result
+		}
+	}
+	if !c.reachable {
+		return skipped(result, "Artifactory is not reachable")
+	}
+	resp, body, err := c.sendRequest(http.MethodGet, "api/system/version", nil)
+	if err != nil {
+		result.Status, result.Message = Fail, err.Error()
+		return result
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c.authenticated = true
+		c.version = parseVersion(body)
+	case http.StatusUnauthorized:
+		result.Status, result.Message = Fail, "Artifactory rejected the credentials"
+		result.Remediation = "Update the credentials with 'jf config edit', or provide them with --access-token or --user and --password."
+		return result
+	default:
+		result.Status, result.Message = Warn, "Unexpected response while authenticating: "+resp.Status
+		return result
+	}
+	if authType == "" {
+		result.Status, result.Message = Warn, "Accessing Artifactory anonymously"
+		result.Remediation = "Most commands require credentials. Configure them with 'jf config add'."
+		return result
+	}
+	if result.Status == "" {
+		result.Status, result.Message = Pass, "Authenticated with "+authType
+	}
+	return result
+}
+
+// Check the expiry of the access token, if it is a JWT. Returns true if the check is done, since the token can't be used.
+func (c *checker) checkTokenExpiry(result CheckResult) (CheckResult, bool) {
+	minutesLeft, err := auth.GetTokenMinutesLeft(c.serverDetails.AccessToken)
+	if err != nil {
+		result.Details = append(result.Details, "The token is a reference token, whose expiry is known only to the server")
+		return result, false
+	}
+	refreshable := c.serverDetails.ArtifactoryRefreshToken != "" || c.serverDetails.RefreshToken != ""
+	left := time.Duration(minutesLeft) * time.Minute
+	switch {
+	case minutesLeft == 0 && !refreshable:
+		result.Status, result.Message = Fail, "The access token has expired"
+		result.Remediation = "Create a new access token, and update it with 'jf config edit'."
+		return result, true
+	case left < expiryWarningPeriod && !refreshable:
+		result.Status, result.Message = Warn, fmt.Sprintf("The access token expires in %d hours", int(left.Hours()))
+		result.Remediation = "Create a new access token before it expires, and update it with 'jf config edit'."
+	case refreshable:
+		result.Details = append(result.Details, "The access token is refreshed automatically")
+	default:
+		result.Details = append(result.Details, fmt.Sprintf("The access token expires in %d days", int(left.Hours()/24)))
+	}
+	return result, false
+}
+
+func parseVersion(body []byte) string {
+	var version struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &version); err != nil {
+		return ""
+	}
+	return version.Version
+}
+
+func (c *checker) checkVersion() CheckResult {
+	result := CheckResult{Name: "Version"}
+	if !c.authenticated {
+		return skipped(result, "the version is available to authenticated users")
+	}
+	if c.version == "" {
+		result.Status, result.Message = Warn, "The Artifactory version could not be determined"
+		return result
+	}
+	if err := clientutils.ValidateMinimumVersion(clientutils.Artifactory, c.version, minSupportedArtifactoryVersion); err != nil {
+		result.Status, result.Message = Fail, fmt.Sprintf("Artifactory %s is not supported", c.version)
+		result.Remediation = fmt.Sprintf("Upgrade Artifactory to version %s or above.", minSupportedArtifactoryVersion)
+		return result
+	}
+	result.Status, result.Message = Pass, "Artifactory "+c.version
+	for _, feature := range features {
+		if clientutils.ValidateMinimumVersion(clientutils.Artifactory, c.version, feature.minVersion) != nil {
+			result.Status, result.Message = Warn, fmt.Sprintf("Artifactory %s does not support some of the features", c.version)
+			result.Details = append(result.Details, fmt.Sprintf("%s: unavailable, requires %s", feature.name, feature.minVersion))
+			result.Remediation = "Upgrade Artifactory to use all the features."
+		} else {
+			result.Details = append(result.Details, feature.name+": available")
+		}
+	}
+	return result
+}
+
+// Check the read, deploy and delete permissions on the repository by deploying, downloading and deleting a probe file.
+// The transfers of the probe file are used to sample the throughput.
+func (c *checker) checkRepoPermissions() []CheckResult {
+	read := CheckResult{Name: "Read permission"}
+	deploy := CheckResult{Name: "Deploy permission"}
+	remove := CheckResult{Name: "Delete permission"}
+	throughput := CheckResult{Name: "Throughput"}
+	if c.repo == "" {
+		reason := "no repository was provided with --repo"
+		return []CheckResult{skipped(read, reason), skipped(deploy, reason), skipped(remove, reason), skipped(throughput, reason)}
+	}
+	if !c.authenticated {
+		reason := "the authentication failed"
+		return []CheckResult{skipped(read, reason), skipped(deploy, reason), skipped(remove, reason), skipped(throughput, reason)}
+	}
+	read = c.checkRepoRead(read)
+	if read.Status == Fail {
+		reason := "the repository can't be read"
+		return []CheckResult{read, skipped(deploy, reason), skipped(remove, reason), skipped(throughput, reason)}
+	}
+	probe := make([]byte, throughputSampleSize)
+	if _, err := rand.Read(probe); err != nil {
+		deploy.Status, deploy.Message = Fail, err.Error()
+		return []CheckResult{read, deploy, skipped(remove, "no probe file was deployed"), skipped(throughput, "no probe file was deployed")}
+	}
+	probePath := fmt.Sprintf("%s/.jfrog-doctor-%d.bin", c.repo, time.Now().UnixNano())
+	start := time.Now()
+	resp, body, err := c.sendRequest(http.MethodPut, probePath, probe)
+	uploadDuration := time.Since(start)
+	if deploy = getProbeResult(deploy, resp, body, err, http.StatusCreated); deploy.Status != Pass {
+		deploy.Remediation = fmt.Sprintf("Grant the user the Deploy/Cache permission on %s.", c.repo)
+		return []CheckResult{read, deploy, skipped(remove, "no probe file was deployed"), skipped(throughput, "no probe file was deployed")}
+	}
+	deploy.Message = "Deployed " + probePath
+	start = time.Now()
+	resp, downloaded, err := c.sendRequest(http.MethodGet, probePath, nil)
+	downloadDuration := time.Since(start)
+	if err == nil && resp.StatusCode == http.StatusOK && len(downloaded) == len(probe) {
+		throughput = getThroughputResult(throughput, uploadDuration, downloadDuration)
+	} else {
+		throughput.Status, throughput.Message = Warn, "The probe file could not be downloaded"
+	}
+	resp, body, err = c.sendRequest(http.MethodDelete, probePath, nil)
+	if remove = getProbeResult(remove, resp, body, err, http.StatusNoContent); remove.Status != Pass {
+		remove.Remediation = fmt.Sprintf("Grant the user the Delete/Overwrite permission on %s, and delete %s manually.", c.repo, probePath)
+	} else {
+		remove.Message = "Deleted " + probePath
+	}
+	return []CheckResult{read, deploy, remove, throughput}
+}
+
+func (c *checker) checkRepoRead(result CheckResult) CheckResult {
+	resp, body, err := c.sendRequest(http.MethodGet, "api/storage/"+c.repo, nil)
+	if result = getProbeResult(result, resp, body, err, http.StatusOK); result.Status == Pass {
+		result.Message = "The repository " + c.repo + " is readable"
+		return result
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		result.Message = "The repository " + c.repo + " does not exist, or the user can't read it"
+	}
+	result.Remediation = fmt.Sprintf("Check the repository name, and grant the user the Read permission on %s.", c.repo)
+	return result
+}
+
+func getProbeResult(result CheckResult, resp *http.Response, body []byte, err error, expectedStatus int) CheckResult {
+	switch {
+	case err != nil:
+		result.Status, result.Message = Fail, err.Error()
+	case resp.StatusCode == expectedStatus || resp.StatusCode == http.StatusOK:
+		result.Status = Pass
+	default:
+		result.Status, result.Message = Fail, fmt.Sprintf("Artifactory response: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return result
+}
+
+func getThroughputResult(result CheckResult, uploadDuration, downloadDuration time.Duration) CheckResult {
+	upload := float64(throughputSampleSize) / max(uploadDuration.Seconds(), 0.001)
+	download := float64(throughputSampleSize) / max(downloadDuration.Seconds(), 0.001)
+	result.Details = []string{
+		fmt.Sprintf("Upload: %.2f MiB/s", upload/(1024*1024)),
+		fmt.Sprintf("Download: %.2f MiB/s", download/(1024*1024)),
+	}
+	if upload < slowThroughput || download < slowThroughput {
+		result.Status, result.Message = Warn, "Transfers are slow"
+		result.Remediation = "Check the bandwidth to Artifactory. Increasing --threads, or --split-count for large downloads, may help."
+		return result
+	}
+	result.Status, result.Message = Pass, fmt.Sprintf("Sampled with a %d MiB file", throughputSampleSize/(1024*1024))
+	return result
+}
+
+func (c *checker) sendRequest(method, apiPath string, content []byte) (*http.Response, []byte, error) {
+	serviceDetails := c.servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	requestUrl := clientutils.AddTrailingSlashIfNeeded(serviceDetails.GetUrl()) + apiPath
+	resp, body, _, err := c.servicesManager.Client().Send(method, requestUrl, content, true, true, &httpClientDetails, "")
+	return resp, body, err
+}
+
+func skipped(result CheckResult, reason string) CheckResult {
+	result.Status, result.Message = Skip, "Skipped, since "+reason
+	return result
+}
+
+func redactUrlCredentials(value string) string {
+	if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+		return parsed.Redacted()
+	}
+	return value
+}
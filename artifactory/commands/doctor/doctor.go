@@ -0,0 +1,146 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type CheckStatus string
+
+const (
+	Pass CheckStatus = "pass"
+	Warn CheckStatus = "warn"
+	Fail CheckStatus = "fail"
+	Skip CheckStatus = "skip"
+)
+
+type OutputFormat string
+
+const (
+	Text OutputFormat = "text"
+	Json OutputFormat = "json"
+)
+
+func GetOutputFormat(format string) (OutputFormat, error) {
+	switch OutputFormat(format) {
+	case "", Text:
+		return Text, nil
+	case Json:
+		return Json, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported output format '%s'. Can be one of 'text' or 'json'", format)
+}
+
+// CheckResult is the outcome of a single diagnostic check.
+type CheckResult struct {
+	Name        string      `json:"name"`
+	Status      CheckStatus `json:"status"`
+	Message     string      `json:"message"`
+	Details     []string    `json:"details,omitempty"`
+	Remediation string      `json:"remediation,omitempty"`
+}
+
+type Report struct {
+	Server  string              `json:"server"`
+	Repo    string              `json:"repo,omitempty"`
+	Checks  []CheckResult       `json:"checks"`
+	Summary map[CheckStatus]int `json:"summary"`
+}
+
+func (r *Report) add(result CheckResult) {
+	r.Checks = append(r.Checks, result)
+	r.Summary[result.Status]++
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, check := range r.Checks {
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Message))
+		for _, detail := range check.Details {
+			sb.WriteString("       " + detail + "\n")
+		}
+		if check.Remediation != "" {
+			sb.WriteString("       Hint: " + check.Remediation + "\n")
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Summary: %d passed, %d warnings, %d failed, %d skipped", r.Summary[Pass], r.Summary[Warn], r.Summary[Fail], r.Summary[Skip]))
+	return sb.String()
+}
+
+// DoctorCommand diagnoses the connection to an Artifactory server, and optionally the permissions on one of its repositories.
+type DoctorCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	format        OutputFormat
+	report        *Report
+}
+
+func NewDoctorCommand() *DoctorCommand {
+	return &DoctorCommand{format: Text}
+}
+
+func (dc *DoctorCommand) SetServerDetails(serverDetails *config.ServerDetails) *DoctorCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DoctorCommand) SetRepo(repo string) *DoctorCommand {
+	dc.repo = repo
+	return dc
+}
+
+func (dc *DoctorCommand) SetFormat(format OutputFormat) *DoctorCommand {
+	dc.format = format
+	return dc
+}
+
+func (dc *DoctorCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DoctorCommand) Report() *Report {
+	return dc.report
+}
+
+func (dc *DoctorCommand) CommandName() string {
+	return "rt_doctor"
+}
+
+func (dc *DoctorCommand) Run() error {
+	dc.report = &Report{Server: dc.serverDetails.ArtifactoryUrl, Repo: dc.repo, Summary: map[CheckStatus]int{}}
+	checker, err := newChecker(dc.serverDetails, dc.repo)
+	if err != nil {
+		return err
+	}
+	for _, check := range checker.getChecks() {
+		for _, result := range check() {
+			log.Debug(fmt.Sprintf("%s: %s", result.Name, result.Status))
+			dc.report.add(result)
+		}
+	}
+	if err = dc.printReport(); err != nil {
+		return err
+	}
+	if failed := dc.report.Summary[Fail]; failed > 0 {
+		return errorutils.CheckErrorf("%d of the checks failed", failed)
+	}
+	return nil
+}
+
+func (dc *DoctorCommand) printReport() error {
+	if dc.format == Json {
+		content, err := json.MarshalIndent(dc.report, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	log.Output(dc.report.String())
+	return nil
+}
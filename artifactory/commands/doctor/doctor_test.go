@@ -0,0 +1,189 @@
+package doctor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAccessToken(expiry time.Time) string {
+	payload := fmt.Sprintf(`{"sub":"jfrt@01/users/admin","exp":%d,"iat":%d}`, expiry.Unix(), time.Now().Unix())
+	return "header." + base64.RawStdEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+// Start a fake Artifactory, which accepts the given token and stores deployed files in memory.
+func startTestArtifactory(t *testing.T, token, version string, tlsServer bool) *httptest.Server {
+	var mutex sync.Mutex
+	files := map[string][]byte{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/system/ping" {
+			_, _ = w.Write([]byte("OK"))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, _ = fmt.Fprintf(w, `{"version":"%s"}`, version)
+		case r.URL.Path == "/api/storage/libs-local":
+			_, _ = w.Write([]byte(`{"repo":"libs-local","path":"/"}`))
+		case r.Method == http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			files[r.URL.Path] = content
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && files[r.URL.Path] != nil:
+			_, _ = w.Write(files[r.URL.Path])
+		case r.Method == http.MethodDelete && files[r.URL.Path] != nil:
+			delete(files, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewUnstartedServer(handler)
+	if tlsServer {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(func() {
+		server.Close()
+		assert.Empty(t, files, "the probe file should be deleted")
+	})
+	return server
+}
+
+func getCheckResults(report *Report) map[string]CheckResult {
+	results := map[string]CheckResult{}
+	for _, check := range report.Checks {
+		results[check.Name] = check
+	}
+	return results
+}
+
+func getCheckStatuses(report *Report) map[string]CheckStatus {
+	statuses := map[string]CheckStatus{}
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestDoctor(t *testing.T) {
+	token := createTestAccessToken(time.Now().Add(72 * time.Hour))
+	server := startTestArtifactory(t, token, "7.70.0", false)
+	doctorCommand := NewDoctorCommand().SetRepo("libs-local").SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/", AccessToken: token})
+
+	require.NoError(t, doctorCommand.Run())
+	assert.Equal(t, map[string]CheckStatus{
+		"DNS resolution":     Pass,
+		"Proxy":              Pass,
+		"TLS":                Warn,
+		"Client certificate": Skip,
+		"Latency":            Pass,
+		// The token expires soon.
+		"Authentication": Warn,
+		// Some features require a newer version.
+		"Version":           Warn,
+		"Read permission":   Pass,
+		"Deploy permission": Pass,
+		"Delete permission": Pass,
+		"Throughput":        getCheckStatuses(doctorCommand.Report())["Throughput"],
+	}, getCheckStatuses(doctorCommand.Report()))
+	results := getCheckResults(doctorCommand.Report())
+	assert.Contains(t, results["Authentication"].Message, "The access token expires in")
+	assert.Contains(t, results["Version"].Details, "Release lifecycle: available")
+	assert.Contains(t, results["Version"].Details, "Draft release bundles: unavailable, requires 7.136.0")
+	assert.Len(t, results["Throughput"].Details, 2)
+}
+
+func TestDoctorUnauthorized(t *testing.T) {
+	server := startTestArtifactory(t, "valid", "7.70.0", false)
+	doctorCommand := NewDoctorCommand().SetRepo("libs-local").SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/", AccessToken: "invalid"})
+
+	assert.ErrorContains(t, doctorCommand.Run(), "1 of the checks failed")
+	statuses := getCheckStatuses(doctorCommand.Report())
+	assert.Equal(t, Fail, statuses["Authentication"])
+	assert.Equal(t, Skip, statuses["Version"])
+	assert.Equal(t, Skip, statuses["Deploy permission"])
+	assert.Contains(t, getCheckResults(doctorCommand.Report())["Authentication"].Remediation, "jf config edit")
+}
+
+func TestDoctorExpiredToken(t *testing.T) {
+	token := createTestAccessToken(time.Now().Add(-time.Hour))
+	server := startTestArtifactory(t, token, "7.70.0", false)
+	doctorCommand := NewDoctorCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/", AccessToken: token})
+
+	assert.Error(t, doctorCommand.Run())
+	results := getCheckResults(doctorCommand.Report())
+	assert.Equal(t, Fail, results["Authentication"].Status)
+	assert.Equal(t, "The access token has expired", results["Authentication"].Message)
+}
+
+func TestDoctorUntrustedCertificate(t *testing.T) {
+	token := createTestAccessToken(time.Now().Add(30 * 24 * time.Hour))
+	server := startTestArtifactory(t, token, "7.136.0", true)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/", AccessToken: token}
+
+	doctorCommand := NewDoctorCommand().SetServerDetails(serverDetails)
+	assert.Error(t, doctorCommand.Run())
+	tlsResult := getCheckResults(doctorCommand.Report())["TLS"]
+	assert.Equal(t, Fail, tlsResult.Status)
+	assert.Contains(t, tlsResult.Remediation, "--insecure-tls")
+	assert.Contains(t, tlsResult.Details[0], "Version: TLS")
+
+	serverDetails.InsecureTls = true
+	doctorCommand = NewDoctorCommand().SetServerDetails(serverDetails)
+	require.NoError(t, doctorCommand.Run())
+	statuses := getCheckStatuses(doctorCommand.Report())
+	assert.Equal(t, Warn, statuses["TLS"])
+	assert.Equal(t, Pass, statuses["Authentication"])
+	assert.Equal(t, Pass, statuses["Version"])
+}
+
+func TestReportOutput(t *testing.T) {
+	report := &Report{Server: "https://acme.jfrog.io/artifactory/", Summary: map[CheckStatus]int{}}
+	report.add(CheckResult{Name: "Latency", Status: Pass, Message: "Artifactory is reachable", Details: []string{"Average: 20ms"}})
+	report.add(CheckResult{Name: "Authentication", Status: Fail, Message: "Artifactory rejected the credentials", Remediation: "Update the credentials."})
+
+	assert.Equal(t, strings.Join([]string{
+		"[PASS] Latency: Artifactory is reachable",
+		"       Average: 20ms",
+		"[FAIL] Authentication: Artifactory rejected the credentials",
+		"       Hint: Update the credentials.",
+		"Summary: 1 passed, 0 warnings, 1 failed, 0 skipped",
+	}, "\n"), report.String())
+
+	content, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"server":"https://acme.jfrog.io/artifactory/","checks":[
+		{"name":"Latency","status":"pass","message":"Artifactory is reachable","details":["Average: 20ms"]},
+		{"name":"Authentication","status":"fail","message":"Artifactory rejected the credentials","remediation":"Update the credentials."}],
+		"summary":{"pass":1,"fail":1}}`, string(content))
+}
+
+func TestGetOutputFormat(t *testing.T) {
+	for format, expected := range map[string]OutputFormat{"": Text, "text": Text, "json": Json} {
+		actual, err := GetOutputFormat(format)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	_, err := GetOutputFormat("xml")
+	assert.ErrorContains(t, err, "unsupported output format")
+}
@@ -0,0 +1,103 @@
+package generic
+
+import (
+	"fmt"
+	"time"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// CleanupCommand deletes the files selected by declarative cleanup policies.
+type CleanupCommand struct {
+	DeleteCommand
+	policiesPath string
+}
+
+func NewCleanupCommand() *CleanupCommand {
+	return &CleanupCommand{DeleteCommand: *NewDeleteCommand()}
+}
+
+func (cc *CleanupCommand) SetPoliciesPath(policiesPath string) *CleanupCommand {
+	cc.policiesPath = policiesPath
+	return cc
+}
+
+func (cc *CleanupCommand) CommandName() string {
+	return "rt_cleanup"
+}
+
+func (cc *CleanupCommand) Run() (err error) {
+	policies, err := ReadCleanupPolicies(cc.policiesPath)
+	if err != nil {
+		return err
+	}
+	report, err := cc.evaluatePolicies(policies)
+	if err != nil {
+		return err
+	}
+	if cc.DryRun() {
+		log.Output(report.String())
+		return nil
+	}
+	log.Info(report.String())
+	if len(report.Candidates) == 0 {
+		return nil
+	}
+	if !cc.quiet && !coreutils.AskYesNo(fmt.Sprintf("The cleanup will delete %d files (%s). Are you sure you want to continue?", len(report.Candidates), formatByteSize(report.TotalSize())), false) {
+		return nil
+	}
+	reader, err := writeCleanupCandidates(report.Candidates)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(reader, &err)
+	successCount, failedCount, err := cc.DeleteFiles(reader)
+	cc.result.SetSuccessCount(successCount)
+	cc.result.SetFailCount(failedCount)
+	return err
+}
+
+func (cc *CleanupCommand) evaluatePolicies(policies *CleanupPolicies) (*CleanupReport, error) {
+	serverDetails, err := cc.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, cc.retries, cc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	report := &CleanupReport{}
+	now := time.Now()
+	for _, policy := range policies.Policies {
+		log.Info(fmt.Sprintf("Evaluating the cleanup policy '%s'...", policy.Name))
+		items, err := artifactoryUtils.ExecuteAqlQuery(servicesManager, policy.createAqlQuery())
+		if err != nil {
+			return nil, fmt.Errorf("cleanup policy '%s': %w", policy.Name, err)
+		}
+		candidates := policy.Evaluate(items, now)
+		log.Info(fmt.Sprintf("The cleanup policy '%s' matched %d of %d files.", policy.Name, len(candidates), len(items)))
+		report.add(candidates)
+	}
+	return report, nil
+}
+
+// Write the files to delete to a reader, in the form expected by the delete service.
+func writeCleanupCandidates(candidates []*CleanupCandidate) (reader *content.ContentReader, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		writer.Write(candidate.Item)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
@@ -0,0 +1,140 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cleanupTestNow = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func createCleanupTestItem(repo, itemPath, name string, size int64, createdDaysAgo, downloadedDaysAgo int, props ...clientutils.Property) clientutils.ResultItem {
+	item := clientutils.ResultItem{Repo: repo, Path: itemPath, Name: name, Type: "file", Size: size, Properties: props,
+		Created: cleanupTestNow.AddDate(0, 0, -createdDaysAgo).Format(artifactoryTimeFormat)}
+	if downloadedDaysAgo >= 0 {
+		item.Stats = []clientutils.Stat{{Downloaded: cleanupTestNow.AddDate(0, 0, -downloadedDaysAgo).Format(artifactoryTimeFormat)}}
+	}
+	return item
+}
+
+func getCandidatePaths(candidates []*CleanupCandidate) (paths []string) {
+	for _, candidate := range candidates {
+		paths = append(paths, candidate.Path())
+	}
+	return
+}
+
+func TestReadCleanupPolicies(t *testing.T) {
+	policiesPath := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(policiesPath, []byte(`
+policies:
+  - name: old-snapshots
+    repos: [libs-snapshot-*, "!libs-snapshot-keep"]
+    path: com/acme/*
+    notDownloadedSince: 30d
+    keepLast: 2
+    excludeProps:
+      retain: "true"
+  - repos: [remote-cache]
+    maxRepoSize: 1.5GB
+`), 0644))
+	policies, err := ReadCleanupPolicies(policiesPath)
+	require.NoError(t, err)
+	require.Len(t, policies.Policies, 2)
+	policy := policies.Policies[0]
+	assert.Equal(t, 30*24*time.Hour, policy.notDownloadedSince)
+	assert.Equal(t, map[string]string{"retain": "true"}, policy.ExcludeProps)
+	assert.Equal(t, `items.find({"$and":[{"type":"file"},{"$or":[{"repo":{"$match":"libs-snapshot-*"}}]},{"repo":{"$nmatch":"libs-snapshot-keep"}},{"path":{"$match":"com/acme/*"}}]}).include("repo","path","name","size","created","property.*","stat.downloaded")`, policy.createAqlQuery())
+	assert.Equal(t, "policy-2", policies.Policies[1].Name)
+	assert.Equal(t, int64(1536*1024*1024), policies.Policies[1].maxRepoSize)
+}
+
+func TestReadCleanupPoliciesInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		policies      string
+		expectedError string
+	}{
+		{"empty", "policies: []", "no cleanup policies"},
+		{"no repos", "policies: [{name: a, olderThan: 1d}]", "has no repos"},
+		{"no conditions", "policies: [{name: a, repos: [r]}]", "should have at least one"},
+		{"invalid age", "policies: [{name: a, repos: [r], olderThan: old}]", "invalid age"},
+		{"invalid size", "policies: [{name: a, repos: [r], maxRepoSize: big}]", "invalid size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policiesPath := filepath.Join(t.TempDir(), "policies.yaml")
+			require.NoError(t, os.WriteFile(policiesPath, []byte(tt.policies), 0644))
+			_, err := ReadCleanupPolicies(policiesPath)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestCleanupPolicyEvaluate(t *testing.T) {
+	retain := clientutils.Property{Key: "retain", Value: "true"}
+	items := []clientutils.ResultItem{
+		createCleanupTestItem("libs", "app/1.0", "app-1.0.jar", 10, 100, 90),
+		createCleanupTestItem("libs", "app/1.1", "app-1.1.jar", 10, 80, -1),
+		createCleanupTestItem("libs", "app/1.2", "app-1.2.jar", 10, 60, 1),
+		createCleanupTestItem("libs", "app/1.3", "app-1.3.jar", 10, 55, 50),
+		createCleanupTestItem("libs", "app/1.4", "app-1.4.jar", 10, 20, -1),
+		createCleanupTestItem("libs", "app/0.9", "app-0.9.jar", 10, 200, -1, retain),
+		// Package level files and folders which aren't versions don't count as versions, and are kept by keepLast.
+		createCleanupTestItem("libs", "app", "maven-metadata.xml", 1, 10, -1),
+		createCleanupTestItem("libs", "app/docs", "index.html", 1, 10, -1),
+		// Files with an invalid creation time are skipped.
+		{Repo: "libs", Path: "app/0.1", Name: "app-0.1.jar", Type: "file", Size: 10, Created: "invalid"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   CleanupPolicy
+		expected []string
+	}{
+		{"older than", CleanupPolicy{OlderThan: "70d"}, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.1/app-1.1.jar"}},
+		{"not downloaded since", CleanupPolicy{NotDownloadedSince: "45d"}, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.1/app-1.1.jar", "libs/app/1.3/app-1.3.jar"}},
+		{"keep last", CleanupPolicy{KeepLast: 3}, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.1/app-1.1.jar"}},
+		{"keep last and not downloaded", CleanupPolicy{KeepLast: 2, NotDownloadedSince: "45d"}, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.1/app-1.1.jar"}},
+		// The least recently used files are deleted until the repository is within the budget.
+		{"repo budget", CleanupPolicy{MaxRepoSize: "37B"}, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.1/app-1.1.jar", "libs/app/1.3/app-1.3.jar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Name, tt.policy.Repos, tt.policy.ExcludeProps = tt.name, []string{"libs"}, map[string]string{"retain": "*"}
+			require.NoError(t, tt.policy.init())
+			assert.Equal(t, tt.expected, getCandidatePaths(tt.policy.Evaluate(items, cleanupTestNow)))
+		})
+	}
+}
+
+func TestCleanupReport(t *testing.T) {
+	report := &CleanupReport{}
+	first := &CleanupCandidate{Item: createCleanupTestItem("libs", "a", "1.jar", 2048, 1, -1), Policy: "first", Reason: "old"}
+	duplicate := &CleanupCandidate{Item: first.Item, Policy: "second"}
+	second := &CleanupCandidate{Item: createCleanupTestItem("docs", ".", "readme.md", 10, 1, -1), Policy: "second", Reason: "unused"}
+	report.add([]*CleanupCandidate{first})
+	report.add([]*CleanupCandidate{duplicate, second})
+
+	require.Len(t, report.Candidates, 2)
+	assert.Equal(t, "first", report.Candidates[0].Policy)
+	assert.Equal(t, int64(2058), report.TotalSize())
+	assert.Equal(t, "libs/a/1.jar (2.0 KiB) [first: old]\n"+
+		"docs/readme.md (10 B) [second: unused]\n"+
+		"docs: 1 files, 10 B\n"+
+		"libs: 1 files, 2.0 KiB\n"+
+		"Total: 2 files, 2.0 KiB to reclaim", report.String())
+}
+
+func TestParseByteSize(t *testing.T) {
+	for size, expected := range map[string]int64{"": 0, "100": 100, "100B": 100, "2KB": 2048, "1.5mb": 1572864, "10GB": 10 << 30, "1TB": 1 << 40} {
+		actual, err := parseByteSize(size)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, size)
+	}
+}
@@ -0,0 +1,399 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// The format of the timestamps returned by AQL.
+const artifactoryTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// The names of version folders, such as 1.0, 2.1.0-SNAPSHOT or v3.
+var versionFolderPattern = regexp.MustCompile(`^[vV]?[0-9]`)
+
+// CleanupPolicies is the content of the cleanup policies YAML file. For example:
+//
+//	policies:
+//	  - name: old-snapshots
+//	    repos: [libs-snapshot-*, "!libs-snapshot-keep"]
+//	    path: com/acme/*
+//	    notDownloadedSince: 30d
+//	    keepLast: 5
+//	    excludeProps:
+//	      retain: "true"
+//	  - name: remote-cache
+//	    repos: [maven-remote-cache]
+//	    maxRepoSize: 50GB
+type CleanupPolicies struct {
+	Policies []*CleanupPolicy `yaml:"policies"`
+}
+
+// CleanupPolicy selects files to delete. A file is deleted only if it matches all the conditions of the policy.
+type CleanupPolicy struct {
+	Name string `yaml:"name"`
+	// Repository name patterns, which may contain wildcards. A pattern starting with '!' excludes the matching repositories.
+	Repos []string `yaml:"repos"`
+	// A pattern of the paths in the repositories, which may contain wildcards.
+	Path string `yaml:"path,omitempty"`
+	// The minimal time since the file was created, such as 30d or 12h.
+	OlderThan string `yaml:"olderThan,omitempty"`
+	// The minimal time since the file was last downloaded. Files which were never downloaded are aged since their creation.
+	NotDownloadedSince string `yaml:"notDownloadedSince,omitempty"`
+	// The number of newest versions to keep per package path.
+	// A version is a folder whose name starts with a digit, optionally prefixed by 'v', and its parent folder is the package path.
+	// Files which aren't in a version folder, such as maven-metadata.xml, are kept.
+	KeepLast int `yaml:"keepLast,omitempty"`
+	// Files having any of these properties are kept. A value of '*' matches any value.
+	ExcludeProps map[string]string `yaml:"excludeProps,omitempty"`
+	// The maximal total size of the files matched by the policy in each repository, such as 10GB.
+	// If set, only the least recently used files are deleted, until the repository is within the budget.
+	MaxRepoSize string `yaml:"maxRepoSize,omitempty"`
+
+	olderThan          time.Duration
+	notDownloadedSince time.Duration
+	maxRepoSize        int64
+}
+
+func ReadCleanupPolicies(policiesPath string) (*CleanupPolicies, error) {
+	data, err := os.ReadFile(policiesPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policies := &CleanupPolicies{}
+	if err = yaml.Unmarshal(data, policies); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the cleanup policies file %s: %s", policiesPath, err.Error())
+	}
+	if len(policies.Policies) == 0 {
+		return nil, errorutils.CheckErrorf("no cleanup policies were found in %s", policiesPath)
+	}
+	for i, policy := range policies.Policies {
+		if policy.Name == "" {
+			policy.Name = "policy-" + strconv.Itoa(i+1)
+		}
+		if err = policy.init(); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+func (cp *CleanupPolicy) init() (err error) {
+	if len(cp.Repos) == 0 {
+		return errorutils.CheckErrorf("the cleanup policy '%s' has no repos", cp.Name)
+	}
+	if cp.KeepLast < 0 {
+		return errorutils.CheckErrorf("the keepLast value of the cleanup policy '%s' should not be negative", cp.Name)
+	}
+	if cp.olderThan, err = parseCleanupAge(cp.OlderThan); err != nil {
+		return fmt.Errorf("cleanup policy '%s': %w", cp.Name, err)
+	}
+	if cp.notDownloadedSince, err = parseCleanupAge(cp.NotDownloadedSince); err != nil {
+		return fmt.Errorf("cleanup policy '%s': %w", cp.Name, err)
+	}
+	if cp.maxRepoSize, err = parseByteSize(cp.MaxRepoSize); err != nil {
+		return fmt.Errorf("cleanup policy '%s': %w", cp.Name, err)
+	}
+	if cp.olderThan == 0 && cp.notDownloadedSince == 0 && cp.KeepLast == 0 && cp.maxRepoSize == 0 {
+		// Avoid deleting all the files of the repositories by mistake.
+		return errorutils.CheckErrorf("the cleanup policy '%s' should have at least one of olderThan, notDownloadedSince, keepLast or maxRepoSize", cp.Name)
+	}
+	return nil
+}
+
+// Parse an age such as 30d, 2w or a Go duration such as 12h.
+func parseCleanupAge(age string) (time.Duration, error) {
+	if age == "" {
+		return 0, nil
+	}
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	if unit, ok := units[age[len(age)-1:]]; ok {
+		if count, err := strconv.Atoi(age[:len(age)-1]); err == nil && count >= 0 {
+			return time.Duration(count) * unit, nil
+		}
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration < 0 {
+		return 0, errorutils.CheckErrorf("invalid age '%s'. The age should be in the form of 30d, 2w or 12h", age)
+	}
+	return duration, nil
+}
+
+// Parse a size such as 500MB or 10GB. The units are binary, so 1KB is 1024 bytes.
+func parseByteSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for i, unit := range []string{"KB", "MB", "GB", "TB"} {
+		if strings.HasSuffix(normalized, unit) {
+			multiplier = 1 << (10 * (i + 1))
+			normalized = strings.TrimSuffix(normalized, unit)
+			break
+		}
+	}
+	normalized = strings.TrimSuffix(normalized, "B")
+	value, err := strconv.ParseFloat(strings.TrimSpace(normalized), 64)
+	if err != nil || value < 0 {
+		return 0, errorutils.CheckErrorf("invalid size '%s'. The size should be in the form of 500MB or 10GB", size)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+func formatByteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// Create the AQL query of the files in the policy's repositories and path, including their properties and download statistics.
+func (cp *CleanupPolicy) createAqlQuery() string {
+	var included, excluded []string
+	for _, repo := range cp.Repos {
+		if strings.HasPrefix(repo, "!") {
+			excluded = append(excluded, fmt.Sprintf(`{"repo":{"$nmatch":%s}}`, quoteAqlValue(strings.TrimPrefix(repo, "!"))))
+			continue
+		}
+		included = append(included, fmt.Sprintf(`{"repo":{"$match":%s}}`, quoteAqlValue(repo)))
+	}
+	criteria := []string{`{"type":"file"}`, fmt.Sprintf(`{"$or":[%s]}`, strings.Join(included, ","))}
+	criteria = append(criteria, excluded...)
+	if cp.Path != "" {
+		criteria = append(criteria, fmt.Sprintf(`{"path":{"$match":%s}}`, quoteAqlValue(strings.Trim(cp.Path, "/"))))
+	}
+	return fmt.Sprintf(`items.find({"$and":[%s]}).include("repo","path","name","size","created","property.*","stat.downloaded")`, strings.Join(criteria, ","))
+}
+
+func quoteAqlValue(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// CleanupCandidate is a file which is deleted by a cleanup policy.
+type CleanupCandidate struct {
+	Item   clientutils.ResultItem
+	Policy string
+	Reason string
+}
+
+func (cc *CleanupCandidate) Path() string {
+	return path.Join(cc.Item.Repo, cc.Item.Path, cc.Item.Name)
+}
+
+type cleanupItem struct {
+	clientutils.ResultItem
+	created  time.Time
+	lastUsed time.Time
+}
+
+func newCleanupItem(item clientutils.ResultItem) (*cleanupItem, error) {
+	ci := &cleanupItem{ResultItem: item}
+	var err error
+	if ci.created, err = time.Parse(artifactoryTimeFormat, item.Created); err != nil {
+		return nil, errorutils.CheckErrorf("unexpected creation time '%s': %s", item.Created, err.Error())
+	}
+	ci.lastUsed = ci.created
+	for _, stat := range item.Stats {
+		if downloaded, err := time.Parse(artifactoryTimeFormat, stat.Downloaded); err == nil && downloaded.After(ci.lastUsed) {
+			ci.lastUsed = downloaded
+		}
+	}
+	return ci, nil
+}
+
+// The version folder of a file, and its parent package path.
+// Returns false if the file isn't in a version folder.
+func (ci *cleanupItem) getPackageAndVersion() (string, string, bool) {
+	versionPath := path.Join(ci.Repo, ci.Path)
+	if ci.Path == "." || !versionFolderPattern.MatchString(path.Base(ci.Path)) {
+		return "", "", false
+	}
+	return path.Dir(versionPath), versionPath, true
+}
+
+func (cp *CleanupPolicy) isExcludedByProps(item *cleanupItem) bool {
+	for _, prop := range item.Properties {
+		if value, ok := cp.ExcludeProps[prop.Key]; ok && (value == "*" || value == prop.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate the policy on the files matched by its repositories and path, and return the files to delete, sorted by path.
+func (cp *CleanupPolicy) Evaluate(items []clientutils.ResultItem, now time.Time) []*CleanupCandidate {
+	cleanupItems := make([]*cleanupItem, 0, len(items))
+	for _, item := range items {
+		cleanupItem, err := newCleanupItem(item)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Skipping %s: %s", path.Join(item.Repo, item.Path, item.Name), err.Error()))
+			continue
+		}
+		cleanupItems = append(cleanupItems, cleanupItem)
+	}
+	oldVersions := cp.getOldVersions(cleanupItems)
+	var candidates []*cleanupItem
+	reasons := make(map[*cleanupItem][]string)
+	for _, item := range cleanupItems {
+		if cp.isExcludedByProps(item) {
+			continue
+		}
+		var itemReasons []string
+		if cp.olderThan > 0 {
+			if now.Sub(item.created) < cp.olderThan {
+				continue
+			}
+			itemReasons = append(itemReasons, "created "+item.created.Format(time.DateOnly))
+		}
+		if cp.notDownloadedSince > 0 {
+			if now.Sub(item.lastUsed) < cp.notDownloadedSince {
+				continue
+			}
+			itemReasons = append(itemReasons, "last used "+item.lastUsed.Format(time.DateOnly))
+		}
+		if cp.KeepLast > 0 {
+			_, version, isVersion := item.getPackageAndVersion()
+			if !isVersion || !oldVersions[version] {
+				continue
+			}
+			itemReasons = append(itemReasons, fmt.Sprintf("not in the last %d versions", cp.KeepLast))
+		}
+		candidates = append(candidates, item)
+		reasons[item] = itemReasons
+	}
+	if cp.maxRepoSize > 0 {
+		candidates = cp.applyRepoBudget(cleanupItems, candidates, reasons)
+	}
+	result := make([]*CleanupCandidate, 0, len(candidates))
+	for _, item := range candidates {
+		result = append(result, &CleanupCandidate{Item: item.ResultItem, Policy: cp.Name, Reason: strings.Join(reasons[item], ", ")})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path() < result[j].Path()
+	})
+	return result
+}
+
+// Get the version folders which are not among the newest versions of their package path.
+// A version is as new as its most recently created file.
+func (cp *CleanupPolicy) getOldVersions(items []*cleanupItem) map[string]bool {
+	if cp.KeepLast == 0 {
+		return nil
+	}
+	versionsCreated := make(map[string]time.Time)
+	packageVersions := make(map[string][]string)
+	for _, item := range items {
+		packagePath, version, isVersion := item.getPackageAndVersion()
+		if !isVersion {
+			continue
+		}
+		created, ok := versionsCreated[version]
+		if !ok {
+			packageVersions[packagePath] = append(packageVersions[packagePath], version)
+		}
+		if !ok || item.created.After(created) {
+			versionsCreated[version] = item.created
+		}
+	}
+	oldVersions := make(map[string]bool)
+	for _, versions := range packageVersions {
+		sort.Slice(versions, func(i, j int) bool {
+			if versionsCreated[versions[i]].Equal(versionsCreated[versions[j]]) {
+				return versions[i] > versions[j]
+			}
+			return versionsCreated[versions[i]].After(versionsCreated[versions[j]])
+		})
+		for i := cp.KeepLast; i < len(versions); i++ {
+			oldVersions[versions[i]] = true
+		}
+	}
+	return oldVersions
+}
+
+// Keep only the least recently used candidates whose deletion is needed to bring each repository within the budget.
+func (cp *CleanupPolicy) applyRepoBudget(items, candidates []*cleanupItem, reasons map[*cleanupItem][]string) []*cleanupItem {
+	repoSizes := make(map[string]int64)
+	for _, item := range items {
+		repoSizes[item.Repo] += item.Size
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+	var withinBudget []*cleanupItem
+	for _, item := range candidates {
+		if repoSizes[item.Repo] <= cp.maxRepoSize {
+			continue
+		}
+		repoSizes[item.Repo] -= item.Size
+		reasons[item] = append(reasons[item], "repository exceeds "+formatByteSize(cp.maxRepoSize))
+		withinBudget = append(withinBudget, item)
+	}
+	return withinBudget
+}
+
+// CleanupReport lists the files to delete by all the policies. A file matched by several policies is attributed to the first one.
+type CleanupReport struct {
+	Candidates []*CleanupCandidate
+	paths      map[string]bool
+}
+
+func (cr *CleanupReport) add(candidates []*CleanupCandidate) {
+	if cr.paths == nil {
+		cr.paths = make(map[string]bool)
+	}
+	for _, candidate := range candidates {
+		if cr.paths[candidate.Path()] {
+			continue
+		}
+		cr.paths[candidate.Path()] = true
+		cr.Candidates = append(cr.Candidates, candidate)
+	}
+}
+
+func (cr *CleanupReport) TotalSize() (total int64) {
+	for _, candidate := range cr.Candidates {
+		total += candidate.Item.Size
+	}
+	return
+}
+
+// String returns the files to delete and the reclaimed space per repository.
+func (cr *CleanupReport) String() string {
+	var sb strings.Builder
+	repoSizes := make(map[string]int64)
+	repoCounts := make(map[string]int)
+	for _, candidate := range cr.Candidates {
+		sb.WriteString(fmt.Sprintf("%s (%s) [%s: %s]\n", candidate.Path(), formatByteSize(candidate.Item.Size), candidate.Policy, candidate.Reason))
+		repoSizes[candidate.Item.Repo] += candidate.Item.Size
+		repoCounts[candidate.Item.Repo]++
+	}
+	repos := make([]string, 0, len(repoSizes))
+	for repo := range repoSizes {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		sb.WriteString(fmt.Sprintf("%s: %d files, %s\n", repo, repoCounts[repo], formatByteSize(repoSizes[repo])))
+	}
+	sb.WriteString(fmt.Sprintf("Total: %d files, %s to reclaim", len(cr.Candidates), formatByteSize(cr.TotalSize())))
+	return sb.String()
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -21,12 +22,26 @@ import (
 
 type DirectDownloadCommand struct {
 	DownloadCommand
+	resume   bool
+	cacheDir string
 }
 
 func NewDirectDownloadCommand() *DirectDownloadCommand {
 	return &DirectDownloadCommand{DownloadCommand: *NewDownloadCommand()}
 }
 
+// Set to resume interrupted downloads.
+func (ddc *DirectDownloadCommand) SetResume(resume bool) *DirectDownloadCommand {
+	ddc.resume = resume
+	return ddc
+}
+
+// Set a content-addressable cache directory, which may be shared by several workspaces on one machine.
+func (ddc *DirectDownloadCommand) SetCacheDir(cacheDir string) *DirectDownloadCommand {
+	ddc.cacheDir = cacheDir
+	return ddc
+}
+
 func (ddc *DirectDownloadCommand) CommandName() string {
 	return "rt_direct_download"
 }
@@ -82,34 +97,25 @@ func (ddc *DirectDownloadCommand) directDownload() error {
 		downloadParamsArray = append(downloadParamsArray, downParams)
 	}
 	// Perform download.
-	// In case of build-info collection/sync-deletes operation/a detailed summary is required, we use the download service which provides results file reader,
-	// otherwise we use the download service which provides only general counters.
+	// Files are listed with the storage API and downloaded by the resumable downloader, which skips up-to-date files,
+	// and always provides a results file reader for build-info collection, sync-deletes and the detailed summary.
 	var totalDownloaded, totalFailed int
-	var summary *serviceutils.OperationSummary
-	if toCollect || ddc.SyncDeletesPath() != "" || ddc.DetailedSummary() {
-		summary, err = servicesManager.DirectDownloadFilesWithSummary(downloadParamsArray...)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-		}
-		if summary != nil {
-			defer gofrog.Close(summary.ArtifactsDetailsReader, &err)
-			// If 'detailed summary' was requested, then the reader should not be closed here.
-			// It will be closed after it will be used to generate the summary.
-			if ddc.DetailedSummary() {
-				ddc.result.SetReader(summary.TransferDetailsReader)
-			} else {
-				defer gofrog.Close(summary.TransferDetailsReader, &err)
-			}
-			totalDownloaded = summary.TotalSucceeded
-			totalFailed = summary.TotalFailed
-		}
-	} else {
-		totalDownloaded, totalFailed, err = servicesManager.DirectDownloadFiles(downloadParamsArray...)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
+	summary, err := ddc.resumableDownload(servicesManager, downloadParamsArray...)
+	if err != nil {
+		errorOccurred = true
+		log.Error(err)
+	}
+	if summary != nil {
+		defer gofrog.Close(summary.ArtifactsDetailsReader, &err)
+		// If 'detailed summary' was requested, then the reader should not be closed here.
+		// It will be closed after it will be used to generate the summary.
+		if ddc.DetailedSummary() {
+			ddc.result.SetReader(summary.TransferDetailsReader)
+		} else {
+			defer gofrog.Close(summary.TransferDetailsReader, &err)
 		}
+		totalDownloaded = summary.TotalSucceeded
+		totalFailed = summary.TotalFailed
 	}
 	ddc.result.SetSuccessCount(totalDownloaded)
 	ddc.result.SetFailCount(totalFailed)
@@ -175,6 +181,20 @@ func (ddc *DirectDownloadCommand) directDownload() error {
 	return err
 }
 
+func (ddc *DirectDownloadCommand) resumableDownload(servicesManager artifactory.ArtifactoryServicesManager, downloadParams ...services.DirectDownloadParams) (*serviceutils.OperationSummary, error) {
+	downloader := &resumableDownloader{
+		servicesManager: servicesManager,
+		threads:         ddc.configuration.Threads,
+		retries:         ddc.retries,
+		resume:          ddc.resume,
+		dryRun:          ddc.DryRun(),
+	}
+	if ddc.cacheDir != "" {
+		downloader.cache = &downloadCache{dir: ddc.cacheDir}
+	}
+	return downloader.download(downloadParams...)
+}
+
 func getDirectDownloadParams(f *spec.File, configuration *utils.DownloadConfiguration) (downParams services.DirectDownloadParams, err error) {
 	downParams = services.NewDirectDownloadParams()
 	downParams.CommonParams, err = f.ToCommonParams()
@@ -0,0 +1,624 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// An interrupted download is kept next to its target with this suffix, so that it can be resumed.
+const directDownloadPartialSuffix = ".jfrog-partial"
+
+// directDownloadFile is a file to download, as listed by the storage API.
+type directDownloadFile struct {
+	Repo      string
+	Path      string
+	Size      int64
+	Sha1      string
+	Sha256    string
+	localPath string
+	params    *services.DirectDownloadParams
+}
+
+func (ddf *directDownloadFile) repoPath() string {
+	return path.Join(ddf.Repo, ddf.Path)
+}
+
+type storageItemInfo struct {
+	Size      string `json:"size"`
+	Checksums struct {
+		Sha1   string `json:"sha1"`
+		Sha256 string `json:"sha256"`
+	} `json:"checksums"`
+	Children []json.RawMessage `json:"children"`
+}
+
+type storageFileList struct {
+	Files []struct {
+		Uri    string `json:"uri"`
+		Size   int64  `json:"size"`
+		Folder bool   `json:"folder"`
+		Sha1   string `json:"sha1"`
+		Sha2   string `json:"sha2"`
+	} `json:"files"`
+}
+
+// resumableDownloader downloads the files matching direct-download params, without AQL.
+// Files whose local copy is up to date are skipped, interrupted downloads may be resumed with range requests,
+// and the content of downloaded files may be shared between workspaces through a local cache.
+// Other files are downloaded by the direct download service, which splits large files and handles symlinks.
+type resumableDownloader struct {
+	servicesManager        artifactory.ArtifactoryServicesManager
+	threads                int
+	retries                int
+	resume                 bool
+	dryRun                 bool
+	cache                  *downloadCache
+	filesTransfersWriter   *content.ContentWriter
+	artifactsDetailsWriter *content.ContentWriter
+	mutex                  sync.Mutex
+	successCount           int
+	failCount              int
+}
+
+// Download the files, and return a summary with the details of the downloaded files.
+func (rd *resumableDownloader) download(downloadParams ...services.DirectDownloadParams) (summary *serviceutils.OperationSummary, err error) {
+	if rd.filesTransfersWriter, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+		return nil, err
+	}
+	if rd.artifactsDetailsWriter, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+		return nil, errors.Join(err, rd.filesTransfersWriter.Close())
+	}
+	var files []*directDownloadFile
+	var errs error
+	for i := range downloadParams {
+		params := &downloadParams[i]
+		if params.Build != "" {
+			// The artifacts of a build are listed by the direct download service, from the build-info.
+			errs = errors.Join(errs, rd.downloadBuildArtifacts(*params))
+			continue
+		}
+		paramsFiles, err := rd.listFiles(params)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		files = append(files, paramsFiles...)
+	}
+	files = removeDuplicateLocalPaths(files)
+	producerConsumer := parallel.NewRunner(rd.threads, uint(len(files)+1), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	for _, file := range files {
+		_, _ = producerConsumer.AddTaskWithError(func(threadId int) error {
+			err := rd.downloadFile(file, clientutils.GetLogMsgPrefix(threadId, rd.dryRun))
+			rd.mutex.Lock()
+			defer rd.mutex.Unlock()
+			if err != nil {
+				rd.failCount++
+				return fmt.Errorf("failed to download %s: %w", file.repoPath(), err)
+			}
+			rd.successCount++
+			return nil
+		}, errorsQueue.AddError)
+	}
+	producerConsumer.Done()
+	producerConsumer.Run()
+	if err = errors.Join(rd.filesTransfersWriter.Close(), rd.artifactsDetailsWriter.Close()); err != nil {
+		return nil, err
+	}
+	summary = &serviceutils.OperationSummary{
+		TransferDetailsReader:  content.NewContentReader(rd.filesTransfersWriter.GetFilePath(), content.DefaultKey),
+		ArtifactsDetailsReader: content.NewContentReader(rd.artifactsDetailsWriter.GetFilePath(), content.DefaultKey),
+		TotalSucceeded:         rd.successCount,
+		TotalFailed:            rd.failCount,
+	}
+	return summary, errors.Join(errs, errorsQueue.GetError())
+}
+
+// Download the artifacts of a build with the direct download service, and add them to the summary.
+func (rd *resumableDownloader) downloadBuildArtifacts(params services.DirectDownloadParams) (err error) {
+	summary, err := rd.servicesManager.DirectDownloadFilesWithSummary(params)
+	if summary == nil {
+		return err
+	}
+	defer ioutils.Close(summary.TransferDetailsReader, &err)
+	defer ioutils.Close(summary.ArtifactsDetailsReader, &err)
+	rd.successCount += summary.TotalSucceeded
+	rd.failCount += summary.TotalFailed
+	for details := new(clientutils.FileTransferDetails); summary.TransferDetailsReader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		rd.filesTransfersWriter.Write(*details)
+	}
+	for details := new(serviceutils.ArtifactDetails); summary.ArtifactsDetailsReader.NextRecord(details) == nil; details = new(serviceutils.ArtifactDetails) {
+		rd.artifactsDetailsWriter.Write(*details)
+	}
+	return errors.Join(err, summary.TransferDetailsReader.GetError(), summary.ArtifactsDetailsReader.GetError())
+}
+
+// Get the local path of an artifact, the same way the direct download service does.
+func getDirectDownloadLocalPath(artifactPath string, params *services.DirectDownloadParams) string {
+	target := params.GetTarget()
+	if target == "" {
+		target = "./"
+	}
+	if params.IsFlat() {
+		return filepath.Join(target, path.Base(artifactPath))
+	}
+	return filepath.Join(target, filepath.FromSlash(artifactPath))
+}
+
+// List the files matching the pattern of the params using the storage API, the same way the direct download service does:
+// a pattern ending with a slash downloads the folder, a pattern with wildcards matches the file names in its folder,
+// and any other pattern is a single file.
+func (rd *resumableDownloader) listFiles(params *services.DirectDownloadParams) ([]*directDownloadFile, error) {
+	repo, artifactPath, found := strings.Cut(params.GetPattern(), "/")
+	if !found || repo == "" {
+		return nil, errorutils.CheckErrorf("invalid pattern format: %s. Should be 'repo/path/to/artifact'", params.GetPattern())
+	}
+	var dir, namePattern string
+	switch {
+	case strings.HasSuffix(params.GetPattern(), "/"):
+		dir, namePattern = strings.TrimSuffix(artifactPath, "/"), "*"
+	case strings.ContainsAny(artifactPath, "*?"):
+		dir, namePattern = path.Dir(artifactPath), path.Base(artifactPath)
+	default:
+		if isDirectDownloadExcluded(artifactPath, params.GetExclusions()) {
+			return nil, nil
+		}
+		file, err := rd.getFileInfo(repo, artifactPath, params)
+		if err != nil {
+			return nil, err
+		}
+		return []*directDownloadFile{file}, nil
+	}
+	if dir == "." {
+		dir = ""
+	}
+	deep := "0"
+	if params.IsRecursive() {
+		deep = "1"
+	}
+	list := &storageFileList{}
+	if err := rd.getStorageInfo(path.Join("api/storage", repo, dir)+"?list&deep="+deep+"&listFolders=0", list); err != nil {
+		return nil, err
+	}
+	var files []*directDownloadFile
+	for _, listed := range list.Files {
+		filePath := strings.TrimPrefix(path.Join(dir, listed.Uri), "/")
+		if matched, _ := path.Match(namePattern, path.Base(filePath)); listed.Folder || !matched || isDirectDownloadExcluded(filePath, params.GetExclusions()) {
+			continue
+		}
+		file := &directDownloadFile{Repo: repo, Path: filePath, Size: listed.Size, Sha1: listed.Sha1, Sha256: listed.Sha2, params: params}
+		file.localPath = getDirectDownloadLocalPath(file.Path, params)
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (rd *resumableDownloader) getFileInfo(repo, artifactPath string, params *services.DirectDownloadParams) (*directDownloadFile, error) {
+	info := &storageItemInfo{}
+	if err := rd.getStorageInfo(path.Join("api/storage", repo, artifactPath), info); err != nil {
+		return nil, err
+	}
+	if info.Children != nil {
+		return nil, errorutils.CheckErrorf("%s is a folder. Add a trailing slash to the pattern to download its content", path.Join(repo, artifactPath))
+	}
+	file := &directDownloadFile{Repo: repo, Path: artifactPath, Sha1: info.Checksums.Sha1, Sha256: info.Checksums.Sha256, params: params}
+	if _, err := fmt.Sscan(info.Size, &file.Size); err != nil {
+		return nil, errorutils.CheckErrorf("unexpected size of %s: %s", file.repoPath(), info.Size)
+	}
+	file.localPath = getDirectDownloadLocalPath(file.Path, params)
+	return file, nil
+}
+
+// Files are excluded as by the direct download service: if their path or name matches an exclusion pattern.
+func isDirectDownloadExcluded(artifactPath string, exclusions []string) bool {
+	for _, exclusion := range exclusions {
+		for _, candidate := range []string{artifactPath, path.Base(artifactPath)} {
+			if matched, _ := path.Match(exclusion, candidate); matched {
+				return true
+			}
+		}
+		// A ** pattern matches any suffix of the path.
+		if strings.Contains(exclusion, "**") {
+			segments := strings.Split(artifactPath, "/")
+			for i := range segments {
+				if matched, _ := path.Match(strings.ReplaceAll(exclusion, "**", "*"), strings.Join(segments[i:], "/")); matched {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (rd *resumableDownloader) getStorageInfo(apiPath string, result any) error {
+	serviceDetails := rd.servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := rd.servicesManager.Client().SendGet(clientutils.AddTrailingSlashIfNeeded(serviceDetails.GetUrl())+escapeUrlPath(apiPath), true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("Artifactory response: %s\n%s", resp.Status, clientutils.IndentJson(body))
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+// Like the download service, only the first file is downloaded to a local path which several files are resolved to.
+func removeDuplicateLocalPaths(files []*directDownloadFile) []*directDownloadFile {
+	localPaths := make(map[string]bool)
+	var unique []*directDownloadFile
+	for _, file := range files {
+		if localPaths[file.localPath] {
+			log.Debug(fmt.Sprintf("Skipping %q, since another file is downloaded to %q", file.repoPath(), file.localPath))
+			continue
+		}
+		localPaths[file.localPath] = true
+		unique = append(unique, file)
+	}
+	return unique
+}
+
+// Escape the segments of a URL path, leaving its query as is.
+func escapeUrlPath(urlPath string) string {
+	urlPath, query, found := strings.Cut(urlPath, "?")
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	if found {
+		return strings.Join(segments, "/") + "?" + query
+	}
+	return strings.Join(segments, "/")
+}
+
+func (rd *resumableDownloader) downloadFile(file *directDownloadFile, logMsgPrefix string) error {
+	if rd.dryRun {
+		log.Info(fmt.Sprintf("%sWould download %q to %q", logMsgPrefix, file.repoPath(), file.localPath))
+		return nil
+	}
+	details, upToDate, err := getUpToDateFileDetails(file.localPath, file)
+	if err != nil {
+		return err
+	}
+	switch {
+	case upToDate:
+		log.Info(fmt.Sprintf("%s%q is up to date", logMsgPrefix, file.localPath))
+	case rd.cache != nil && rd.cache.fetch(file, file.localPath):
+		log.Info(fmt.Sprintf("%sCopied %q from the cache to %q", logMsgPrefix, file.repoPath(), file.localPath))
+	case !rd.resume:
+		return rd.downloadWithService(file)
+	default:
+		log.Info(fmt.Sprintf("%sDownloading %q to %q", logMsgPrefix, file.repoPath(), file.localPath))
+		if err = rd.downloadAndVerify(file); err != nil {
+			return err
+		}
+	}
+	if details == nil {
+		if details, err = fileutils.GetFileDetails(file.localPath, true); err != nil {
+			return err
+		}
+	}
+	if rd.cache != nil {
+		rd.cache.store(file.localPath, details.Checksum.Sha256)
+	}
+	if file.params.IsSymlink() {
+		if err = restoreSymlink(file.localPath, file.params); err != nil {
+			return err
+		}
+	}
+	if file.params.IsExplode() {
+		if err = clientutils.ExtractArchive(filepath.Dir(file.localPath), filepath.Base(file.localPath), filepath.Base(file.localPath), logMsgPrefix, file.params.IsBypassArchiveInspection()); err != nil {
+			return err
+		}
+	}
+	rd.saveSummary(file, details)
+	return nil
+}
+
+// Download a single file with the direct download service, which splits large files, validates the checksums of the response,
+// and handles symlinks and archives. The file is downloaded to the same local path, since the pattern is its full path.
+func (rd *resumableDownloader) downloadWithService(file *directDownloadFile) error {
+	commonParams := *file.params.CommonParams
+	commonParams.Pattern = file.repoPath()
+	commonParams.Exclusions = nil
+	fileParams := *file.params
+	fileParams.CommonParams = &commonParams
+	succeeded, failed, err := rd.servicesManager.DirectDownloadFiles(fileParams)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || succeeded == 0 {
+		return errorutils.CheckErrorf("the direct download service failed to download the file")
+	}
+	// Symlinks and extracted archives are not regular files anymore, so their checksums are the listed ones.
+	details := &fileutils.FileDetails{Checksum: buildinfo.Checksum{Sha1: file.Sha1, Sha256: file.Sha256}, Size: file.Size}
+	if info, err := os.Lstat(file.localPath); err == nil && info.Mode().IsRegular() {
+		if details, err = fileutils.GetFileDetails(file.localPath, true); err != nil {
+			return err
+		}
+		if rd.cache != nil {
+			rd.cache.store(file.localPath, details.Checksum.Sha256)
+		}
+	}
+	rd.saveSummary(file, details)
+	return nil
+}
+
+// Replace a symlink placeholder with the symlink, as the direct download service does.
+func restoreSymlink(localPath string, params *services.DirectDownloadParams) error {
+	placeholder, err := os.ReadFile(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	target, isSymlink := strings.CutPrefix(string(placeholder), "symlink:")
+	if !isSymlink {
+		return nil
+	}
+	target = strings.TrimSpace(target)
+	if strings.Contains(filepath.Clean(target), "..") {
+		return errorutils.CheckErrorf("symlink target contains path traversal: %s", target)
+	}
+	if params.ValidateSymlinks() && !fileutils.IsPathExists(target, false) {
+		return errorutils.CheckErrorf("symlink validation failed, target doesn't exist: %s", target)
+	}
+	if err = os.Remove(localPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Created symlink:", localPath, "->", target)
+	return errorutils.CheckError(os.Symlink(target, localPath))
+}
+
+// Check whether the local file has the remote file's content. The details of an up-to-date file are returned, to avoid computing them again.
+func getUpToDateFileDetails(localPath string, file *directDownloadFile) (*fileutils.FileDetails, bool, error) {
+	info, err := os.Stat(localPath)
+	if err != nil || info.IsDir() || info.Size() != file.Size {
+		return nil, false, nil
+	}
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return nil, false, err
+	}
+	return details, isMatchingChecksum(details, file), nil
+}
+
+func isMatchingChecksum(details *fileutils.FileDetails, file *directDownloadFile) bool {
+	if file.Sha256 != "" {
+		return details.Checksum.Sha256 == file.Sha256
+	}
+	// Old Artifactory versions may not return the SHA-256 checksum.
+	return file.Sha1 != "" && details.Checksum.Sha1 == file.Sha1
+}
+
+// Download a file to a partial file next to it, verify its checksum and move it to its place.
+func (rd *resumableDownloader) downloadAndVerify(file *directDownloadFile) (err error) {
+	if err = os.MkdirAll(filepath.Dir(file.localPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	partialPath := file.localPath + directDownloadPartialSuffix
+	defer func() {
+		// Without resume, there is no use in keeping an interrupted download.
+		if err != nil && !rd.resume {
+			err = errors.Join(err, removeIfExists(partialPath))
+		}
+	}()
+	for attempt := 0; ; attempt++ {
+		err = rd.downloadToPartial(file, partialPath)
+		if err == nil || !rd.resume || attempt >= rd.retries {
+			break
+		}
+		log.Warn(fmt.Sprintf("The download of %q was interrupted, resuming: %s", file.repoPath(), err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	details, err := fileutils.GetFileDetails(partialPath, true)
+	if err != nil {
+		return err
+	}
+	if err = verifyChecksum(details, file); err != nil {
+		return errors.Join(err, removeIfExists(partialPath))
+	}
+	return errorutils.CheckError(os.Rename(partialPath, file.localPath))
+}
+
+// Return an error describing the mismatch, with the checksum which was compared.
+func verifyChecksum(details *fileutils.FileDetails, file *directDownloadFile) error {
+	if isMatchingChecksum(details, file) {
+		return nil
+	}
+	if file.Sha256 != "" {
+		return errorutils.CheckErrorf("checksum mismatch: expected SHA-256 %s, got %s", file.Sha256, details.Checksum.Sha256)
+	}
+	return errorutils.CheckErrorf("checksum mismatch: expected SHA-1 %s, got %s", file.Sha1, details.Checksum.Sha1)
+}
+
+// Download the missing part of a file. With resume, the download continues from the end of an existing partial file.
+func (rd *resumableDownloader) downloadToPartial(file *directDownloadFile, partialPath string) (err error) {
+	var offset int64
+	if info, statErr := os.Stat(partialPath); rd.resume && statErr == nil {
+		offset = info.Size()
+	}
+	if offset > 0 && offset >= file.Size {
+		// The partial file is complete, or longer than the remote file, in which case the checksum verification fails.
+		return nil
+	}
+	serviceDetails := rd.servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	if offset > 0 {
+		serviceutils.AddHeader("Range", fmt.Sprintf("bytes=%d-", offset), &httpClientDetails.Headers)
+	}
+	downloadUrl := clientutils.AddTrailingSlashIfNeeded(serviceDetails.GetUrl()) + escapeUrlPath(file.repoPath())
+	resp, _, _, err := rd.servicesManager.Client().Send(http.MethodGet, downloadUrl, nil, true, false, &httpClientDetails, "")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return errorutils.CheckErrorf("unexpected Content-Range: %s", resp.Header.Get("Content-Range"))
+		}
+		log.Debug(fmt.Sprintf("Resuming the download of %q from byte %d", file.repoPath(), offset))
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	case http.StatusOK:
+		// The server ignored the range, so the file is downloaded from the start.
+	default:
+		return errorutils.CheckErrorf("Artifactory response: %s", resp.Status)
+	}
+	partialFile, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(partialFile.Close()))
+	}()
+	_, err = io.Copy(partialFile, resp.Body)
+	return errorutils.CheckError(err)
+}
+
+// Write the details of a downloaded file, in the form written by the direct download service.
+func (rd *resumableDownloader) saveSummary(file *directDownloadFile, details *fileutils.FileDetails) {
+	rtUrl := strings.TrimSuffix(rd.servicesManager.GetConfig().GetServiceDetails().GetUrl(), "/")
+	rd.filesTransfersWriter.Write(clientutils.FileTransferDetails{SourcePath: "/" + file.repoPath(), TargetPath: file.localPath, RtUrl: rtUrl, Sha256: details.Checksum.Sha256})
+	rd.artifactsDetailsWriter.Write(serviceutils.ArtifactDetails{
+		ArtifactoryPath: file.repoPath(),
+		Checksums:       buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5, Sha256: details.Checksum.Sha256},
+	})
+}
+
+func removeIfExists(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+// downloadCache is a content-addressable store of downloaded files, which may be shared by several workspaces on one machine.
+// Files are stored by their SHA-256 checksum, and are copied to and from the workspaces, so that changing a file in a workspace
+// never changes the cached content.
+type downloadCache struct {
+	dir string
+}
+
+func (dc *downloadCache) getPath(sha256 string) string {
+	return filepath.Join(dc.dir, sha256[:2], sha256)
+}
+
+// Place the cached content of a file in the local path. Returns false if the content is not in the cache.
+// Since the cache may be shared, the copied content is verified, and corrupted content is removed from the cache.
+func (dc *downloadCache) fetch(file *directDownloadFile, localPath string) bool {
+	if len(file.Sha256) < 2 {
+		return false
+	}
+	cachePath := dc.getPath(file.Sha256)
+	if info, err := os.Stat(cachePath); err != nil || info.Size() != file.Size {
+		return false
+	}
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		log.Warn("Failed to create", filepath.Dir(localPath)+":", err.Error())
+		return false
+	}
+	corrupted := false
+	err := copyFileAtomically(cachePath, localPath, func(tempPath string) error {
+		details, err := fileutils.GetFileDetails(tempPath, true)
+		if err != nil {
+			return err
+		}
+		err = verifyChecksum(details, file)
+		corrupted = err != nil
+		return err
+	})
+	if err != nil {
+		log.Warn("Failed to use the cached content of", file.repoPath()+":", err.Error())
+		if corrupted {
+			if removeErr := removeIfExists(cachePath); removeErr != nil {
+				log.Warn("Failed to remove the corrupted cache entry", cachePath+":", removeErr.Error())
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Add the content of a local file to the cache. Failures are logged, since the cache is only an optimization.
+func (dc *downloadCache) store(localPath, sha256 string) {
+	if len(sha256) < 2 {
+		return
+	}
+	cachePath := dc.getPath(sha256)
+	if _, err := os.Stat(cachePath); err == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		log.Warn("Failed to create the cache directory:", err.Error())
+		return
+	}
+	if err := copyFileAtomically(localPath, cachePath, nil); err != nil {
+		log.Warn("Failed to add", localPath, "to the cache:", err.Error())
+	}
+}
+
+// Copy a file to a temporary file next to the destination, verify it if needed, and then move it into place.
+// This way, the destination is never partially written, even if another process writes it at the same time.
+func copyFileAtomically(sourcePath, destPath string, verify func(tempPath string) error) (err error) {
+	tempFile, err := os.CreateTemp(filepath.Dir(destPath), filepath.Base(destPath)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, removeIfExists(tempPath))
+		}
+	}()
+	if err = copyFileContent(sourcePath, tempFile); err != nil {
+		return err
+	}
+	if verify != nil {
+		if err = verify(tempPath); err != nil {
+			return err
+		}
+	}
+	return errorutils.CheckError(os.Rename(tempPath, destPath))
+}
+
+// Copy the content of a file to an open file, and close it.
+func copyFileContent(sourcePath string, dest *os.File) (err error) {
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(dest.Close()))
+	}()
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	_, err = io.Copy(dest, source)
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,306 @@
+package generic
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A fake Artifactory, serving the storage API and files with range requests.
+type testDirectDownloadServer struct {
+	*httptest.Server
+	files     map[string]string
+	mutex     sync.Mutex
+	downloads []string
+}
+
+func newTestDirectDownloadServer(t *testing.T, files map[string]string) *testDirectDownloadServer {
+	server := &testDirectDownloadServer{files: files}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/system/version" {
+			_, _ = fmt.Fprint(w, `{"version":"7.90.0"}`)
+			return
+		}
+		if storagePath, found := strings.CutPrefix(r.URL.Path, "/api/storage/"); found {
+			server.writeStorageInfo(w, r, strings.TrimSuffix(storagePath, "/"))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fileContent, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Checksum-Sha1", getTestSha1(fileContent))
+		w.Header().Set("X-Checksum-Sha256", getTestSha256(fileContent))
+		// The direct download service checks the size of the file with a HEAD request, which isn't a download.
+		if r.Method == http.MethodGet {
+			server.mutex.Lock()
+			server.downloads = append(server.downloads, r.URL.Path+" "+r.Header.Get("Range"))
+			server.mutex.Unlock()
+		}
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			assert.NoError(t, err)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(fileContent)-1, len(fileContent)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(fileContent[offset:]))
+			return
+		}
+		_, _ = w.Write([]byte(fileContent))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Write the file list of a folder, or the info of a file or a folder.
+func (tds *testDirectDownloadServer) writeStorageInfo(w http.ResponseWriter, r *http.Request, storagePath string) {
+	if fileContent, isFile := tds.files[storagePath]; isFile {
+		_, _ = fmt.Fprintf(w, `{"size":"%d","checksums":{"sha1":"%s","sha256":"%s"}}`, len(fileContent), getTestSha1(fileContent), getTestSha256(fileContent))
+		return
+	}
+	var listed []string
+	for filePath, fileContent := range tds.files {
+		relativePath, found := strings.CutPrefix(filePath, storagePath+"/")
+		if !found || (r.URL.Query().Get("deep") != "1" && strings.Contains(relativePath, "/")) {
+			continue
+		}
+		listed = append(listed, fmt.Sprintf(`{"uri":"/%s","size":%d,"folder":false,"sha1":"%s","sha2":"%s"}`,
+			relativePath, len(fileContent), getTestSha1(fileContent), getTestSha256(fileContent)))
+	}
+	if len(listed) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	sort.Strings(listed)
+	if r.URL.Query().Has("list") {
+		_, _ = fmt.Fprintf(w, `{"files":[%s]}`, strings.Join(listed, ","))
+		return
+	}
+	_, _ = fmt.Fprint(w, `{"children":[]}`)
+}
+
+func (tds *testDirectDownloadServer) getDownloads() []string {
+	tds.mutex.Lock()
+	defer tds.mutex.Unlock()
+	sort.Strings(tds.downloads)
+	return tds.downloads
+}
+
+func getTestSha1(content string) string {
+	checksum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func getTestSha256(content string) string {
+	checksum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func newTestResumableDownloader(t *testing.T, server *testDirectDownloadServer) *resumableDownloader {
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	return &resumableDownloader{servicesManager: servicesManager, threads: 2, retries: 1, resume: true}
+}
+
+func createTestDirectDownloadParams(pattern, target string, recursive bool, exclusions ...string) services.DirectDownloadParams {
+	params := services.NewDirectDownloadParams()
+	params.Pattern, params.Target, params.Recursive, params.Exclusions = pattern, target, recursive, exclusions
+	return params
+}
+
+func TestResumableDownloaderListFiles(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{
+		"repo/app/app.txt":     "t",
+		"repo/app/1.0/app.bin": "a",
+		"repo/app/2.0/app.bin": "b",
+	})
+	downloader := newTestResumableDownloader(t, server)
+	flatParams := createTestDirectDownloadParams("repo/app/", "out/", true)
+	flatParams.Flat = true
+
+	tests := []struct {
+		name     string
+		params   services.DirectDownloadParams
+		expected []string
+	}{
+		{"folder", createTestDirectDownloadParams("repo/app/", "out/", true), []string{"out/app/1.0/app.bin", "out/app/2.0/app.bin", "out/app/app.txt"}},
+		{"folder not recursive", createTestDirectDownloadParams("repo/app/", "out/", false), []string{"out/app/app.txt"}},
+		// Wildcards match the names of the files in the folder of the pattern.
+		{"wildcard", createTestDirectDownloadParams("repo/app/*.bin", "out/", true), []string{"out/app/1.0/app.bin", "out/app/2.0/app.bin"}},
+		{"wildcard not recursive", createTestDirectDownloadParams("repo/app/*", "out/", false), []string{"out/app/app.txt"}},
+		{"single file", createTestDirectDownloadParams("repo/app/1.0/app.bin", "out/", true), []string{"out/app/1.0/app.bin"}},
+		{"flat", flatParams, []string{"out/app.bin", "out/app.bin", "out/app.txt"}},
+		{"exclusions", createTestDirectDownloadParams("repo/app/", "out/", true, "*.txt", "app/1.0/*"), []string{"out/app/2.0/app.bin"}},
+		{"excluded file", createTestDirectDownloadParams("repo/app/app.txt", "out/", true, "**/*.txt"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := downloader.listFiles(&tt.params)
+			require.NoError(t, err)
+			var localPaths []string
+			for _, file := range files {
+				localPaths = append(localPaths, filepath.ToSlash(file.localPath))
+				assert.Equal(t, getTestSha256(server.files[file.repoPath()]), file.Sha256)
+				assert.Equal(t, getTestSha1(server.files[file.repoPath()]), file.Sha1)
+				assert.Equal(t, int64(len(server.files[file.repoPath()])), file.Size)
+			}
+			assert.Equal(t, tt.expected, localPaths)
+		})
+	}
+	// The duplicates are removed before the download.
+	assert.Len(t, removeDuplicateLocalPaths([]*directDownloadFile{{Path: "a", localPath: "x"}, {Path: "b", localPath: "x"}}), 1)
+
+	folderParams := createTestDirectDownloadParams("repo/app", "out/", true)
+	_, err := downloader.listFiles(&folderParams)
+	assert.ErrorContains(t, err, "is a folder")
+}
+
+func TestResumableDownloaderResume(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{"repo/big.bin": "0123456789", "repo/small.bin": "abc"})
+	target := t.TempDir() + string(filepath.Separator)
+	// The download of big.bin was interrupted after 4 bytes, and small.bin is up to date.
+	require.NoError(t, os.WriteFile(filepath.Join(target, "big.bin"+directDownloadPartialSuffix), []byte("0123"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "small.bin"), []byte("abc"), 0644))
+
+	summary, err := newTestResumableDownloader(t, server).download(createTestDirectDownloadParams("repo/", target, true))
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.TotalSucceeded)
+	assert.Equal(t, []string{"/repo/big.bin bytes=4-"}, server.getDownloads())
+	downloaded, err := os.ReadFile(filepath.Join(target, "big.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(downloaded))
+	assert.NoFileExists(t, filepath.Join(target, "big.bin"+directDownloadPartialSuffix))
+	length, err := summary.TransferDetailsReader.Length()
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+}
+
+func TestResumableDownloaderChecksumMismatch(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{"repo/file.bin": "0123456789"})
+	target := t.TempDir() + string(filepath.Separator)
+	// A corrupted partial file fails the verification, and is removed so that the next attempt starts from scratch.
+	require.NoError(t, os.WriteFile(filepath.Join(target, "file.bin"+directDownloadPartialSuffix), []byte("xxxx"), 0644))
+
+	summary, err := newTestResumableDownloader(t, server).download(createTestDirectDownloadParams("repo/file.bin", target, true))
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.Equal(t, 1, summary.TotalFailed)
+	assert.NoFileExists(t, filepath.Join(target, "file.bin"))
+	assert.NoFileExists(t, filepath.Join(target, "file.bin"+directDownloadPartialSuffix))
+}
+
+func TestResumableDownloaderCache(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{"repo/file.bin": "content"})
+	cache := &downloadCache{dir: t.TempDir()}
+	workspaces := []string{t.TempDir() + string(filepath.Separator), t.TempDir() + string(filepath.Separator)}
+	for _, workspace := range workspaces {
+		downloader := newTestResumableDownloader(t, server)
+		downloader.cache = cache
+		_, err := downloader.download(createTestDirectDownloadParams("repo/file.bin", workspace, true))
+		require.NoError(t, err)
+		downloaded, err := os.ReadFile(filepath.Join(workspace, "file.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(downloaded))
+	}
+	// The second workspace got the file from the cache.
+	assert.Equal(t, []string{"/repo/file.bin "}, server.getDownloads())
+	assert.FileExists(t, cache.getPath(getTestSha256("content")))
+}
+
+func TestResumableDownloaderCorruptedCache(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{"repo/file.bin": "content"})
+	cache := &downloadCache{dir: t.TempDir()}
+	workspace := t.TempDir() + string(filepath.Separator)
+	downloader := newTestResumableDownloader(t, server)
+	downloader.cache = cache
+	_, err := downloader.download(createTestDirectDownloadParams("repo/file.bin", workspace, true))
+	require.NoError(t, err)
+
+	// The cache holds a copy, so changing the file in the workspace doesn't change the cached content.
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "file.bin"), []byte("changed"), 0644))
+	cached, err := os.ReadFile(cache.getPath(getTestSha256("content")))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(cached))
+
+	// Cached content of the same size but with another checksum is removed, and the file is downloaded again.
+	require.NoError(t, os.WriteFile(cache.getPath(getTestSha256("content")), []byte("corrupt"), 0644))
+	otherWorkspace := t.TempDir() + string(filepath.Separator)
+	_, err = downloader.download(createTestDirectDownloadParams("repo/file.bin", otherWorkspace, true))
+	require.NoError(t, err)
+	downloaded, err := os.ReadFile(filepath.Join(otherWorkspace, "file.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(downloaded))
+	assert.Equal(t, []string{"/repo/file.bin ", "/repo/file.bin "}, server.getDownloads())
+	cached, err = os.ReadFile(cache.getPath(getTestSha256("content")))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(cached))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	details := &fileutils.FileDetails{Checksum: buildinfo.Checksum{Sha1: "local-sha1", Sha256: "local-sha256"}}
+	assert.ErrorContains(t, verifyChecksum(details, &directDownloadFile{Sha1: "sha1", Sha256: "sha256"}), "expected SHA-256 sha256, got local-sha256")
+	// Without a SHA-256 checksum, the SHA-1 checksums are compared and reported.
+	assert.ErrorContains(t, verifyChecksum(details, &directDownloadFile{Sha1: "sha1"}), "expected SHA-1 sha1, got local-sha1")
+	assert.NoError(t, verifyChecksum(details, &directDownloadFile{Sha1: "local-sha1"}))
+}
+
+func TestResumableDownloaderWithoutResume(t *testing.T) {
+	server := newTestDirectDownloadServer(t, map[string]string{"repo/new.bin": "new", "repo/same.bin": "same"})
+	target := t.TempDir() + string(filepath.Separator)
+	// Unchanged files are skipped without resume too.
+	require.NoError(t, os.WriteFile(filepath.Join(target, "same.bin"), []byte("same"), 0644))
+
+	downloader := newTestResumableDownloader(t, server)
+	downloader.resume = false
+	summary, err := downloader.download(createTestDirectDownloadParams("repo/", target, true))
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.TotalSucceeded)
+	// Other files are downloaded by the direct download service.
+	assert.Equal(t, []string{"/repo/new.bin "}, server.getDownloads())
+	downloaded, err := os.ReadFile(filepath.Join(target, "new.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(downloaded))
+	length, err := summary.ArtifactsDetailsReader.Length()
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+}
+
+func TestRestoreSymlink(t *testing.T) {
+	dir := t.TempDir()
+	params := services.NewDirectDownloadParams()
+	placeholder := filepath.Join(dir, "link")
+	require.NoError(t, os.WriteFile(placeholder, []byte("symlink:target.txt"), 0644))
+	require.NoError(t, restoreSymlink(placeholder, &params))
+	target, err := os.Readlink(placeholder)
+	require.NoError(t, err)
+	assert.Equal(t, "target.txt", target)
+
+	// Regular files are kept, and targets outside the download are rejected.
+	regular := filepath.Join(dir, "regular")
+	require.NoError(t, os.WriteFile(regular, []byte("content"), 0644))
+	assert.NoError(t, restoreSymlink(regular, &params))
+	assert.FileExists(t, regular)
+	require.NoError(t, os.WriteFile(placeholder+"2", []byte("symlink:../outside"), 0644))
+	assert.ErrorContains(t, restoreSymlink(placeholder+"2", &params), "path traversal")
+}
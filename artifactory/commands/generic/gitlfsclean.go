@@ -2,9 +2,13 @@ package generic
 
 import (
 	"fmt"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -40,6 +44,9 @@ func (glc *GitLfsCommand) Run() error {
 		return err
 	}
 
+	if glc.configuration.Report {
+		return glc.report(servicesManager)
+	}
 	gitLfsCleanParams := getGitLfsCleanParams(glc.configuration)
 
 	filesToDeleteReader, err := servicesManager.GetUnreferencedGitLfsFiles(gitLfsCleanParams)
@@ -85,11 +92,45 @@ func (glc *GitLfsCommand) deleteLfsFilesFromArtifactory(deleteItems *content.Con
 
 type GitLfsCleanConfiguration struct {
 	Quiet   bool
+	Report  bool
 	Refs    string
 	Repo    string
 	GitPath string
 }
 
+// Print the number and total size of the unreferenced LFS files, for each of the comma-separated refs patterns and for all of them together.
+// Nothing is deleted.
+func (glc *GitLfsCommand) report(servicesManager artifactory.ArtifactoryServicesManager) error {
+	refsSets := strings.Split(glc.configuration.Refs, ",")
+	if len(refsSets) > 1 {
+		refsSets = append(refsSets, glc.configuration.Refs)
+	}
+	for _, refs := range refsSets {
+		gitLfsCleanParams := getGitLfsCleanParams(glc.configuration)
+		gitLfsCleanParams.Refs = refs
+		count, totalSize, err := countUnreferencedGitLfsFiles(servicesManager, gitLfsCleanParams)
+		if err != nil {
+			return err
+		}
+		log.Output(fmt.Sprintf("%s: %d orphan files, %s", refs, count, formatByteSize(totalSize)))
+	}
+	return nil
+}
+
+func countUnreferencedGitLfsFiles(servicesManager artifactory.ArtifactoryServicesManager, gitLfsCleanParams services.GitLfsCleanParams) (count int, totalSize int64, err error) {
+	reader, err := servicesManager.GetUnreferencedGitLfsFiles(gitLfsCleanParams)
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(reader, &err)
+	for resultItem := new(clientutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(clientutils.ResultItem) {
+		count++
+		totalSize += resultItem.Size
+	}
+	err = reader.GetError()
+	return
+}
+
 func getGitLfsCleanParams(configuration *GitLfsCleanConfiguration) (gitLfsCleanParams services.GitLfsCleanParams) {
 	gitLfsCleanParams = services.NewGitLfsCleanParams()
 	gitLfsCleanParams.GitPath = configuration.GitPath
@@ -0,0 +1,337 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DefaultGitLfsMigrateRefs = "refs/*"
+	lfsMediaType             = "application/vnd.git-lfs+json"
+)
+
+// GitLfsMigrateCommand uploads the LFS objects referenced by the history of a Git repository to an Artifactory LFS repository.
+// The objects are read from the local LFS storage of the clone, or downloaded from the source LFS server.
+type GitLfsMigrateCommand struct {
+	GenericCommand
+	gitPath   string
+	repo      string
+	refs      string
+	sourceUrl string
+	threads   int
+}
+
+func NewGitLfsMigrateCommand() *GitLfsMigrateCommand {
+	return &GitLfsMigrateCommand{GenericCommand: *NewGenericCommand(), refs: DefaultGitLfsMigrateRefs}
+}
+
+func (glm *GitLfsMigrateCommand) SetGitPath(gitPath string) *GitLfsMigrateCommand {
+	glm.gitPath = gitPath
+	return glm
+}
+
+func (glm *GitLfsMigrateCommand) SetRepo(repo string) *GitLfsMigrateCommand {
+	glm.repo = repo
+	return glm
+}
+
+func (glm *GitLfsMigrateCommand) SetRefs(refs string) *GitLfsMigrateCommand {
+	if refs != "" {
+		glm.refs = refs
+	}
+	return glm
+}
+
+// Set the URL of the source LFS server, from which objects missing in the local LFS storage are downloaded.
+// Credentials may be provided in the URL.
+func (glm *GitLfsMigrateCommand) SetSourceUrl(sourceUrl string) *GitLfsMigrateCommand {
+	glm.sourceUrl = sourceUrl
+	return glm
+}
+
+func (glm *GitLfsMigrateCommand) SetThreads(threads int) *GitLfsMigrateCommand {
+	glm.threads = threads
+	return glm
+}
+
+func (glm *GitLfsMigrateCommand) CommandName() string {
+	return "rt_git_lfs_migrate"
+}
+
+func (glm *GitLfsMigrateCommand) Run() (err error) {
+	if glm.repo == "" {
+		return errorutils.CheckErrorf("the target LFS repository should be provided with the --repo option")
+	}
+	if glm.gitPath == "" {
+		if glm.gitPath, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	log.Info("Collecting LFS pointers from the Git references matching", glm.refs, "...")
+	objects, err := CollectLfsObjects(glm.gitPath, getGitLfsRefsRegex(glm.refs))
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(glm.serverDetails, glm.retries, glm.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	existing, err := listLfsObjectsInArtifactory(servicesManager, glm.repo)
+	if err != nil {
+		return err
+	}
+	missing := getMissingLfsObjects(objects, existing)
+	var missingSize int64
+	for _, object := range missing {
+		missingSize += object.Size
+	}
+	log.Info(fmt.Sprintf("Found %d LFS objects, %d of which (%s) are missing in %s.", len(objects), len(missing), formatByteSize(missingSize), glm.repo))
+	if glm.DryRun() {
+		for _, object := range missing {
+			log.Output(fmt.Sprintf("%s (%s) %s", getLfsObjectPath(object.Oid), formatByteSize(object.Size), object.Path))
+		}
+		return nil
+	}
+	migrator := &lfsObjectsMigrator{
+		GitLfsMigrateCommand: glm,
+		servicesManager:      servicesManager,
+		localLfsDir:          getLocalLfsDir(glm.gitPath),
+	}
+	if glm.sourceUrl != "" {
+		if err = migrator.initSource(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(migrator.tempDir))
+		}()
+	}
+	err = migrator.migrate(missing)
+	glm.result.SetSuccessCount(migrator.successCount)
+	glm.result.SetFailCount(migrator.failCount)
+	return err
+}
+
+// Get the LFS storage directory of a clone or of a bare repository.
+func getLocalLfsDir(gitPath string) string {
+	if info, err := os.Stat(filepath.Join(gitPath, ".git")); err == nil && info.IsDir() {
+		return filepath.Join(gitPath, ".git", "lfs")
+	}
+	return filepath.Join(gitPath, "lfs")
+}
+
+// List the objects in the LFS repository, mapping their oids to their sha256 checksums.
+func listLfsObjectsInArtifactory(servicesManager artifactory.ArtifactoryServicesManager, repo string) (map[string]string, error) {
+	aql := fmt.Sprintf(`items.find({"repo":%s,"path":{"$match":"objects/*"},"type":"file"}).include("name","sha256")`, quoteAqlValue(repo))
+	items, err := artifactoryUtils.ExecuteAqlQuery(servicesManager, aql)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]string, len(items))
+	for _, item := range items {
+		existing[item.Name] = item.Sha256
+	}
+	return existing, nil
+}
+
+// Get the objects which are not in the repository, or whose checksum in the repository is wrong, sorted by oid.
+func getMissingLfsObjects(objects map[string]*LfsObject, existing map[string]string) []*LfsObject {
+	var missing []*LfsObject
+	for oid, object := range objects {
+		if sha256, ok := existing[oid]; ok && (sha256 == "" || sha256 == oid) {
+			continue
+		}
+		missing = append(missing, object)
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Oid < missing[j].Oid
+	})
+	return missing
+}
+
+type lfsObjectsMigrator struct {
+	*GitLfsMigrateCommand
+	servicesManager artifactory.ArtifactoryServicesManager
+	localLfsDir     string
+	sourceClient    *httpclient.HttpClient
+	sourceDetails   httputils.HttpClientDetails
+	sourceBatchUrl  string
+	tempDir         string
+	mutex           sync.Mutex
+	successCount    int
+	failCount       int
+}
+
+func (lom *lfsObjectsMigrator) initSource() (err error) {
+	sourceUrl, err := url.Parse(lom.sourceUrl)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if sourceUrl.User != nil {
+		lom.sourceDetails.User = sourceUrl.User.Username()
+		lom.sourceDetails.Password, _ = sourceUrl.User.Password()
+		sourceUrl.User = nil
+	}
+	lom.sourceBatchUrl = strings.TrimSuffix(sourceUrl.String(), "/") + "/objects/batch"
+	if lom.sourceClient, err = httpclient.ClientBuilder().SetRetries(lom.retries).SetRetryWaitMilliSecs(lom.retryWaitTimeMilliSecs).Build(); err != nil {
+		return err
+	}
+	lom.tempDir, err = fileutils.CreateTempDir()
+	return err
+}
+
+func (lom *lfsObjectsMigrator) migrate(objects []*LfsObject) error {
+	producerConsumer := parallel.NewRunner(lom.threads, uint(len(objects)), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	for _, object := range objects {
+		_, _ = producerConsumer.AddTaskWithError(func(int) error {
+			err := lom.migrateObject(object)
+			lom.mutex.Lock()
+			defer lom.mutex.Unlock()
+			if err != nil {
+				lom.failCount++
+				return fmt.Errorf("failed to migrate the LFS object %s (%s): %w", object.Oid, object.Path, err)
+			}
+			lom.successCount++
+			return nil
+		}, errorsQueue.AddError)
+	}
+	producerConsumer.Done()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+func (lom *lfsObjectsMigrator) migrateObject(object *LfsObject) (err error) {
+	localPath := filepath.Join(lom.localLfsDir, filepath.FromSlash(getLfsObjectPath(object.Oid)))
+	if _, err = os.Stat(localPath); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) || lom.sourceClient == nil {
+			return errorutils.CheckErrorf("the object was not found in the local LFS storage %s", lom.localLfsDir)
+		}
+		if localPath, err = lom.downloadFromSource(object); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(localPath)))
+		}()
+	}
+	return lom.upload(localPath, object)
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		Oid     string `json:"oid"`
+		Actions struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// Download an object from the source LFS server, using the LFS batch API. The download is verified against the object's oid.
+func (lom *lfsObjectsMigrator) downloadFromSource(object *LfsObject) (string, error) {
+	request, err := json.Marshal(map[string]any{
+		"operation": "download",
+		"transfers": []string{"basic"},
+		"objects":   []map[string]any{{"oid": object.Oid, "size": object.Size}},
+	})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	httpClientDetails := lom.sourceDetails.Clone()
+	httpClientDetails.Headers = map[string]string{"Accept": lfsMediaType, "Content-Type": lfsMediaType}
+	resp, body, err := lom.sourceClient.SendPost(lom.sourceBatchUrl, request, *httpClientDetails, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errorutils.CheckErrorf("the source LFS server responded with %s: %s", resp.Status, string(body))
+	}
+	batchResponse := &lfsBatchResponse{}
+	if err = json.Unmarshal(body, batchResponse); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(batchResponse.Objects) != 1 {
+		return "", errorutils.CheckErrorf("unexpected response from the source LFS server: %s", string(body))
+	}
+	batchObject := batchResponse.Objects[0]
+	if batchObject.Error != nil {
+		return "", errorutils.CheckErrorf("the source LFS server responded with %d: %s", batchObject.Error.Code, batchObject.Error.Message)
+	}
+	if batchObject.Actions.Download == nil {
+		return "", errorutils.CheckErrorf("the source LFS server returned no download action")
+	}
+	downloadDetails := &httpclient.DownloadFileDetails{
+		FileName:       object.Oid,
+		DownloadPath:   batchObject.Actions.Download.Href,
+		RelativePath:   object.Oid,
+		LocalPath:      lom.tempDir,
+		LocalFileName:  object.Oid,
+		ExpectedSha256: object.Oid,
+	}
+	resp, err = lom.sourceClient.DownloadFile(downloadDetails, "", httputils.HttpClientDetails{Headers: batchObject.Actions.Download.Header}, false, false)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errorutils.CheckErrorf("failed to download from the source LFS server: %s", resp.Status)
+	}
+	return filepath.Join(lom.tempDir, object.Oid), nil
+}
+
+// Upload an object to the LFS repository in the objects/ab/cd/<oid> layout, after verifying its content matches its oid.
+func (lom *lfsObjectsMigrator) upload(localPath string, object *LfsObject) error {
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return err
+	}
+	if details.Checksum.Sha256 != object.Oid || details.Size != object.Size {
+		return errorutils.CheckErrorf("the content of %s does not match the LFS pointer", localPath)
+	}
+	httpClientDetails := lom.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	clientServicesUtils.AddChecksumHeaders(httpClientDetails.Headers, details)
+	targetUrl := clientutils.AddTrailingSlashIfNeeded(lom.servicesManager.GetConfig().GetServiceDetails().GetUrl()) + path.Join(lom.repo, getLfsObjectPath(object.Oid))
+	log.Info("Uploading", object.Path, "as", getLfsObjectPath(object.Oid))
+	resp, body, err := lom.servicesManager.Client().UploadFile(localPath, targetUrl, "", &httpClientDetails, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("Artifactory response: %s\n%s", resp.Status, clientutils.IndentJson(body))
+	}
+	var uploaded struct {
+		Checksums struct {
+			Sha256 string `json:"sha256"`
+		} `json:"checksums"`
+	}
+	if err = json.Unmarshal(body, &uploaded); err == nil && uploaded.Checksums.Sha256 != "" && uploaded.Checksums.Sha256 != object.Oid {
+		return errorutils.CheckErrorf("the uploaded object's sha256 is %s", uploaded.Checksums.Sha256)
+	}
+	return nil
+}
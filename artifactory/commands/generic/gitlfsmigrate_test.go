@@ -0,0 +1,157 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestLfsPointer(content string) (oid, pointer string) {
+	checksum := sha256.Sum256([]byte(content))
+	oid = hex.EncodeToString(checksum[:])
+	return oid, fmt.Sprintf("%s\noid sha256:%s\nsize %d\n", lfsPointerVersion, oid, len(content))
+}
+
+func TestParseLfsPointer(t *testing.T) {
+	oid, pointer := createTestLfsPointer("content")
+	tests := []struct {
+		name    string
+		pointer string
+		ok      bool
+	}{
+		{"valid", pointer, true},
+		{"extension lines", strings.Replace(pointer, "oid", "ext-0-foo sha256:abc\noid", 1), true},
+		{"no version", strings.TrimPrefix(pointer, lfsPointerVersion+"\n"), false},
+		{"no size", strings.Replace(pointer, "size 7\n", "", 1), false},
+		{"invalid oid", strings.Replace(pointer, oid, "1234", 1), false},
+		{"not sha256", strings.Replace(pointer, "sha256:", "sha1:", 1), false},
+		{"regular file", "hello world\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualOid, size, ok := parseLfsPointer([]byte(tt.pointer))
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, oid, actualOid)
+				assert.Equal(t, int64(7), size)
+			}
+		})
+	}
+}
+
+func TestGetLfsObjectPath(t *testing.T) {
+	oid, _ := createTestLfsPointer("content")
+	assert.Equal(t, "objects/ed/70/"+oid, getLfsObjectPath(oid))
+}
+
+func TestGetGitLfsRefsRegex(t *testing.T) {
+	regex := getGitLfsRefsRegex("refs/heads/main,refs/tags/*")
+	assert.Equal(t, `^(refs/heads/main|refs/tags/.*)$`, regex)
+}
+
+// Create a Git repository with a commit per content, each replacing the LFS pointer of the previous one.
+// The objects of all the contents are in the local LFS storage.
+func createTestLfsGitRepo(t *testing.T, contents ...string) (gitPath string, oids []string) {
+	gitPath = t.TempDir()
+	repo, err := git.PlainInit(gitPath, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(gitPath, "README.md"), []byte("not a pointer\n"), 0644))
+	for i, content := range contents {
+		oid, pointer := createTestLfsPointer(content)
+		oids = append(oids, oid)
+		objectPath := filepath.Join(gitPath, ".git", "lfs", filepath.FromSlash(getLfsObjectPath(oid)))
+		require.NoError(t, os.MkdirAll(filepath.Dir(objectPath), 0755))
+		require.NoError(t, os.WriteFile(objectPath, []byte(content), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(gitPath, "assets"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(gitPath, "assets", "image.bin"), []byte(pointer), 0644))
+		_, err = worktree.Add(".")
+		require.NoError(t, err)
+		_, err = worktree.Commit(fmt.Sprintf("commit %d", i), &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}})
+		require.NoError(t, err)
+	}
+	return
+}
+
+func TestCollectLfsObjects(t *testing.T) {
+	gitPath, oids := createTestLfsGitRepo(t, "first version", "second version")
+
+	objects, err := CollectLfsObjects(gitPath, getGitLfsRefsRegex(DefaultGitLfsMigrateRefs))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	for i, oid := range oids {
+		require.Contains(t, objects, oid)
+		assert.Equal(t, "assets/image.bin", objects[oid].Path)
+		assert.Equal(t, int64(len([]string{"first version", "second version"}[i])), objects[oid].Size)
+	}
+
+	objects, err = CollectLfsObjects(gitPath, getGitLfsRefsRegex("refs/tags/*"))
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestGitLfsMigrate(t *testing.T) {
+	gitPath, oids := createTestLfsGitRepo(t, "first version", "second version")
+	var mutex sync.Mutex
+	uploaded := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/search/aql":
+			// The first object is already in the repository.
+			_, _ = fmt.Fprintf(w, `{"results":[{"repo":"lfs","path":"objects","name":"%s","sha256":"%s"}]}`, oids[0], oids[0])
+		case r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			mutex.Lock()
+			uploaded[r.URL.Path] = string(body)
+			mutex.Unlock()
+			assert.Equal(t, oids[1], r.Header.Get("X-Checksum"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"checksums":{"sha256":"%s"}}`, oids[1])
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	migrateCommand := NewGitLfsMigrateCommand().SetGitPath(gitPath).SetRepo("lfs").SetThreads(2)
+	migrateCommand.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	require.NoError(t, migrateCommand.Run())
+	assert.Equal(t, map[string]string{"/lfs/" + getLfsObjectPath(oids[1]): "second version"}, uploaded)
+	assert.Equal(t, 1, migrateCommand.Result().SuccessCount())
+	assert.Equal(t, 0, migrateCommand.Result().FailCount())
+}
+
+func TestGitLfsMigrateMissingObject(t *testing.T) {
+	gitPath, oids := createTestLfsGitRepo(t, "content")
+	require.NoError(t, os.Remove(filepath.Join(gitPath, ".git", "lfs", filepath.FromSlash(getLfsObjectPath(oids[0])))))
+	migrator := &lfsObjectsMigrator{GitLfsMigrateCommand: NewGitLfsMigrateCommand().SetThreads(1), localLfsDir: getLocalLfsDir(gitPath)}
+	err := migrator.migrate([]*LfsObject{{Oid: oids[0], Size: 7, Path: "assets/image.bin"}})
+	assert.ErrorContains(t, err, "was not found in the local LFS storage")
+	assert.Equal(t, 1, migrator.failCount)
+}
+
+func TestGetMissingLfsObjects(t *testing.T) {
+	objects := map[string]*LfsObject{"b": {Oid: "b"}, "a": {Oid: "a"}, "c": {Oid: "c"}, "d": {Oid: "d"}}
+	// "c" has a wrong checksum in the repository.
+	missing := getMissingLfsObjects(objects, map[string]string{"b": "b", "c": "x", "d": ""})
+	require.Len(t, missing, 2)
+	assert.Equal(t, "a", missing[0].Oid)
+	assert.Equal(t, "c", missing[1].Oid)
+}
@@ -0,0 +1,218 @@
+package generic
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// A Git LFS pointer is a small text file. Any blob bigger than this is not a pointer.
+	maxLfsPointerSize = 1024
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+)
+
+var lfsOidRegex = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// LfsObject is a Git LFS object, referenced by a pointer file committed to Git.
+type LfsObject struct {
+	Oid  string
+	Size int64
+	// A path of a pointer to the object, for reporting.
+	Path string
+}
+
+// Get the path of an LFS object in an LFS repository or in the local LFS storage, in the form of objects/ab/cd/<oid>.
+func getLfsObjectPath(oid string) string {
+	return path.Join("objects", oid[0:2], oid[2:4], oid)
+}
+
+// Parse a Git LFS pointer file. Returns false if the content is not a valid pointer.
+func parseLfsPointer(data []byte) (oid string, size int64, ok bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() || scanner.Text() != lfsPointerVersion {
+		return "", 0, false
+	}
+	sizeFound := false
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			return "", 0, false
+		}
+		switch key {
+		case "oid":
+			oid = strings.TrimPrefix(value, "sha256:")
+			if !strings.HasPrefix(value, "sha256:") || !lfsOidRegex.MatchString(oid) {
+				return "", 0, false
+			}
+		case "size":
+			var err error
+			if size, err = strconv.ParseInt(value, 10, 64); err != nil || size < 0 {
+				return "", 0, false
+			}
+			sizeFound = true
+		}
+	}
+	return oid, size, oid != "" && sizeFound
+}
+
+// lfsPointersCollector finds the LFS pointers in the history of Git references.
+// Trees and blobs shared between commits are visited once.
+type lfsPointersCollector struct {
+	repo         *git.Repository
+	visitedTrees map[plumbing.Hash]bool
+	visitedBlobs map[plumbing.Hash]bool
+	objects      map[string]*LfsObject
+}
+
+// CollectLfsObjects returns the LFS objects referenced by all the commits reachable from the references matching the regex, by oid.
+func CollectLfsObjects(gitPath, refsRegex string) (map[string]*LfsObject, error) {
+	repo, err := git.PlainOpen(gitPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	refsMatcher, err := regexp.Compile(refsRegex)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	collector := &lfsPointersCollector{
+		repo:         repo,
+		visitedTrees: make(map[plumbing.Hash]bool),
+		visitedBlobs: make(map[plumbing.Hash]bool),
+		objects:      make(map[string]*LfsObject),
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	visitedCommits := make(map[plumbing.Hash]bool)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !refsMatcher.MatchString(ref.Name().String()) {
+			return nil
+		}
+		log.Debug("Collecting LFS pointers from", ref.Name().String())
+		commit, err := getRefCommit(repo, ref)
+		if err != nil {
+			// References to trees or blobs have no history.
+			log.Debug("Skipping", ref.Name().String()+":", err.Error())
+			return nil
+		}
+		return collector.collectHistory(commit, visitedCommits)
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return collector.objects, nil
+}
+
+func getRefCommit(repo *git.Repository, ref *plumbing.Reference) (*object.Commit, error) {
+	commit, err := repo.CommitObject(ref.Hash())
+	if err == nil {
+		return commit, nil
+	}
+	// An annotated tag points to a tag object.
+	tag, err := repo.TagObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return tag.Commit()
+}
+
+func (lpc *lfsPointersCollector) collectHistory(commit *object.Commit, visitedCommits map[plumbing.Hash]bool) error {
+	pending := []*object.Commit{commit}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if visitedCommits[current.Hash] {
+			continue
+		}
+		visitedCommits[current.Hash] = true
+		tree, err := current.Tree()
+		if err != nil {
+			return err
+		}
+		if err = lpc.collectTree(tree, ""); err != nil {
+			return err
+		}
+		err = current.Parents().ForEach(func(parent *object.Commit) error {
+			pending = append(pending, parent)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lpc *lfsPointersCollector) collectTree(tree *object.Tree, treePath string) error {
+	if lpc.visitedTrees[tree.Hash] {
+		return nil
+	}
+	lpc.visitedTrees[tree.Hash] = true
+	for _, entry := range tree.Entries {
+		entryPath := path.Join(treePath, entry.Name)
+		switch entry.Mode {
+		case filemode.Dir:
+			subtree, err := lpc.repo.TreeObject(entry.Hash)
+			if err != nil {
+				return err
+			}
+			if err = lpc.collectTree(subtree, entryPath); err != nil {
+				return err
+			}
+		case filemode.Regular, filemode.Executable:
+			if err := lpc.collectBlob(entry.Hash, entryPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (lpc *lfsPointersCollector) collectBlob(hash plumbing.Hash, blobPath string) (err error) {
+	if lpc.visitedBlobs[hash] {
+		return nil
+	}
+	lpc.visitedBlobs[hash] = true
+	blob, err := lpc.repo.BlobObject(hash)
+	if err != nil || blob.Size > maxLfsPointerSize {
+		return err
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if oid, size, ok := parseLfsPointer(data); ok {
+		if _, exists := lpc.objects[oid]; !exists {
+			lpc.objects[oid] = &LfsObject{Oid: oid, Size: size, Path: blobPath}
+		}
+	}
+	return nil
+}
+
+// Convert a comma-separated list of reference patterns, which may contain wildcards, to a regex.
+func getGitLfsRefsRegex(refs string) string {
+	replacer := strings.NewReplacer(",", "|", "\\*", ".*")
+	return "^(" + replacer.Replace(regexp.QuoteMeta(refs)) + ")$"
+}
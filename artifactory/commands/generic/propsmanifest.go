@@ -0,0 +1,266 @@
+package generic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	PropsManifestRowSuccess = "success"
+	PropsManifestRowFailure = "failure"
+
+	propsManifestPathColumn = "path"
+	// The report columns, which are added to the columns of a CSV manifest.
+	propsManifestStatusColumn = "#status"
+	propsManifestErrorColumn  = "#error"
+	// In a CSV manifest, a column named '+key' adds values to the key, and a column named '-key' removes the key.
+	// Any other column replaces the values of the key.
+	propsManifestAddPrefix    = "+"
+	propsManifestRemovePrefix = "-"
+	// The separator of multiple values in a CSV cell.
+	propsManifestValuesSeparator = ","
+)
+
+// PropsManifestRow lists the properties changes of a single item in Artifactory.
+type PropsManifestRow struct {
+	// The item's path in Artifactory, in the form of repo/path/name.
+	Path string `json:"path"`
+	// Properties whose values are replaced.
+	Set map[string][]string `json:"set,omitempty"`
+	// Values added to the existing values of the properties.
+	Add map[string][]string `json:"add,omitempty"`
+	// Properties which are removed.
+	Remove []string `json:"remove,omitempty"`
+	// The result of the last run, when the manifest is a report.
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// The original record of a CSV manifest.
+	record []string
+}
+
+func (row *PropsManifestRow) validate() error {
+	if row.Path == "" {
+		return errorutils.CheckErrorf("a row with no path was found")
+	}
+	if len(row.Set)+len(row.Add)+len(row.Remove) == 0 {
+		return errorutils.CheckErrorf("no properties changes were provided for '%s'", row.Path)
+	}
+	for _, key := range row.Remove {
+		_, inSet := row.Set[key]
+		_, inAdd := row.Add[key]
+		if inSet || inAdd {
+			return errorutils.CheckErrorf("the property '%s' is both set and removed for '%s'", key, row.Path)
+		}
+	}
+	for key := range row.Add {
+		if _, inSet := row.Set[key]; inSet {
+			return errorutils.CheckErrorf("the property '%s' is both set and added for '%s'", key, row.Path)
+		}
+	}
+	return nil
+}
+
+// PropsManifest is a list of per-item properties changes, read from a CSV or a JSON file.
+// A manifest may be a report of a previous run, in which case the successful rows are skipped.
+type PropsManifest struct {
+	Rows []*PropsManifestRow
+	// The header of a CSV manifest, without the report columns.
+	csvHeader []string
+}
+
+func isCsvManifest(manifestPath string) bool {
+	return strings.EqualFold(filepath.Ext(manifestPath), ".csv")
+}
+
+func ReadPropsManifest(manifestPath string) (*PropsManifest, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	var manifest *PropsManifest
+	if isCsvManifest(manifestPath) {
+		manifest, err = readCsvPropsManifest(csv.NewReader(file))
+	} else {
+		manifest = &PropsManifest{}
+		err = errorutils.CheckError(json.NewDecoder(file).Decode(&manifest.Rows))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the properties manifest %s: %w", manifestPath, err)
+	}
+	for _, row := range manifest.Rows {
+		if err = row.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+func readCsvPropsManifest(reader *csv.Reader) (*PropsManifest, error) {
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(records) == 0 {
+		return nil, errorutils.CheckErrorf("the manifest is empty")
+	}
+	header := records[0]
+	if len(header) == 0 || strings.TrimSpace(header[0]) != propsManifestPathColumn {
+		return nil, errorutils.CheckErrorf("the first column of the manifest should be '%s'", propsManifestPathColumn)
+	}
+	manifest := &PropsManifest{}
+	statusColumn, errorColumn := -1, -1
+	for i, column := range header {
+		switch strings.TrimSpace(column) {
+		case propsManifestStatusColumn:
+			statusColumn = i
+		case propsManifestErrorColumn:
+			errorColumn = i
+		default:
+			manifest.csvHeader = append(manifest.csvHeader, column)
+		}
+	}
+	for line, record := range records[1:] {
+		row := &PropsManifestRow{Path: strings.TrimSpace(record[0])}
+		if statusColumn >= 0 {
+			row.Status = record[statusColumn]
+		}
+		if errorColumn >= 0 {
+			row.Error = record[errorColumn]
+		}
+		for i, column := range header[1:] {
+			i++
+			if i == statusColumn || i == errorColumn {
+				continue
+			}
+			row.record = append(row.record, record[i])
+			if err = row.addCsvCell(strings.TrimSpace(column), strings.TrimSpace(record[i])); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line+2, err)
+			}
+		}
+		row.record = append([]string{record[0]}, row.record...)
+		manifest.Rows = append(manifest.Rows, row)
+	}
+	return manifest, nil
+}
+
+func (row *PropsManifestRow) addCsvCell(column, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(column, propsManifestRemovePrefix):
+		remove, err := strconv.ParseBool(cell)
+		if err != nil {
+			return errorutils.CheckErrorf("the value of the '%s' column should be either true or false", column)
+		}
+		if remove {
+			row.Remove = append(row.Remove, strings.TrimPrefix(column, propsManifestRemovePrefix))
+		}
+	case strings.HasPrefix(column, propsManifestAddPrefix):
+		if row.Add == nil {
+			row.Add = make(map[string][]string)
+		}
+		row.Add[strings.TrimPrefix(column, propsManifestAddPrefix)] = splitPropsManifestValues(cell)
+	default:
+		if row.Set == nil {
+			row.Set = make(map[string][]string)
+		}
+		row.Set[column] = splitPropsManifestValues(cell)
+	}
+	return nil
+}
+
+func splitPropsManifestValues(cell string) (values []string) {
+	for _, value := range strings.Split(cell, propsManifestValuesSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return
+}
+
+// WriteReport writes the manifest with the status of each row, in the format of the manifest path.
+// The report can be used as a manifest to re-run only the failed rows.
+func (pm *PropsManifest) WriteReport(reportPath string) (err error) {
+	file, err := os.Create(reportPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	if !isCsvManifest(reportPath) {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return errorutils.CheckError(encoder.Encode(pm.Rows))
+	}
+	writer := csv.NewWriter(file)
+	header := pm.csvHeader
+	if header == nil {
+		header = pm.createCsvHeader()
+	}
+	records := [][]string{append(append([]string{}, header...), propsManifestStatusColumn, propsManifestErrorColumn)}
+	for _, row := range pm.Rows {
+		record := row.record
+		if record == nil {
+			record = row.toCsvRecord(header)
+		}
+		records = append(records, append(append([]string{}, record...), row.Status, row.Error))
+	}
+	return errorutils.CheckError(writer.WriteAll(records))
+}
+
+// Create a CSV header for a manifest which was read from a JSON file.
+func (pm *PropsManifest) createCsvHeader() []string {
+	columns := make(map[string]bool)
+	for _, row := range pm.Rows {
+		for key := range row.Set {
+			columns[key] = true
+		}
+		for key := range row.Add {
+			columns[propsManifestAddPrefix+key] = true
+		}
+		for _, key := range row.Remove {
+			columns[propsManifestRemovePrefix+key] = true
+		}
+	}
+	header := make([]string, 0, len(columns))
+	for column := range columns {
+		header = append(header, column)
+	}
+	sort.Strings(header)
+	return append([]string{propsManifestPathColumn}, header...)
+}
+
+func (row *PropsManifestRow) toCsvRecord(header []string) []string {
+	record := []string{row.Path}
+	for _, column := range header[1:] {
+		var cell string
+		switch {
+		case strings.HasPrefix(column, propsManifestRemovePrefix):
+			for _, key := range row.Remove {
+				if key == strings.TrimPrefix(column, propsManifestRemovePrefix) {
+					cell = "true"
+				}
+			}
+		case strings.HasPrefix(column, propsManifestAddPrefix):
+			cell = strings.Join(row.Add[strings.TrimPrefix(column, propsManifestAddPrefix)], propsManifestValuesSeparator)
+		default:
+			cell = strings.Join(row.Set[column], propsManifestValuesSeparator)
+		}
+		record = append(record, cell)
+	}
+	return record
+}
@@ -0,0 +1,163 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPropsCsvManifest = `path,license,+tags,-owner
+repo/a/app.jar,MIT,"x,y",
+repo/b/lib.jar,,z,true
+`
+
+func writeTestManifest(t *testing.T, name, manifestContent string) string {
+	manifestPath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifestContent), 0644))
+	return manifestPath
+}
+
+func TestReadPropsManifestCsv(t *testing.T) {
+	manifest, err := ReadPropsManifest(writeTestManifest(t, "manifest.csv", testPropsCsvManifest))
+	require.NoError(t, err)
+	require.Len(t, manifest.Rows, 2)
+	assert.Equal(t, "repo/a/app.jar", manifest.Rows[0].Path)
+	assert.Equal(t, map[string][]string{"license": {"MIT"}}, manifest.Rows[0].Set)
+	assert.Equal(t, map[string][]string{"tags": {"x", "y"}}, manifest.Rows[0].Add)
+	assert.Empty(t, manifest.Rows[0].Remove)
+	assert.Empty(t, manifest.Rows[1].Set)
+	assert.Equal(t, []string{"owner"}, manifest.Rows[1].Remove)
+}
+
+func TestReadPropsManifestJson(t *testing.T) {
+	manifest, err := ReadPropsManifest(writeTestManifest(t, "manifest.json", `[{"path": "repo/a/app.jar", "set": {"license": ["MIT"]}, "remove": ["owner"]}]`))
+	require.NoError(t, err)
+	require.Len(t, manifest.Rows, 1)
+	assert.Equal(t, map[string][]string{"license": {"MIT"}}, manifest.Rows[0].Set)
+	assert.Equal(t, []string{"owner"}, manifest.Rows[0].Remove)
+}
+
+func TestReadPropsManifestInvalid(t *testing.T) {
+	tests := []struct {
+		name            string
+		manifestName    string
+		manifestContent string
+		expectedError   string
+	}{
+		{"no path column", "manifest.csv", "license\nMIT\n", "first column"},
+		{"invalid remove", "manifest.csv", "path,-owner\nrepo/a,maybe\n", "line 2"},
+		{"no changes", "manifest.csv", "path,license\nrepo/a,\n", "no properties changes"},
+		{"set and removed", "manifest.json", `[{"path": "repo/a", "set": {"a": ["b"]}, "remove": ["a"]}]`, "both set and removed"},
+		{"no path", "manifest.json", `[{"set": {"a": ["b"]}}]`, "no path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadPropsManifest(writeTestManifest(t, tt.manifestName, tt.manifestContent))
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestPropsManifestReport(t *testing.T) {
+	for _, manifestName := range []string{"manifest.csv", "manifest.json"} {
+		t.Run(manifestName, func(t *testing.T) {
+			manifest, err := ReadPropsManifest(writeTestManifest(t, "manifest.csv", testPropsCsvManifest))
+			require.NoError(t, err)
+			manifest.Rows[0].Status = PropsManifestRowSuccess
+			manifest.Rows[1].Status, manifest.Rows[1].Error = PropsManifestRowFailure, "not found"
+
+			reportPath := filepath.Join(t.TempDir(), manifestName)
+			require.NoError(t, manifest.WriteReport(reportPath))
+			report, err := ReadPropsManifest(reportPath)
+			require.NoError(t, err)
+			require.Len(t, report.Rows, 2)
+			for i, row := range report.Rows {
+				assert.Equal(t, manifest.Rows[i].Path, row.Path)
+				assert.Equal(t, manifest.Rows[i].Set, row.Set)
+				assert.Equal(t, manifest.Rows[i].Add, row.Add)
+				assert.Equal(t, manifest.Rows[i].Remove, row.Remove)
+				assert.Equal(t, manifest.Rows[i].Status, row.Status)
+				assert.Equal(t, manifest.Rows[i].Error, row.Error)
+			}
+		})
+	}
+}
+
+func TestRunPropsManifest(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		mutex.Unlock()
+		switch {
+		case r.URL.Path == "/api/storage/repo/missing.jar":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"properties": {"tags": ["old"]}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	manifestPath := writeTestManifest(t, "manifest.csv", testPropsCsvManifest+"repo/missing.jar,MIT,,\n")
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	propsCommand := NewPropsCommand().SetThreads(2)
+	propsCommand.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	setProps := NewSetPropsCommand().SetPropsCommand(*propsCommand).SetManifestPath(manifestPath).SetReportPath(reportPath)
+	err := setProps.Run()
+	assert.ErrorContains(t, err, "failed to apply the properties of 1 rows")
+	assert.Equal(t, 2, setProps.Result().SuccessCount())
+	assert.Equal(t, 1, setProps.Result().FailCount())
+	assert.ElementsMatch(t, []string{
+		"PUT /api/storage/repo/a/app.jar?properties=license=MIT;tags=old%2Cx%2Cy&recursive=0",
+		"GET /api/storage/repo/a/app.jar?properties",
+		"GET /api/storage/repo/b/lib.jar?properties",
+		"PUT /api/storage/repo/b/lib.jar?properties=tags=old%2Cz&recursive=0",
+		"DELETE /api/storage/repo/b/lib.jar?properties=owner&recursive=0",
+		"PUT /api/storage/repo/missing.jar?properties=license=MIT&recursive=0",
+	}, requests)
+
+	// Re-running the report retries only the failed row.
+	requests = nil
+	setProps.SetManifestPath(reportPath).SetReportPath("")
+	err = setProps.Run()
+	assert.ErrorContains(t, err, "failed to apply the properties of 1 rows")
+	assert.Equal(t, 0, setProps.Result().SuccessCount())
+	assert.Equal(t, []string{"PUT /api/storage/repo/missing.jar?properties=license=MIT&recursive=0"}, requests)
+}
+
+func TestToPropsString(t *testing.T) {
+	props := clientServicesUtils.NewProperties()
+	props.AddProperty("tags", "a,b")
+	props.AddProperty("tags", "c")
+	props.AddProperty("notes", "x;y")
+	propsString := toPropsString(props)
+	assert.Equal(t, `notes=x\;y;tags=a\,b,c`, propsString)
+
+	// The props services parse the string back to the same properties.
+	parsed, err := clientServicesUtils.ParseProperties(propsString)
+	require.NoError(t, err)
+	assert.Equal(t, props.ToMap(), parsed.ToMap())
+}
+
+func TestSetPropsDryRun(t *testing.T) {
+	reader, err := createItemReader("repo/a/app.jar")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	setProps := NewSetPropsCommand().SetPropsCommand(*NewPropsCommand().SetProps("license=MIT"))
+	require.NoError(t, setProps.logDryRun(reader))
+	assert.Equal(t, 1, setProps.Result().SuccessCount())
+	assert.Equal(t, 0, setProps.Result().FailCount())
+}
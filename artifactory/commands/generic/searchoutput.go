@@ -0,0 +1,396 @@
+package generic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+type SearchOutputFormat string
+
+const (
+	SearchOutputJson   SearchOutputFormat = "json"
+	SearchOutputCsv    SearchOutputFormat = "csv"
+	SearchOutputTable  SearchOutputFormat = "table"
+	SearchOutputNdjson SearchOutputFormat = "ndjson"
+
+	// A field or a group of a single property, in the form of props.<key>.
+	searchPropsFieldPrefix = "props."
+	// A group of the first segments of the path, in the form of path:<depth>.
+	searchPathGroupPrefix = "path:"
+	searchRepoGroup       = "repo"
+	// The group of the results which have no value for the grouped property.
+	searchNoValueGroup = "(none)"
+)
+
+// The fields which are displayed in the csv and table formats, if no fields are requested.
+var defaultSearchFields = []string{"path", "type", "size", "modified", "sha256"}
+
+func GetSearchOutputFormat(format string) (SearchOutputFormat, error) {
+	switch SearchOutputFormat(format) {
+	case "":
+		return SearchOutputJson, nil
+	case SearchOutputJson, SearchOutputCsv, SearchOutputTable, SearchOutputNdjson:
+		return SearchOutputFormat(format), nil
+	}
+	return "", errorutils.CheckErrorf("invalid output format '%s'. The valid values are: json, csv, table and ndjson", format)
+}
+
+// ParseSearchFields parses a comma-separated list of search result fields.
+// A field is either a JSON field of the search result, such as 'path' or 'sha256', 'props' for all the properties, or 'props.<key>' for a single property.
+func ParseSearchFields(fields string) ([]string, error) {
+	if fields == "" {
+		return nil, nil
+	}
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, err := getSearchResultField(&utils.SearchResult{}, field); err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, field)
+	}
+	return parsed, nil
+}
+
+func getSearchResultField(result *utils.SearchResult, field string) (string, error) {
+	if strings.HasPrefix(field, searchPropsFieldPrefix) {
+		return strings.Join(result.Props[strings.TrimPrefix(field, searchPropsFieldPrefix)], ","), nil
+	}
+	switch field {
+	case "path":
+		return result.Path, nil
+	case "type":
+		return result.Type, nil
+	case "size":
+		return strconv.FormatInt(result.Size, 10), nil
+	case "created":
+		return result.Created, nil
+	case "modified":
+		return result.Modified, nil
+	case "updated":
+		return result.Updated, nil
+	case "created_by":
+		return result.CreatedBy, nil
+	case "modified_by":
+		return result.ModifiedBy, nil
+	case "sha1":
+		return result.Sha1, nil
+	case "sha256":
+		return result.Sha256, nil
+	case "md5":
+		return result.Md5, nil
+	case "original_sha1":
+		return result.OriginalSha1, nil
+	case "original_md5":
+		return result.OriginalMd5, nil
+	case "depth":
+		return strconv.Itoa(result.Depth), nil
+	case "props":
+		return formatSearchResultProps(result.Props), nil
+	}
+	return "", errorutils.CheckErrorf("unknown search result field '%s'", field)
+}
+
+// Format the properties in the form of key1=value1,value2;key2=value3, sorted by key.
+func formatSearchResultProps(props map[string][]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	formatted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		formatted = append(formatted, key+"="+strings.Join(props[key], ","))
+	}
+	return strings.Join(formatted, ";")
+}
+
+// Project the search result to the requested fields. Single properties are nested under 'props', as in the full result.
+func projectSearchResult(result *utils.SearchResult, fields []string) map[string]any {
+	projected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		switch {
+		case strings.HasPrefix(field, searchPropsFieldPrefix):
+			key := strings.TrimPrefix(field, searchPropsFieldPrefix)
+			values, ok := result.Props[key]
+			if !ok {
+				continue
+			}
+			props, _ := projected["props"].(map[string][]string)
+			if props == nil {
+				props = make(map[string][]string)
+				projected["props"] = props
+			}
+			props[key] = values
+		case field == "props":
+			projected[field] = result.Props
+		case field == "size":
+			projected[field] = result.Size
+		case field == "depth":
+			projected[field] = result.Depth
+		default:
+			projected[field], _ = getSearchResultField(result, field)
+		}
+	}
+	return projected
+}
+
+// WriteSearchResults writes the search results to the writer in the requested format, projected to the requested fields.
+// The results are streamed from the reader, except for the table format which is rendered once all the rows are read.
+func WriteSearchResults(writer io.Writer, reader *content.ContentReader, format SearchOutputFormat, fields []string) error {
+	if len(fields) == 0 && (format == SearchOutputCsv || format == SearchOutputTable) {
+		fields = defaultSearchFields
+	}
+	rowWriter, err := newSearchRowWriter(writer, format, fields)
+	if err != nil {
+		return err
+	}
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		var row any = result
+		if len(fields) > 0 {
+			row = projectSearchResult(result, fields)
+		}
+		if err = rowWriter.writeRow(row, func(field string) string {
+			value, _ := getSearchResultField(result, field)
+			return value
+		}); err != nil {
+			return err
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return rowWriter.close()
+}
+
+// searchRowWriter writes rows in one of the search output formats.
+// A row is written as a JSON object in the json and ndjson formats, and as the values of the columns in the csv and table formats.
+type searchRowWriter struct {
+	writer    io.Writer
+	format    SearchOutputFormat
+	columns   []string
+	csvWriter *csv.Writer
+	table     table.Writer
+	rowsCount int
+}
+
+func newSearchRowWriter(writer io.Writer, format SearchOutputFormat, columns []string) (*searchRowWriter, error) {
+	rw := &searchRowWriter{writer: writer, format: format, columns: columns}
+	switch format {
+	case SearchOutputCsv:
+		rw.csvWriter = csv.NewWriter(writer)
+		return rw, errorutils.CheckError(rw.csvWriter.Write(columns))
+	case SearchOutputTable:
+		rw.table = table.NewWriter()
+		rw.table.SetOutputMirror(writer)
+		header := make(table.Row, 0, len(columns))
+		for _, column := range columns {
+			header = append(header, column)
+		}
+		rw.table.AppendHeader(header)
+	}
+	return rw, nil
+}
+
+func (rw *searchRowWriter) writeRow(row any, getColumn func(column string) string) (err error) {
+	defer func() {
+		rw.rowsCount++
+	}()
+	switch rw.format {
+	case SearchOutputCsv:
+		return errorutils.CheckError(rw.csvWriter.Write(rw.getColumns(getColumn)))
+	case SearchOutputTable:
+		tableRow := make(table.Row, 0, len(rw.columns))
+		for _, value := range rw.getColumns(getColumn) {
+			tableRow = append(tableRow, value)
+		}
+		rw.table.AppendRow(tableRow)
+		return nil
+	}
+	data, err := json.Marshal(row)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if rw.format == SearchOutputNdjson {
+		_, err = fmt.Fprintln(rw.writer, string(data))
+		return errorutils.CheckError(err)
+	}
+	prefix := "[\n  "
+	if rw.rowsCount > 0 {
+		prefix = ",\n  "
+	}
+	_, err = fmt.Fprint(rw.writer, prefix+string(data))
+	return errorutils.CheckError(err)
+}
+
+func (rw *searchRowWriter) getColumns(getColumn func(column string) string) []string {
+	values := make([]string, 0, len(rw.columns))
+	for _, column := range rw.columns {
+		values = append(values, getColumn(column))
+	}
+	return values
+}
+
+func (rw *searchRowWriter) close() (err error) {
+	switch rw.format {
+	case SearchOutputCsv:
+		rw.csvWriter.Flush()
+		return errorutils.CheckError(rw.csvWriter.Error())
+	case SearchOutputTable:
+		rw.table.Render()
+	case SearchOutputJson:
+		suffix := "\n]\n"
+		if rw.rowsCount == 0 {
+			suffix = "[]\n"
+		}
+		_, err = fmt.Fprint(rw.writer, suffix)
+	}
+	return errorutils.CheckError(err)
+}
+
+// SearchAggregation summarizes the search results instead of printing them.
+type SearchAggregation struct {
+	// Either 'repo', 'path:<depth>' or 'props.<key>'. If empty, the totals of all the results are returned.
+	GroupBy string
+	Count   bool
+	SumSize bool
+}
+
+func (sa *SearchAggregation) Validate() error {
+	if !sa.Count && !sa.SumSize {
+		return errorutils.CheckErrorf("the group-by option requires the count or the sum-size option")
+	}
+	if _, err := sa.getGroups(&utils.SearchResult{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get the groups of a search result. A result with several values of the grouped property belongs to the group of each value.
+func (sa *SearchAggregation) getGroups(result *utils.SearchResult) ([]string, error) {
+	switch {
+	case sa.GroupBy == "":
+		return []string{""}, nil
+	case sa.GroupBy == searchRepoGroup:
+		return []string{strings.SplitN(result.Path, "/", 2)[0]}, nil
+	case strings.HasPrefix(sa.GroupBy, searchPathGroupPrefix):
+		depth, err := strconv.Atoi(strings.TrimPrefix(sa.GroupBy, searchPathGroupPrefix))
+		if err != nil || depth < 1 {
+			return nil, errorutils.CheckErrorf("invalid path depth in '%s'. The depth should be a positive number, such as path:2", sa.GroupBy)
+		}
+		segments := strings.Split(result.Path, "/")
+		if result.Type != "folder" {
+			// Group files by their folders.
+			segments = segments[:len(segments)-1]
+		}
+		if len(segments) > depth {
+			segments = segments[:depth]
+		}
+		return []string{path.Join(segments...)}, nil
+	case strings.HasPrefix(sa.GroupBy, searchPropsFieldPrefix):
+		values := result.Props[strings.TrimPrefix(sa.GroupBy, searchPropsFieldPrefix)]
+		if len(values) == 0 {
+			return []string{searchNoValueGroup}, nil
+		}
+		return values, nil
+	}
+	return nil, errorutils.CheckErrorf("invalid group-by value '%s'. The valid values are: repo, path:<depth> and props.<key>", sa.GroupBy)
+}
+
+type searchAggregate struct {
+	Group string `json:"group,omitempty"`
+	Count *int   `json:"count,omitempty"`
+	Size  *int64 `json:"size,omitempty"`
+}
+
+// WriteAggregation writes the totals of the search results in the requested format, sorted by group.
+func (sa *SearchAggregation) WriteAggregation(writer io.Writer, reader *content.ContentReader, format SearchOutputFormat) error {
+	aggregates := make(map[string]*searchAggregate)
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		groups, err := sa.getGroups(result)
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			aggregate, ok := aggregates[group]
+			if !ok {
+				aggregate = sa.newAggregate(group)
+				aggregates[group] = aggregate
+			}
+			if aggregate.Count != nil {
+				*aggregate.Count++
+			}
+			if aggregate.Size != nil {
+				*aggregate.Size += result.Size
+			}
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	if len(aggregates) == 0 {
+		aggregates[""] = sa.newAggregate("")
+	}
+	groups := make([]string, 0, len(aggregates))
+	for group := range aggregates {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var columns []string
+	if sa.GroupBy != "" {
+		columns = append(columns, "group")
+	}
+	if sa.Count {
+		columns = append(columns, "count")
+	}
+	if sa.SumSize {
+		columns = append(columns, "size")
+	}
+	rowWriter, err := newSearchRowWriter(writer, format, columns)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		aggregate := aggregates[group]
+		if err = rowWriter.writeRow(aggregate, func(column string) string {
+			switch column {
+			case "count":
+				return strconv.Itoa(*aggregate.Count)
+			case "size":
+				return strconv.FormatInt(*aggregate.Size, 10)
+			}
+			return aggregate.Group
+		}); err != nil {
+			return err
+		}
+	}
+	return rowWriter.close()
+}
+
+func (sa *SearchAggregation) newAggregate(group string) *searchAggregate {
+	aggregate := &searchAggregate{Group: group}
+	if sa.Count {
+		aggregate.Count = new(int)
+	}
+	if sa.SumSize {
+		aggregate.Size = new(int64)
+	}
+	return aggregate
+}
@@ -0,0 +1,103 @@
+package generic
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSearchReader(t *testing.T) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	writer.Write(utils.SearchResult{Path: "libs/a/b/app.jar", Type: "file", Size: 10, Sha256: "s1", Props: map[string][]string{"team": {"core"}}})
+	writer.Write(utils.SearchResult{Path: "libs/a/c/lib.jar", Type: "file", Size: 20, Props: map[string][]string{"team": {"core", "web"}}})
+	writer.Write(utils.SearchResult{Path: "docs/readme.md", Type: "file", Size: 5})
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	t.Cleanup(func() {
+		assert.NoError(t, reader.Close())
+	})
+	return reader
+}
+
+func TestParseSearchFields(t *testing.T) {
+	fields, err := ParseSearchFields("path, size,props.team")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"path", "size", "props.team"}, fields)
+
+	_, err = ParseSearchFields("path,unknown")
+	assert.ErrorContains(t, err, "unknown search result field 'unknown'")
+}
+
+func TestWriteSearchResults(t *testing.T) {
+	tests := []struct {
+		format   SearchOutputFormat
+		fields   []string
+		expected string
+	}{
+		{SearchOutputCsv, []string{"path", "size", "props.team"}, "path,size,props.team\n" +
+			"libs/a/b/app.jar,10,core\n" +
+			"libs/a/c/lib.jar,20,\"core,web\"\n" +
+			"docs/readme.md,5,\n"},
+		{SearchOutputNdjson, []string{"path", "props.team"}, `{"path":"libs/a/b/app.jar","props":{"team":["core"]}}` + "\n" +
+			`{"path":"libs/a/c/lib.jar","props":{"team":["core","web"]}}` + "\n" +
+			`{"path":"docs/readme.md"}` + "\n"},
+		{SearchOutputJson, []string{"path", "size"}, "[\n" +
+			`  {"path":"libs/a/b/app.jar","size":10},` + "\n" +
+			`  {"path":"libs/a/c/lib.jar","size":20},` + "\n" +
+			`  {"path":"docs/readme.md","size":5}` + "\n]\n"},
+		{SearchOutputNdjson, nil, `{"path":"libs/a/b/app.jar","type":"file","size":10,"sha256":"s1","props":{"team":["core"]}}` + "\n" +
+			`{"path":"libs/a/c/lib.jar","type":"file","size":20,"props":{"team":["core","web"]}}` + "\n" +
+			`{"path":"docs/readme.md","type":"file","size":5}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buffer bytes.Buffer
+			require.NoError(t, WriteSearchResults(&buffer, createTestSearchReader(t), tt.format, tt.fields))
+			assert.Equal(t, tt.expected, buffer.String())
+		})
+	}
+}
+
+func TestWriteSearchResultsTable(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteSearchResults(&buffer, createTestSearchReader(t), SearchOutputTable, nil))
+	output := buffer.String()
+	assert.Contains(t, output, "PATH")
+	assert.Contains(t, output, "SHA256")
+	assert.Contains(t, output, "libs/a/c/lib.jar")
+}
+
+func TestWriteAggregation(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation SearchAggregation
+		format      SearchOutputFormat
+		expected    string
+	}{
+		{"total", SearchAggregation{Count: true, SumSize: true}, SearchOutputCsv, "count,size\n3,35\n"},
+		{"repo", SearchAggregation{GroupBy: "repo", SumSize: true}, SearchOutputCsv, "group,size\ndocs,5\nlibs,30\n"},
+		{"path", SearchAggregation{GroupBy: "path:2", Count: true}, SearchOutputCsv, "group,count\ndocs,1\nlibs/a,2\n"},
+		{"property", SearchAggregation{GroupBy: "props.team", Count: true, SumSize: true}, SearchOutputNdjson, `{"group":"(none)","count":1,"size":5}` + "\n" +
+			`{"group":"core","count":2,"size":30}` + "\n" +
+			`{"group":"web","count":1,"size":20}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.aggregation.Validate())
+			var buffer bytes.Buffer
+			require.NoError(t, tt.aggregation.WriteAggregation(&buffer, createTestSearchReader(t), tt.format))
+			assert.Equal(t, tt.expected, buffer.String())
+		})
+	}
+}
+
+func TestSearchAggregationValidate(t *testing.T) {
+	assert.ErrorContains(t, (&SearchAggregation{GroupBy: "repo"}).Validate(), "requires the count or the sum-size option")
+	assert.ErrorContains(t, (&SearchAggregation{GroupBy: "path:0", Count: true}).Validate(), "invalid path depth")
+	assert.ErrorContains(t, (&SearchAggregation{GroupBy: "owner", Count: true}).Validate(), "invalid group-by value")
+}
@@ -2,12 +2,18 @@ package generic
 
 import (
 	"errors"
+	"fmt"
 
+	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type SetPropsCommand struct {
 	PropsCommand
+	manifestPath string
+	reportPath   string
 }
 
 func NewSetPropsCommand() *SetPropsCommand {
@@ -24,11 +30,26 @@ func (setProps *SetPropsCommand) SetRepoOnly(repoOnly bool) *SetPropsCommand {
 	return setProps
 }
 
+// Set a CSV or JSON manifest of per-item properties changes, which is used instead of the spec and the properties.
+func (setProps *SetPropsCommand) SetManifestPath(manifestPath string) *SetPropsCommand {
+	setProps.manifestPath = manifestPath
+	return setProps
+}
+
+// Set the path of the per-row report of a manifest run.
+func (setProps *SetPropsCommand) SetReportPath(reportPath string) *SetPropsCommand {
+	setProps.reportPath = reportPath
+	return setProps
+}
+
 func (setProps *SetPropsCommand) CommandName() string {
 	return "rt_set_properties"
 }
 
 func (setProps *SetPropsCommand) Run() (err error) {
+	if setProps.manifestPath != "" {
+		return setProps.runManifest()
+	}
 	serverDetails, err := setProps.ServerDetails()
 	if errorutils.CheckError(err) != nil {
 		return err
@@ -45,6 +66,9 @@ func (setProps *SetPropsCommand) Run() (err error) {
 	defer func() {
 		err = errors.Join(err, reader.Close())
 	}()
+	if setProps.DryRun() {
+		return setProps.logDryRun(reader)
+	}
 	propsParams := GetPropsParams(reader, setProps.props, setProps.repoOnly)
 	success, err := servicesManager.SetProps(propsParams)
 
@@ -57,3 +81,21 @@ func (setProps *SetPropsCommand) Run() (err error) {
 	}
 	return err
 }
+
+// Log the items which would have been updated, without setting their properties.
+func (setProps *SetPropsCommand) logDryRun(reader *content.ContentReader) error {
+	for item := new(clientServicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(clientServicesUtils.ResultItem) {
+		itemPath := item.GetItemRelativePath()
+		if setProps.repoOnly {
+			itemPath = item.Repo
+		}
+		log.Info(fmt.Sprintf("[Dry run] Setting properties on %s: %s", itemPath, setProps.props))
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	length, err := reader.Length()
+	setProps.Result().SetSuccessCount(length)
+	return err
+}
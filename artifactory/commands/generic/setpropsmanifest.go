@@ -0,0 +1,195 @@
+package generic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Apply the properties changes of each row of the manifest in parallel, and write the report if requested.
+func (setProps *SetPropsCommand) runManifest() (err error) {
+	manifest, err := ReadPropsManifest(setProps.manifestPath)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := setProps.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	servicesManager, err := createPropsServiceManager(setProps.threads, setProps.retries, setProps.retryWaitTimeMilliSecs, serverDetails)
+	if err != nil {
+		return err
+	}
+	applier := &manifestPropsApplier{servicesManager: servicesManager, dryRun: setProps.DryRun()}
+	applier.apply(manifest, setProps.threads)
+
+	result := setProps.Result()
+	result.SetSuccessCount(applier.successCount)
+	result.SetFailCount(applier.failCount)
+	if setProps.reportPath != "" {
+		if err = manifest.WriteReport(setProps.reportPath); err != nil {
+			return err
+		}
+		log.Info("The properties report was written to", setProps.reportPath)
+	}
+	if applier.failCount == 0 {
+		return nil
+	}
+	if setProps.reportPath != "" {
+		return errorutils.CheckErrorf("failed to apply the properties of %d rows. To retry only the failed rows, run the command again with --manifest=%s", applier.failCount, setProps.reportPath)
+	}
+	return errorutils.CheckErrorf("failed to apply the properties of %d rows. Use the --report option to write the failed rows to a manifest which can be re-run", applier.failCount)
+}
+
+type manifestPropsApplier struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	dryRun          bool
+	mutex           sync.Mutex
+	successCount    int
+	failCount       int
+}
+
+func (mpa *manifestPropsApplier) apply(manifest *PropsManifest, threads int) {
+	producerConsumer := parallel.NewRunner(threads, uint(len(manifest.Rows)), false)
+	for _, row := range manifest.Rows {
+		// The successful rows of a previous run are skipped.
+		if row.Status == PropsManifestRowSuccess {
+			log.Debug("Skipping", row.Path, "which was updated in a previous run.")
+			continue
+		}
+		_, _ = producerConsumer.AddTask(func(int) error {
+			err := mpa.applyRow(row)
+			mpa.mutex.Lock()
+			defer mpa.mutex.Unlock()
+			if err != nil {
+				log.Error(fmt.Sprintf("Failed to apply the properties of %s: %s", row.Path, err.Error()))
+				row.Status, row.Error = PropsManifestRowFailure, err.Error()
+				mpa.failCount++
+				return nil
+			}
+			row.Status, row.Error = PropsManifestRowSuccess, ""
+			mpa.successCount++
+			return nil
+		})
+	}
+	producerConsumer.Done()
+	producerConsumer.Run()
+}
+
+func (mpa *manifestPropsApplier) applyRow(row *PropsManifestRow) (err error) {
+	itemPath := strings.Trim(row.Path, "/")
+	props := clientServicesUtils.NewProperties()
+	for key, values := range row.Set {
+		for _, value := range values {
+			props.AddProperty(key, value)
+		}
+	}
+	if len(row.Add) > 0 {
+		// The added values are set together with the existing values of the properties.
+		existing, err := mpa.getExistingProps(itemPath)
+		if err != nil {
+			return err
+		}
+		for key, values := range row.Add {
+			for _, value := range append(existing[key], values...) {
+				props.AddProperty(key, value)
+			}
+		}
+	}
+	if mpa.dryRun {
+		log.Info(fmt.Sprintf("[Dry run] Setting properties on %s: %s", itemPath, describePropsManifestRow(row)))
+		return nil
+	}
+	reader, err := createItemReader(itemPath)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(reader, &err)
+	if props.KeysLen() > 0 {
+		success, err := mpa.servicesManager.SetProps(services.PropsParams{Reader: reader, Props: toPropsString(props)})
+		if err != nil {
+			return err
+		}
+		if success != 1 {
+			return errorutils.CheckErrorf("failed to set the properties of %s", itemPath)
+		}
+	}
+	if len(row.Remove) > 0 {
+		success, err := mpa.servicesManager.DeleteProps(services.PropsParams{Reader: reader, Props: strings.Join(row.Remove, ",")})
+		if err != nil {
+			return err
+		}
+		if success != 1 {
+			return errorutils.CheckErrorf("failed to delete the properties of %s", itemPath)
+		}
+	}
+	return nil
+}
+
+// Create a reader of a single item, to be passed to the props services.
+func createItemReader(itemPath string) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	repo, relativePath, _ := strings.Cut(itemPath, "/")
+	writer.Write(clientServicesUtils.ResultItem{Repo: repo, Path: ".", Name: relativePath})
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+// The props services parse the properties from a "key=value1,value2;key2=value3" string, so the separators in the keys and values are escaped.
+func toPropsString(props *clientServicesUtils.Properties) string {
+	keyEscaper := strings.NewReplacer(";", `\;`)
+	valueEscaper := strings.NewReplacer(";", `\;`, ",", `\,`)
+	propsMap := props.ToMap()
+	keys := make([]string, 0, len(propsMap))
+	for key := range propsMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var propsStrings []string
+	for _, key := range keys {
+		values := make([]string, 0, len(propsMap[key]))
+		for _, value := range propsMap[key] {
+			values = append(values, valueEscaper.Replace(value))
+		}
+		propsStrings = append(propsStrings, keyEscaper.Replace(key)+"="+strings.Join(values, ","))
+	}
+	return strings.Join(propsStrings, ";")
+}
+
+func (mpa *manifestPropsApplier) getExistingProps(itemPath string) (map[string][]string, error) {
+	itemProps, err := mpa.servicesManager.GetItemProps(itemPath)
+	if err != nil || itemProps == nil {
+		return nil, err
+	}
+	return itemProps.Properties, nil
+}
+
+func describePropsManifestRow(row *PropsManifestRow) string {
+	var changes []string
+	for key, values := range row.Set {
+		changes = append(changes, fmt.Sprintf("%s=%s", key, strings.Join(values, ",")))
+	}
+	for key, values := range row.Add {
+		changes = append(changes, fmt.Sprintf("%s+=%s", key, strings.Join(values, ",")))
+	}
+	for _, key := range row.Remove {
+		changes = append(changes, "-"+key)
+	}
+	sort.Strings(changes)
+	return strings.Join(changes, "; ")
+}
@@ -0,0 +1,474 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/parallel"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	syncStateDirName = "sync"
+	// Downloaded files are written to a temp file next to the target, and renamed when complete.
+	syncTempFileSuffix = ".jfrog-sync-tmp"
+)
+
+// SyncCommand syncs a local directory with a path in a repository, using the SHA-256 checksums of the files.
+// The files of the last sync are cached locally, to detect the side on which a file changed and to avoid
+// rehashing local files which were not modified since.
+type SyncCommand struct {
+	GenericCommand
+	localDir       string
+	repo           string
+	repoPath       string
+	mode           SyncMode
+	conflictPolicy SyncConflictPolicy
+	threads        int
+	stateDir       string
+	// In push and pull modes, delete files which were added on the other side.
+	deleteAdded bool
+}
+
+// syncedFile is a file which was identical on both sides at the end of the last sync.
+type syncedFile struct {
+	Sha256 string `json:"sha256"`
+	Sha1   string `json:"sha1,omitempty"`
+	Size   int64  `json:"size"`
+	// The modification time of the local file, in nanoseconds.
+	ModTime int64 `json:"modTime"`
+}
+
+type syncState struct {
+	LocalDir string                `json:"localDir"`
+	Target   string                `json:"target"`
+	Files    map[string]syncedFile `json:"files"`
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{GenericCommand: *NewGenericCommand(), mode: SyncModeBoth, conflictPolicy: SyncConflictSkip}
+}
+
+func (sc *SyncCommand) SetLocalDir(localDir string) *SyncCommand {
+	sc.localDir = localDir
+	return sc
+}
+
+// SetTarget sets the synced repository path, in the form of repo/path.
+func (sc *SyncCommand) SetTarget(target string) *SyncCommand {
+	target = strings.Trim(target, "/")
+	sc.repo, sc.repoPath, _ = strings.Cut(target, "/")
+	sc.repoPath = strings.Trim(sc.repoPath, "/")
+	return sc
+}
+
+func (sc *SyncCommand) SetMode(mode SyncMode) *SyncCommand {
+	sc.mode = mode
+	return sc
+}
+
+func (sc *SyncCommand) SetConflictPolicy(conflictPolicy SyncConflictPolicy) *SyncCommand {
+	sc.conflictPolicy = conflictPolicy
+	return sc
+}
+
+func (sc *SyncCommand) SetThreads(threads int) *SyncCommand {
+	sc.threads = threads
+	return sc
+}
+
+func (sc *SyncCommand) SetDeleteAdded(deleteAdded bool) *SyncCommand {
+	sc.deleteAdded = deleteAdded
+	return sc
+}
+
+// SetStateDir sets the directory of the sync state files. Default: $JFROG_CLI_HOME_DIR/sync
+func (sc *SyncCommand) SetStateDir(stateDir string) *SyncCommand {
+	sc.stateDir = stateDir
+	return sc
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+func (sc *SyncCommand) Run() (err error) {
+	if sc.repo == "" {
+		return errorutils.CheckErrorf("the sync target should be in the form of repo/path")
+	}
+	if sc.localDir, err = filepath.Abs(sc.localDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(sc.localDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	statePath, err := sc.getStatePath()
+	if err != nil {
+		return err
+	}
+	state, err := readSyncState(statePath)
+	if err != nil {
+		return err
+	}
+	local, err := scanLocalDir(sc.localDir, state.Files)
+	if err != nil {
+		return err
+	}
+	remote, err := sc.listRemoteFiles(servicesManager)
+	if err != nil {
+		return err
+	}
+	plan, err := computeSyncPlan(local, remote, state.Files, sc.mode, sc.conflictPolicy, sc.deleteAdded)
+	if err != nil {
+		return err
+	}
+	if sc.dryRun {
+		log.Output(plan.String())
+		return nil
+	}
+	log.Info(plan.String())
+	deletes := plan.Count(SyncDeleteRemote) + plan.Count(SyncDeleteLocal)
+	if deletes > 0 && !sc.quiet && !coreutils.AskYesNo(fmt.Sprintf("The sync will delete %d files. Are you sure you want to continue?", deletes), false) {
+		return nil
+	}
+	syncer := &planExecutor{
+		SyncCommand:     sc,
+		servicesManager: servicesManager,
+		local:           local,
+		state:           newSyncedFiles(plan, local, state.Files),
+	}
+	err = syncer.execute(plan)
+	sc.result.SetSuccessCount(syncer.successCount)
+	sc.result.SetFailCount(syncer.failCount)
+	state.LocalDir, state.Target, state.Files = sc.localDir, sc.getTarget(), syncer.state
+	return errors.Join(err, writeSyncState(statePath, state))
+}
+
+func (sc *SyncCommand) getTarget() string {
+	return path.Join(sc.repo, sc.repoPath)
+}
+
+// Each pair of a local directory and a repository path on a server has its own state file.
+func (sc *SyncCommand) getStatePath() (string, error) {
+	stateDir := sc.stateDir
+	if stateDir == "" {
+		homeDir, err := coreutils.GetJfrogHomeDir()
+		if err != nil {
+			return "", err
+		}
+		stateDir = filepath.Join(homeDir, syncStateDirName)
+	}
+	key := sha256.Sum256([]byte(sc.serverDetails.ArtifactoryUrl + "\n" + sc.localDir + "\n" + sc.getTarget()))
+	return filepath.Join(stateDir, hex.EncodeToString(key[:])+".json"), nil
+}
+
+func readSyncState(statePath string) (*syncState, error) {
+	state := &syncState{Files: make(map[string]syncedFile)}
+	content, err := os.ReadFile(statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Debug("No sync state found at " + statePath + ". All the files are compared.")
+		return state, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, state); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the sync state %s: %s", statePath, err.Error())
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]syncedFile)
+	}
+	return state, nil
+}
+
+func writeSyncState(statePath string, state *syncState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(statePath), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(statePath, content, 0600))
+}
+
+// Return the regular files in the local directory by their relative path.
+// Files with the size and modification time of the last sync are not hashed again.
+func scanLocalDir(localDir string, lastSynced map[string]syncedFile) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	hashed := 0
+	err := filepath.WalkDir(localDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			if !entry.IsDir() {
+				log.Debug("Skipping " + filePath + ", which is not a regular file.")
+			}
+			return nil
+		}
+		if strings.HasSuffix(entry.Name(), syncTempFileSuffix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		file := syncFile{Size: info.Size(), Modified: info.ModTime()}
+		// States written by older versions have no SHA-1 checksums, so their files are hashed again.
+		if synced, ok := lastSynced[relativePath]; ok && synced.Size == file.Size && synced.ModTime == file.Modified.UnixNano() && synced.Sha1 != "" {
+			file.Sha256, file.Sha1 = synced.Sha256, synced.Sha1
+		} else {
+			details, err := fileutils.GetFileDetails(filePath, true)
+			if err != nil {
+				return err
+			}
+			file.Sha256, file.Sha1 = details.Checksum.Sha256, details.Checksum.Sha1
+			hashed++
+		}
+		files[relativePath] = file
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	log.Debug(fmt.Sprintf("Found %d local files, %d of which were hashed.", len(files), hashed))
+	return files, nil
+}
+
+// Return the files under the repository path by their path relative to it.
+func (sc *SyncCommand) listRemoteFiles(servicesManager artifactory.ArtifactoryServicesManager) (map[string]syncFile, error) {
+	results, err := artifactoryUtils.ExecuteAqlQuery(servicesManager, createSyncAqlQuery(sc.repo, sc.repoPath))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]syncFile)
+	for _, item := range results {
+		relativePath := strings.TrimPrefix(path.Join(item.Path, item.Name), sc.repoPath+"/")
+		if item.Path == "." {
+			relativePath = item.Name
+		}
+		if item.Sha256 == "" {
+			log.Debug(fmt.Sprintf("The SHA-256 checksum of %s/%s/%s is missing in Artifactory. Its SHA-1 checksum is compared instead.", item.Repo, item.Path, item.Name))
+		}
+		modified, err := time.Parse(time.RFC3339, item.Modified)
+		if err != nil {
+			log.Debug(fmt.Sprintf("Couldn't parse the modification time of %s: %s", relativePath, err.Error()))
+		}
+		files[relativePath] = syncFile{Sha256: item.Sha256, Sha1: item.Actual_Sha1, Size: item.Size, Modified: modified}
+	}
+	return files, nil
+}
+
+func createSyncAqlQuery(repo, repoPath string) string {
+	pathFilter := ""
+	if repoPath != "" {
+		pathFilter = fmt.Sprintf(`,"$or":[{"path":%q},{"path":{"$match":%q}}]`, repoPath, repoPath+"/*")
+	}
+	return fmt.Sprintf(`items.find({"repo":%q,"type":"file"%s}).include("repo","path","name","sha256","actual_sha1","size","modified")`, repo, pathFilter)
+}
+
+// Return the files of the last sync which remain in sync after the plan is executed, assuming all actions fail.
+// Files which are now identical on both sides are added.
+func newSyncedFiles(plan *SyncPlan, local map[string]syncFile, lastSynced map[string]syncedFile) map[string]syncedFile {
+	state := make(map[string]syncedFile)
+	for _, entry := range plan.Entries {
+		if synced, ok := lastSynced[entry.Path]; ok {
+			state[entry.Path] = synced
+		}
+	}
+	for filePath, file := range plan.InSync {
+		state[filePath] = syncedFile{Sha256: file.Sha256, Sha1: file.Sha1, Size: file.Size, ModTime: local[filePath].Modified.UnixNano()}
+	}
+	return state
+}
+
+// planExecutor executes the actions of a sync plan in parallel, and updates the synced files accordingly.
+type planExecutor struct {
+	*SyncCommand
+	servicesManager artifactory.ArtifactoryServicesManager
+	local           map[string]syncFile
+	mutex           sync.Mutex
+	state           map[string]syncedFile
+	successCount    int
+	failCount       int
+}
+
+func (pe *planExecutor) execute(plan *SyncPlan) error {
+	producerConsumer := parallel.NewRunner(pe.threads, uint(len(plan.Entries)), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	for _, entry := range plan.Entries {
+		if entry.Action == SyncConflict {
+			log.Warn(fmt.Sprintf("Skipping %s, which changed both locally and in Artifactory since the last sync.", entry.Path))
+			continue
+		}
+		if entry.Action == SyncSkip {
+			log.Debug(fmt.Sprintf("Skipping %s: %s.", entry.Path, entry.Reason))
+			continue
+		}
+		_, _ = producerConsumer.AddTaskWithError(func(int) error {
+			err := pe.executeEntry(entry)
+			pe.mutex.Lock()
+			defer pe.mutex.Unlock()
+			if err != nil {
+				pe.failCount++
+				return err
+			}
+			pe.successCount++
+			return nil
+		}, errorsQueue.AddError)
+	}
+	producerConsumer.Done()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+func (pe *planExecutor) executeEntry(entry SyncPlanEntry) error {
+	log.Info(fmt.Sprintf("%s: %s", entry.Action, entry.Path))
+	localPath := filepath.Join(pe.localDir, filepath.FromSlash(entry.Path))
+	switch entry.Action {
+	case SyncUpload:
+		if err := pe.upload(localPath, entry.Path); err != nil {
+			return err
+		}
+		localFile := pe.local[entry.Path]
+		pe.setSynced(entry.Path, syncedFile{Sha256: localFile.Sha256, Sha1: localFile.Sha1, Size: localFile.Size, ModTime: localFile.Modified.UnixNano()})
+	case SyncDownload:
+		details, err := pe.download(localPath, entry)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		pe.setSynced(entry.Path, syncedFile{Sha256: details.Checksum.Sha256, Sha1: details.Checksum.Sha1, Size: info.Size(), ModTime: info.ModTime().UnixNano()})
+	case SyncDeleteRemote:
+		if err := pe.deleteRemote(entry.Path); err != nil {
+			return err
+		}
+		pe.setSynced(entry.Path, syncedFile{})
+	case SyncDeleteLocal:
+		if err := os.Remove(localPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errorutils.CheckError(err)
+		}
+		pe.setSynced(entry.Path, syncedFile{})
+	}
+	return nil
+}
+
+// Set the state of a file after a successful action. An empty state removes the file from the state.
+func (pe *planExecutor) setSynced(relativePath string, synced syncedFile) {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	if synced.Sha256 == "" {
+		delete(pe.state, relativePath)
+		return
+	}
+	pe.state[relativePath] = synced
+}
+
+func (pe *planExecutor) getRepoPath(relativePath string) string {
+	return path.Join(pe.repo, pe.repoPath, relativePath)
+}
+
+func (pe *planExecutor) upload(localPath, relativePath string) error {
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = localPath
+	uploadParams.Target = pe.getRepoPath(relativePath)
+	uploadParams.Flat = true
+	uploaded, failed, err := pe.servicesManager.UploadFiles(artifactory.UploadServiceOptions{FailFast: true}, uploadParams)
+	if err != nil {
+		return err
+	}
+	if uploaded != 1 || failed > 0 {
+		return errorutils.CheckErrorf("failed to upload %s", relativePath)
+	}
+	return nil
+}
+
+// Download the file to a temp file next to the local path, and rename it once its checksum is verified.
+func (pe *planExecutor) download(localPath string, entry SyncPlanEntry) (details *fileutils.FileDetails, err error) {
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	tempPath := localPath + syncTempFileSuffix
+	defer func() {
+		if err != nil {
+			if removeErr := os.Remove(tempPath); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+				err = errors.Join(err, errorutils.CheckError(removeErr))
+			}
+		}
+	}()
+	downloadParams := services.NewDownloadParams()
+	downloadParams.Pattern = pe.getRepoPath(entry.Path)
+	downloadParams.Target = tempPath
+	downloadParams.Flat = true
+	downloaded, failed, err := pe.servicesManager.DownloadFiles(downloadParams)
+	if err != nil {
+		return nil, err
+	}
+	if downloaded != 1 || failed > 0 {
+		return nil, errorutils.CheckErrorf("failed to download %s", entry.Path)
+	}
+	// The file may have changed in Artifactory since the plan was computed.
+	if details, err = fileutils.GetFileDetails(tempPath, true); err != nil {
+		return nil, err
+	}
+	if !isSameContent(details.Checksum.Sha256, details.Checksum.Sha1, entry.RemoteSha256, entry.RemoteSha1) {
+		return nil, errorutils.CheckErrorf("the content of %s changed in Artifactory during the sync", entry.Path)
+	}
+	return details, errorutils.CheckError(os.Rename(tempPath, localPath))
+}
+
+func (pe *planExecutor) deleteRemote(relativePath string) (err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	repoPath := pe.getRepoPath(relativePath)
+	writer.Write(clientServicesUtils.ResultItem{Repo: pe.repo, Path: path.Dir(strings.TrimPrefix(repoPath, pe.repo+"/")), Name: path.Base(repoPath), Type: "file"})
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer ioutils.Close(reader, &err)
+	deleted, err := pe.servicesManager.DeleteFiles(reader)
+	if err != nil {
+		return err
+	}
+	if deleted != 1 {
+		return errorutils.CheckErrorf("failed to delete %s", relativePath)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSyncPlan(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	lastSynced := map[string]syncedFile{
+		"unchanged.txt":       {Sha256: "a"},
+		"local-modified.txt":  {Sha256: "a"},
+		"remote-modified.txt": {Sha256: "a"},
+		"local-deleted.txt":   {Sha256: "a"},
+		"remote-deleted.txt":  {Sha256: "a"},
+		"both-modified.txt":   {Sha256: "a"},
+		"both-deleted.txt":    {Sha256: "a"},
+	}
+	local := map[string]syncFile{
+		"unchanged.txt":       {Sha256: "a"},
+		"local-modified.txt":  {Sha256: "b"},
+		"remote-modified.txt": {Sha256: "a"},
+		"remote-deleted.txt":  {Sha256: "a"},
+		"both-modified.txt":   {Sha256: "b", Modified: newer},
+		"local-new.txt":       {Sha256: "c"},
+		"both-new-same.txt":   {Sha256: "d"},
+	}
+	remote := map[string]syncFile{
+		"unchanged.txt":       {Sha256: "a"},
+		"local-modified.txt":  {Sha256: "a"},
+		"remote-modified.txt": {Sha256: "b"},
+		"local-deleted.txt":   {Sha256: "a"},
+		"both-modified.txt":   {Sha256: "c", Modified: older},
+		"remote-new.txt":      {Sha256: "c"},
+		"both-new-same.txt":   {Sha256: "d"},
+	}
+
+	tests := []struct {
+		mode     SyncMode
+		policy   SyncConflictPolicy
+		expected map[string]SyncAction
+	}{
+		{SyncModeBoth, SyncConflictSkip, map[string]SyncAction{
+			"both-modified.txt":   SyncConflict,
+			"local-deleted.txt":   SyncDeleteRemote,
+			"local-modified.txt":  SyncUpload,
+			"local-new.txt":       SyncUpload,
+			"remote-deleted.txt":  SyncDeleteLocal,
+			"remote-modified.txt": SyncDownload,
+			"remote-new.txt":      SyncDownload,
+		}},
+		{SyncModeBoth, SyncConflictNewer, map[string]SyncAction{
+			"both-modified.txt":   SyncUpload,
+			"local-deleted.txt":   SyncDeleteRemote,
+			"local-modified.txt":  SyncUpload,
+			"local-new.txt":       SyncUpload,
+			"remote-deleted.txt":  SyncDeleteLocal,
+			"remote-modified.txt": SyncDownload,
+			"remote-new.txt":      SyncDownload,
+		}},
+		{SyncModePush, SyncConflictRemote, map[string]SyncAction{
+			"both-modified.txt":   SyncConflict,
+			"local-deleted.txt":   SyncDeleteRemote,
+			"local-modified.txt":  SyncUpload,
+			"local-new.txt":       SyncUpload,
+			"remote-deleted.txt":  SyncUpload,
+			"remote-modified.txt": SyncUpload,
+			"remote-new.txt":      SyncDeleteRemote,
+		}},
+		{SyncModePull, SyncConflictRemote, map[string]SyncAction{
+			"both-modified.txt":   SyncDownload,
+			"local-deleted.txt":   SyncDownload,
+			"local-modified.txt":  SyncDownload,
+			"local-new.txt":       SyncDeleteLocal,
+			"remote-deleted.txt":  SyncDeleteLocal,
+			"remote-modified.txt": SyncDownload,
+			"remote-new.txt":      SyncDownload,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+"-"+string(tt.policy), func(t *testing.T) {
+			plan, err := computeSyncPlan(local, remote, lastSynced, tt.mode, tt.policy, true)
+			require.NoError(t, err)
+			actual := make(map[string]SyncAction)
+			for _, entry := range plan.Entries {
+				actual[entry.Path] = entry.Action
+			}
+			assert.Equal(t, tt.expected, actual)
+			assert.Len(t, plan.InSync, 2)
+			assert.Contains(t, plan.InSync, "both-new-same.txt")
+		})
+	}
+
+	_, err := computeSyncPlan(local, remote, lastSynced, SyncModeBoth, SyncConflictFail, true)
+	assert.ErrorContains(t, err, "found 1 conflicts")
+
+	// Without the delete option, files added on the other side are kept.
+	plan, err := computeSyncPlan(local, remote, lastSynced, SyncModePush, SyncConflictRemote, false)
+	require.NoError(t, err)
+	assert.Equal(t, SyncPlanEntry{Path: "remote-new.txt", Action: SyncSkip, Reason: "added in Artifactory, kept in push mode without the delete option", RemoteSha256: "c"}, plan.Entries[len(plan.Entries)-1])
+	assert.Equal(t, 1, plan.Count(SyncDeleteRemote))
+	plan, err = computeSyncPlan(local, remote, lastSynced, SyncModePull, SyncConflictRemote, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(SyncSkip))
+	assert.Equal(t, 1, plan.Count(SyncDeleteLocal))
+}
+
+func TestComputeSyncPlanSha1Fallback(t *testing.T) {
+	local := map[string]syncFile{"a.txt": {Sha256: "a256", Sha1: "a1"}, "b.txt": {Sha256: "b256", Sha1: "b1"}}
+	// The SHA-256 checksums of files in Artifactory may be missing.
+	remote := map[string]syncFile{"a.txt": {Sha1: "a1"}, "b.txt": {Sha1: "other"}}
+	plan, err := computeSyncPlan(local, remote, nil, SyncModePush, SyncConflictLocal, false)
+	require.NoError(t, err)
+	assert.Contains(t, plan.InSync, "a.txt")
+	assert.Equal(t, []SyncPlanEntry{
+		{Path: "b.txt", Action: SyncUpload, Reason: "changed on both sides, local wins", LocalSha256: "b256", RemoteSha1: "other"},
+	}, plan.Entries)
+}
+
+func TestComputeSyncPlanFirstSync(t *testing.T) {
+	local := map[string]syncFile{"a.txt": {Sha256: "a"}, "b.txt": {Sha256: "b"}}
+	remote := map[string]syncFile{"a.txt": {Sha256: "other"}, "c.txt": {Sha256: "c"}}
+	plan, err := computeSyncPlan(local, remote, nil, SyncModeBoth, SyncConflictLocal, false)
+	require.NoError(t, err)
+	assert.Equal(t, []SyncPlanEntry{
+		{Path: "a.txt", Action: SyncUpload, Reason: "changed on both sides, local wins", LocalSha256: "a", RemoteSha256: "other"},
+		{Path: "b.txt", Action: SyncUpload, Reason: "changed locally", LocalSha256: "b"},
+		{Path: "c.txt", Action: SyncDownload, Reason: "changed in Artifactory", RemoteSha256: "c"},
+	}, plan.Entries)
+}
+
+func TestScanLocalDir(t *testing.T) {
+	localDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(localDir, "dir"), 0755))
+	filePath := filepath.Join(localDir, "dir", "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("content"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "partial"+syncTempFileSuffix), []byte("partial"), 0644))
+
+	files, err := scanLocalDir(localDir, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	file := files["dir/file.txt"]
+	assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", file.Sha256)
+	assert.Equal(t, "040f06fd774092478d450774f5ba30c5da78acc8", file.Sha1)
+
+	// A file with the size and modification time of the last sync is not hashed again.
+	lastSynced := map[string]syncedFile{"dir/file.txt": {Sha256: "cached", Sha1: "cached1", Size: file.Size, ModTime: file.Modified.UnixNano()}}
+	files, err = scanLocalDir(localDir, lastSynced)
+	require.NoError(t, err)
+	assert.Equal(t, "cached", files["dir/file.txt"].Sha256)
+	assert.Equal(t, "cached1", files["dir/file.txt"].Sha1)
+}
+
+func TestSyncStateRoundTrip(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "sync", "state.json")
+	state, err := readSyncState(statePath)
+	require.NoError(t, err)
+	assert.Empty(t, state.Files)
+
+	state.Files["a.txt"] = syncedFile{Sha256: "a", Size: 1, ModTime: 2}
+	require.NoError(t, writeSyncState(statePath, state))
+	state, err = readSyncState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]syncedFile{"a.txt": {Sha256: "a", Size: 1, ModTime: 2}}, state.Files)
+}
+
+func TestCreateSyncAqlQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"repo":"generic-local","type":"file"}).include("repo","path","name","sha256","actual_sha1","size","modified")`, createSyncAqlQuery("generic-local", ""))
+	assert.Equal(t, `items.find({"repo":"generic-local","type":"file","$or":[{"path":"a/b"},{"path":{"$match":"a/b/*"}}]}).include("repo","path","name","sha256","actual_sha1","size","modified")`, createSyncAqlQuery("generic-local", "a/b"))
+}
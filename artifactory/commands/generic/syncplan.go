@@ -0,0 +1,236 @@
+package generic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type SyncMode string
+
+const (
+	// Make the repository path a mirror of the local directory.
+	SyncModePush SyncMode = "push"
+	// Make the local directory a mirror of the repository path.
+	SyncModePull SyncMode = "pull"
+	// Propagate the changes of each side to the other side.
+	SyncModeBoth SyncMode = "both"
+)
+
+type SyncConflictPolicy string
+
+const (
+	// Report conflicts and leave both sides unchanged.
+	SyncConflictSkip SyncConflictPolicy = "skip"
+	// Fail before changing anything if there are conflicts.
+	SyncConflictFail SyncConflictPolicy = "fail"
+	// The local file wins.
+	SyncConflictLocal SyncConflictPolicy = "local"
+	// The file in Artifactory wins.
+	SyncConflictRemote SyncConflictPolicy = "remote"
+	// The most recently modified file wins.
+	SyncConflictNewer SyncConflictPolicy = "newer"
+)
+
+type SyncAction string
+
+const (
+	SyncUpload       SyncAction = "upload"
+	SyncDownload     SyncAction = "download"
+	SyncDeleteRemote SyncAction = "delete-remote"
+	SyncDeleteLocal  SyncAction = "delete-local"
+	SyncConflict     SyncAction = "conflict"
+	// A file added on one side, which push or pull mode would delete without the delete option.
+	SyncSkip SyncAction = "skip"
+)
+
+func GetSyncMode(mode string) (SyncMode, error) {
+	switch SyncMode(mode) {
+	case "":
+		return SyncModeBoth, nil
+	case SyncModePush, SyncModePull, SyncModeBoth:
+		return SyncMode(mode), nil
+	}
+	return "", errorutils.CheckErrorf("invalid sync mode '%s'. The valid values are: push, pull and both", mode)
+}
+
+func GetSyncConflictPolicy(policy string) (SyncConflictPolicy, error) {
+	switch SyncConflictPolicy(policy) {
+	case "":
+		return SyncConflictSkip, nil
+	case SyncConflictSkip, SyncConflictFail, SyncConflictLocal, SyncConflictRemote, SyncConflictNewer:
+		return SyncConflictPolicy(policy), nil
+	}
+	return "", errorutils.CheckErrorf("invalid conflict policy '%s'. The valid values are: skip, fail, local, remote and newer", policy)
+}
+
+// syncFile is a file on one side of the sync, identified by its path relative to the synced directory.
+type syncFile struct {
+	Sha256 string
+	// Compared only if the SHA-256 checksum of a file in Artifactory is missing.
+	Sha1     string
+	Size     int64
+	Modified time.Time
+}
+
+// Return true if the files have the same content. The SHA-256 checksums are compared if both are known, otherwise the SHA-1 checksums.
+// Files without comparable checksums are considered different.
+func isSameContent(sha256, sha1, otherSha256, otherSha1 string) bool {
+	if sha256 != "" && otherSha256 != "" {
+		return sha256 == otherSha256
+	}
+	return sha1 != "" && sha1 == otherSha1
+}
+
+// SyncPlanEntry is an action required to sync a single file.
+type SyncPlanEntry struct {
+	Path         string
+	Action       SyncAction
+	Reason       string
+	LocalSha256  string
+	RemoteSha256 string
+	RemoteSha1   string
+}
+
+// SyncPlan lists the actions required to sync the local directory and the repository path, sorted by path.
+type SyncPlan struct {
+	Entries []SyncPlanEntry
+	// The files which are identical on both sides, by path.
+	InSync map[string]syncFile
+}
+
+func (sp *SyncPlan) Count(action SyncAction) (count int) {
+	for _, entry := range sp.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return
+}
+
+func (sp *SyncPlan) String() string {
+	var sb strings.Builder
+	for _, entry := range sp.Entries {
+		sb.WriteString(fmt.Sprintf("%-13s %s (%s)\n", entry.Action, entry.Path, entry.Reason))
+	}
+	sb.WriteString(fmt.Sprintf("%d to upload, %d to download, %d to delete in Artifactory, %d to delete locally, %d conflicts, %d skipped, %d in sync",
+		sp.Count(SyncUpload), sp.Count(SyncDownload), sp.Count(SyncDeleteRemote), sp.Count(SyncDeleteLocal), sp.Count(SyncConflict), sp.Count(SyncSkip), len(sp.InSync)))
+	return sb.String()
+}
+
+// computeSyncPlan compares the local files and the files in Artifactory with the files of the last sync.
+// A side changed if a file was added, modified or deleted on it since the last sync. A file changed on both sides is a conflict.
+// In push and pull modes, files added on the other side are deleted only if deleteAdded is set, so that a first sync never deletes files
+// which were never synced unless requested.
+func computeSyncPlan(local, remote map[string]syncFile, lastSynced map[string]syncedFile, mode SyncMode, policy SyncConflictPolicy, deleteAdded bool) (*SyncPlan, error) {
+	plan := &SyncPlan{InSync: make(map[string]syncFile)}
+	for _, path := range getSortedSyncPaths(local, remote, lastSynced) {
+		localFile, inLocal := local[path]
+		remoteFile, inRemote := remote[path]
+		if inLocal && inRemote && isSameContent(localFile.Sha256, localFile.Sha1, remoteFile.Sha256, remoteFile.Sha1) {
+			plan.InSync[path] = localFile
+			continue
+		}
+		if !inLocal && !inRemote {
+			continue
+		}
+		base, synced := lastSynced[path]
+		localChanged := inLocal != synced || (inLocal && !isSameContent(localFile.Sha256, localFile.Sha1, base.Sha256, base.Sha1))
+		remoteChanged := inRemote != synced || (inRemote && !isSameContent(remoteFile.Sha256, remoteFile.Sha1, base.Sha256, base.Sha1))
+		entry := SyncPlanEntry{Path: path, LocalSha256: localFile.Sha256, RemoteSha256: remoteFile.Sha256, RemoteSha1: remoteFile.Sha1}
+		switch {
+		case localChanged && remoteChanged:
+			entry.Action, entry.Reason = resolveSyncConflict(localFile, inLocal, remoteFile, inRemote, policy)
+			if !isAllowedSyncAction(entry.Action, mode) {
+				entry.Action, entry.Reason = SyncConflict, fmt.Sprintf("changed on both sides, not resolved in %s mode", mode)
+			}
+		case localChanged:
+			entry.Action, entry.Reason = getPushAction(inLocal), "changed locally"
+			if !isAllowedSyncAction(entry.Action, mode) {
+				// In pull mode, the local directory is made identical to Artifactory.
+				entry.Action, entry.Reason = getPullAction(inRemote), "changed locally, reverted in pull mode"
+				if entry.Action == SyncDeleteLocal && !deleteAdded {
+					entry.Action, entry.Reason = SyncSkip, "added locally, kept in pull mode without the delete option"
+				}
+			}
+		default:
+			entry.Action, entry.Reason = getPullAction(inRemote), "changed in Artifactory"
+			if !isAllowedSyncAction(entry.Action, mode) {
+				// In push mode, Artifactory is made identical to the local directory.
+				entry.Action, entry.Reason = getPushAction(inLocal), "changed in Artifactory, reverted in push mode"
+				if entry.Action == SyncDeleteRemote && !deleteAdded {
+					entry.Action, entry.Reason = SyncSkip, "added in Artifactory, kept in push mode without the delete option"
+				}
+			}
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+	if policy == SyncConflictFail {
+		if conflicts := plan.Count(SyncConflict); conflicts > 0 {
+			return plan, errorutils.CheckErrorf("found %d conflicts between the local directory and Artifactory:\n%s", conflicts, plan.String())
+		}
+	}
+	return plan, nil
+}
+
+func isAllowedSyncAction(action SyncAction, mode SyncMode) bool {
+	switch action {
+	case SyncUpload, SyncDeleteRemote:
+		return mode != SyncModePull
+	case SyncDownload, SyncDeleteLocal:
+		return mode != SyncModePush
+	}
+	return true
+}
+
+func resolveSyncConflict(localFile syncFile, inLocal bool, remoteFile syncFile, inRemote bool, policy SyncConflictPolicy) (SyncAction, string) {
+	switch policy {
+	case SyncConflictLocal:
+		return getPushAction(inLocal), "changed on both sides, local wins"
+	case SyncConflictRemote:
+		return getPullAction(inRemote), "changed on both sides, Artifactory wins"
+	case SyncConflictNewer:
+		// The time of a deletion is unknown, so the existing file wins.
+		if !inRemote || (inLocal && !localFile.Modified.Before(remoteFile.Modified)) {
+			return getPushAction(inLocal), "changed on both sides, local is newer"
+		}
+		return getPullAction(inRemote), "changed on both sides, Artifactory is newer"
+	}
+	return SyncConflict, "changed on both sides"
+}
+
+func getPushAction(inLocal bool) SyncAction {
+	if inLocal {
+		return SyncUpload
+	}
+	return SyncDeleteRemote
+}
+
+func getPullAction(inRemote bool) SyncAction {
+	if inRemote {
+		return SyncDownload
+	}
+	return SyncDeleteLocal
+}
+
+func getSortedSyncPaths(local, remote map[string]syncFile, lastSynced map[string]syncedFile) []string {
+	paths := make(map[string]bool)
+	for path := range local {
+		paths[path] = true
+	}
+	for path := range remote {
+		paths[path] = true
+	}
+	for path := range lastSynced {
+		paths[path] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
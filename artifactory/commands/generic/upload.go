@@ -28,6 +28,9 @@ type UploadCommand struct {
 	uploadConfiguration *utils.UploadConfiguration
 	buildConfiguration  *build.BuildConfiguration
 	progress            ioUtils.ProgressMgr
+	watch               bool
+	watchDebounce       time.Duration
+	watchStopFile       string
 }
 
 func NewUploadCommand() *UploadCommand {
@@ -52,6 +55,21 @@ func (uc *UploadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	uc.progress = progress
 }
 
+func (uc *UploadCommand) SetWatch(watch bool) *UploadCommand {
+	uc.watch = watch
+	return uc
+}
+
+func (uc *UploadCommand) SetWatchDebounce(watchDebounce time.Duration) *UploadCommand {
+	uc.watchDebounce = watchDebounce
+	return uc
+}
+
+func (uc *UploadCommand) SetWatchStopFile(watchStopFile string) *UploadCommand {
+	uc.watchStopFile = watchStopFile
+	return uc
+}
+
 func (uc *UploadCommand) ShouldPrompt() bool {
 	return uc.syncDelete() && !uc.Quiet()
 }
@@ -65,6 +83,9 @@ func (uc *UploadCommand) CommandName() string {
 }
 
 func (uc *UploadCommand) Run() error {
+	if uc.watch {
+		return uc.watchAndUpload()
+	}
 	return uc.upload()
 }
 
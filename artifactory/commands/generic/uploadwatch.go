@@ -0,0 +1,437 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils/civcs"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The default time a file should remain unmodified before it is uploaded in watch mode.
+const DefaultWatchDebounce = 2 * time.Second
+
+// How often the stop file is checked, in case its creation is not reported by the filesystem notifications.
+const watchStopFileInterval = time.Second
+
+// The delay before failed files are uploaded again. It doubles after every failed retry, up to the maximum delay.
+const (
+	watchRetryInitialDelay = 5 * time.Second
+	watchRetryMaxDelay     = 5 * time.Minute
+)
+
+// watchedFile is the state of a local file, as observed while watching.
+type watchedFile struct {
+	size    int64
+	modTime time.Time
+}
+
+// uploadWatcher uploads the files matching the upload spec as they are created or modified, until it is stopped.
+type uploadWatcher struct {
+	*UploadCommand
+	servicesManager artifactory.ArtifactoryServicesManager
+	uploadParams    []services.UploadParams
+	toCollect       bool
+	fsWatcher       *fsnotify.Watcher
+	vcsCache        *clientUtils.VcsCache
+	// The last observed state of files which are not uploaded yet.
+	observed map[string]watchedFile
+	// The state of the files when they were uploaded.
+	uploaded map[string]watchedFile
+	// The files which failed to upload, and weren't uploaded successfully since.
+	failed map[string]bool
+	// The delay before the failed files are uploaded again, or zero if no file failed.
+	retryDelay             time.Duration
+	transferDetailsReaders []*content.ContentReader
+	successCount           int
+	// The errors of the upload batches, which don't stop the watch.
+	uploadErr error
+}
+
+// Watch the source patterns and upload new and modified files, until a signal is received or the stop file is created.
+// Files are uploaded with the same spec semantics as a regular upload, once their size and modification time stabilise.
+func (uc *UploadCommand) watchAndUpload() (err error) {
+	if uc.SyncDeletesPath() != "" {
+		return errorutils.CheckErrorf("the sync-deletes option is not supported in watch mode")
+	}
+	uc.uploadConfiguration.MinChecksumDeploySize, err = utils.GetMinChecksumDeploySize()
+	if err != nil {
+		return
+	}
+	serverDetails, err := uc.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	servicesManager, err := utils.CreateUploadServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.retries, uc.retryWaitTimeMilliSecs, uc.DryRun(), nil)
+	if err != nil {
+		return
+	}
+	toCollect, err := uc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return
+	}
+	addVcsProps := false
+	buildProps := ""
+	if toCollect && !uc.DryRun() {
+		addVcsProps = true
+		if buildProps, err = build.CreateBuildPropsFromConfiguration(uc.buildConfiguration); err != nil {
+			return
+		}
+	}
+	watcher := &uploadWatcher{
+		UploadCommand:   uc,
+		servicesManager: servicesManager,
+		toCollect:       toCollect,
+		vcsCache:        clientUtils.NewVcsDetails(),
+		observed:        make(map[string]watchedFile),
+		uploaded:        make(map[string]watchedFile),
+		failed:          make(map[string]bool),
+	}
+	for i := 0; i < len(uc.Spec().Files); i++ {
+		file := uc.Spec().Get(i)
+		if file.Archive != "" {
+			return errorutils.CheckErrorf("the archive option is not supported in watch mode")
+		}
+		file.TargetProps = clientUtils.AddProps(file.TargetProps, file.Props)
+		file.TargetProps = civcs.MergeWithUserProps(file.TargetProps)
+		uploadParams, err := getUploadParams(file, uc.uploadConfiguration, buildProps, addVcsProps, uc.DryRun())
+		if err != nil {
+			return err
+		}
+		watcher.uploadParams = append(watcher.uploadParams, uploadParams)
+	}
+	if watcher.fsWatcher, err = fsnotify.NewWatcher(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(watcher.fsWatcher.Close()))
+	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = watcher.run(ctx)
+	uc.result.SetSuccessCount(watcher.successCount)
+	uc.result.SetFailCount(len(watcher.failed))
+	return errors.Join(err, watcher.uploadErr, watcher.setTransferDetailsReader())
+}
+
+func (uw *uploadWatcher) run(ctx context.Context) error {
+	if err := uw.addWatches(); err != nil {
+		return err
+	}
+	if uw.watchStopFile != "" {
+		log.Info(fmt.Sprintf("Watching for files to upload. Stop with Ctrl+C, or by creating %s.", uw.watchStopFile))
+	} else {
+		log.Info("Watching for files to upload. Stop with Ctrl+C.")
+	}
+	stopFileTicker := time.NewTicker(watchStopFileInterval)
+	defer stopFileTicker.Stop()
+	// Upload the existing files first.
+	scanTimer := time.NewTimer(0)
+	defer scanTimer.Stop()
+	// Upload the failed files again, even if no file changes.
+	retryTimer := time.NewTimer(0)
+	retryTimer.Stop()
+	defer retryTimer.Stop()
+	// Failed uploads don't stop the watch. They're reported in the summary when the watch stops.
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Received a stop signal.")
+			return uw.finalScan()
+		case event, ok := <-uw.fsWatcher.Events:
+			if !ok {
+				return uw.finalScan()
+			}
+			if uw.isStopFile(event.Name) && uw.stopFileExists() {
+				return uw.finalScan()
+			}
+			if event.Has(fsnotify.Create) {
+				uw.watchNewDir(event.Name)
+			}
+			scanTimer.Reset(uw.watchDebounce)
+		case err, ok := <-uw.fsWatcher.Errors:
+			if ok {
+				log.Warn("File watcher error:", err.Error())
+			}
+		case <-stopFileTicker.C:
+			if uw.stopFileExists() {
+				return uw.finalScan()
+			}
+		case <-scanTimer.C:
+			pending, err := uw.scan(false)
+			if err != nil {
+				return err
+			}
+			// Check again later for files which are still being written.
+			if pending {
+				scanTimer.Reset(uw.watchDebounce)
+			}
+			uw.scheduleRetry(retryTimer)
+		case <-retryTimer.C:
+			log.Info(fmt.Sprintf("Retrying the upload of %d failed files.", len(uw.failed)))
+			pending, err := uw.scan(false)
+			if err != nil {
+				return err
+			}
+			if pending {
+				scanTimer.Reset(uw.watchDebounce)
+			}
+			uw.scheduleRetry(retryTimer)
+		}
+	}
+}
+
+// Start the retry timer if files failed to upload, or stop it if all the files were uploaded.
+func (uw *uploadWatcher) scheduleRetry(retryTimer *time.Timer) {
+	retryTimer.Stop()
+	if uw.retryDelay > 0 {
+		retryTimer.Reset(uw.retryDelay)
+	}
+}
+
+// Returns the delay before the next retry, after a batch with failed files.
+func nextRetryDelay(retryDelay time.Duration) time.Duration {
+	if retryDelay == 0 {
+		return watchRetryInitialDelay
+	}
+	return min(2*retryDelay, watchRetryMaxDelay)
+}
+
+// Before stopping, upload all the new and modified files, including files which may still be written.
+func (uw *uploadWatcher) finalScan() error {
+	log.Info("Stopping the watch. Uploading the remaining files.")
+	_, err := uw.scan(true)
+	return err
+}
+
+// Watch the root directory of each source pattern, and its subdirectories if the pattern is recursive.
+func (uw *uploadWatcher) addWatches() error {
+	for _, params := range uw.uploadParams {
+		rootPath, err := fspatterns.GetRootPath(params.GetPattern(), params.GetTarget(), params.TargetPathInArchive, params.GetPatternType(), params.IsSymlink())
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(rootPath); err != nil || !info.IsDir() {
+			rootPath = filepath.Dir(rootPath)
+		}
+		if !params.IsRecursive() {
+			if err = uw.fsWatcher.Add(rootPath); err != nil {
+				return errorutils.CheckError(err)
+			}
+			continue
+		}
+		if err = uw.addDirWatches(rootPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uw *uploadWatcher) addDirWatches(rootDir string) error {
+	return errorutils.CheckError(filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		log.Debug("Watching", path)
+		return uw.fsWatcher.Add(path)
+	}))
+}
+
+// Directories created under a watched directory are watched if any of the source patterns is recursive.
+func (uw *uploadWatcher) watchNewDir(path string) {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return
+	}
+	for _, params := range uw.uploadParams {
+		if params.IsRecursive() {
+			if err := uw.addDirWatches(path); err != nil {
+				log.Warn(fmt.Sprintf("Failed to watch %s: %s", path, err.Error()))
+			}
+			return
+		}
+	}
+}
+
+func (uw *uploadWatcher) isStopFile(path string) bool {
+	if uw.watchStopFile == "" {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absStopFile, err := filepath.Abs(uw.watchStopFile)
+	return err == nil && absPath == absStopFile
+}
+
+func (uw *uploadWatcher) stopFileExists() bool {
+	if uw.watchStopFile == "" {
+		return false
+	}
+	_, err := os.Stat(uw.watchStopFile)
+	return err == nil
+}
+
+// Upload the files matching the source patterns which were added or modified since they were last uploaded.
+// Unless forced, a file is uploaded only after it wasn't modified for the debounce period, and its size didn't change
+// since the previous scan. Returns true if some files are still being written.
+func (uw *uploadWatcher) scan(force bool) (pending bool, err error) {
+	now := time.Now()
+	var batch []services.UploadParams
+	toUpload := make(map[string]watchedFile)
+	for _, params := range uw.uploadParams {
+		err = services.CollectFilesForUpload(services.DeepCopyUploadParams(&params), nil, uw.vcsCache, func(data services.UploadData) {
+			localPath := data.Artifact.LocalPath
+			if data.IsDir || uw.isStopFile(localPath) {
+				return
+			}
+			if _, ok := toUpload[localPath]; ok {
+				return
+			}
+			info, statErr := os.Stat(localPath)
+			if statErr != nil {
+				// The file was removed during the scan.
+				return
+			}
+			current := watchedFile{size: info.Size(), modTime: info.ModTime()}
+			if uploaded, ok := uw.uploaded[localPath]; ok && uploaded == current {
+				return
+			}
+			previous, seen := uw.observed[localPath]
+			uw.observed[localPath] = current
+			if !force && (now.Sub(current.modTime) < uw.watchDebounce || (seen && previous.size != current.size)) {
+				pending = true
+				return
+			}
+			toUpload[localPath] = current
+			batch = append(batch, createWatchedFileUploadParams(params, data.Artifact))
+		})
+		if err != nil {
+			return
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+	log.Info(fmt.Sprintf("Uploading %d new or modified files.", len(batch)))
+	succeeded, uploadErr := uw.uploadBatch(batch)
+	if uploadErr != nil {
+		log.Warn("Upload failed:", uploadErr.Error())
+		uw.uploadErr = errors.Join(uw.uploadErr, uploadErr)
+	}
+	// Failed files aren't recorded as uploaded, so they're uploaded again on the next scan or retry, and when stopping.
+	failed := 0
+	for localPath, file := range toUpload {
+		if !succeeded[localPath] {
+			uw.failed[localPath] = true
+			failed++
+			continue
+		}
+		delete(uw.failed, localPath)
+		uw.uploaded[localPath] = file
+		delete(uw.observed, localPath)
+	}
+	if failed > 0 {
+		uw.retryDelay = nextRetryDelay(uw.retryDelay)
+		log.Warn(fmt.Sprintf("%d files failed to upload. They will be uploaded again in %s.", failed, uw.retryDelay))
+	} else if len(uw.failed) == 0 {
+		uw.retryDelay = 0
+	}
+	return
+}
+
+// Upload a single file to the target computed from the original spec, keeping the spec's properties and options.
+func createWatchedFileUploadParams(params services.UploadParams, artifact clientUtils.Artifact) services.UploadParams {
+	fileParams := services.DeepCopyUploadParams(&params)
+	fileParams.SetPattern(artifact.LocalPath)
+	fileParams.SetTarget(artifact.TargetPath)
+	fileParams.Exclusions = nil
+	fileParams.Recursive = false
+	fileParams.Regexp = false
+	fileParams.Ant = false
+	fileParams.IncludeDirs = false
+	fileParams.Flat = true
+	return fileParams
+}
+
+// Upload the batch and return the local paths of the files which were uploaded successfully.
+func (uw *uploadWatcher) uploadBatch(batch []services.UploadParams) (succeeded map[string]bool, err error) {
+	summary, err := uw.servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, batch...)
+	if summary == nil {
+		return nil, err
+	}
+	uw.successCount += summary.TotalSucceeded
+	defer func() {
+		err = errors.Join(err, summary.ArtifactsDetailsReader.Close())
+	}()
+	succeeded, readErr := getUploadedLocalPaths(summary.TransferDetailsReader)
+	if err = errors.Join(err, readErr, recordCommandSummary(summary)); err != nil {
+		return succeeded, errors.Join(err, summary.TransferDetailsReader.Close())
+	}
+	// The transfer details of all the batches are merged for the detailed summary.
+	if uw.DetailedSummary() {
+		uw.transferDetailsReaders = append(uw.transferDetailsReaders, summary.TransferDetailsReader)
+	} else if err = summary.TransferDetailsReader.Close(); err != nil {
+		return
+	}
+	if uw.DryRun() || !uw.toCollect {
+		return
+	}
+	buildArtifacts, err := rtServicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return
+	}
+	err = build.PopulateBuildArtifactsAsPartials(buildArtifacts, uw.buildConfiguration, buildInfo.Generic)
+	return
+}
+
+// Read the local paths of the transferred files. The reader is reset, to be read again for the detailed summary.
+func getUploadedLocalPaths(transferDetailsReader *content.ContentReader) (map[string]bool, error) {
+	uploaded := make(map[string]bool)
+	for details := new(clientUtils.FileTransferDetails); transferDetailsReader.NextRecord(details) == nil; details = new(clientUtils.FileTransferDetails) {
+		uploaded[details.SourcePath] = true
+	}
+	err := transferDetailsReader.GetError()
+	transferDetailsReader.Reset()
+	return uploaded, err
+}
+
+func (uw *uploadWatcher) setTransferDetailsReader() error {
+	switch len(uw.transferDetailsReaders) {
+	case 0:
+		return nil
+	case 1:
+		uw.result.SetReader(uw.transferDetailsReaders[0])
+		return nil
+	}
+	merged, err := content.MergeReaders(uw.transferDetailsReaders, content.DefaultKey)
+	for _, reader := range uw.transferDetailsReaders {
+		err = errors.Join(err, reader.Close())
+	}
+	if err != nil {
+		return err
+	}
+	uw.result.SetReader(merged)
+	return nil
+}
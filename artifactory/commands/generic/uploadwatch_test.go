@@ -0,0 +1,149 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWatchedFileUploadParams(t *testing.T) {
+	file := &spec.File{Pattern: "build/(*).bin", Target: "repo/{1}/", Regexp: "true", Exclusions: []string{"*.tmp"}, TargetProps: "a=b"}
+	params, err := getUploadParams(file, &utils.UploadConfiguration{}, "", false, true)
+	require.NoError(t, err)
+
+	fileParams := createWatchedFileUploadParams(params, clientUtils.Artifact{LocalPath: "build/app.bin", TargetPath: "repo/app/app.bin"})
+	assert.Equal(t, "build/app.bin", fileParams.GetPattern())
+	assert.Equal(t, "repo/app/app.bin", fileParams.GetTarget())
+	assert.True(t, fileParams.IsFlat())
+	assert.False(t, fileParams.IsRecursive())
+	assert.False(t, fileParams.Regexp)
+	assert.Empty(t, fileParams.Exclusions)
+	assert.Equal(t, params.TargetProps, fileParams.TargetProps)
+	// The original params are unchanged.
+	assert.True(t, params.Regexp)
+	assert.Equal(t, "build/(*).bin", params.GetPattern())
+}
+
+func TestUploadWatcherScan(t *testing.T) {
+	sourceDir := t.TempDir()
+	stopFile := filepath.Join(sourceDir, "stop")
+	stable := filepath.Join(sourceDir, "stable.txt")
+	require.NoError(t, os.WriteFile(stable, []byte("stable"), 0644))
+	stableTime := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(stable, stableTime, stableTime))
+	writing := filepath.Join(sourceDir, "writing.txt")
+	require.NoError(t, os.WriteFile(writing, []byte("part"), 0644))
+
+	uc := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	uc.SetWatchDebounce(10 * time.Second).SetWatchStopFile(stopFile)
+	uc.SetDryRun(true).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"})
+	servicesManager, err := utils.CreateUploadServiceManager(uc.serverDetails, 1, 0, 0, true, nil)
+	require.NoError(t, err)
+	params, err := getUploadParams(&spec.File{Pattern: filepath.Join(sourceDir, "*"), Target: "repo/"}, uc.uploadConfiguration, "", false, true)
+	require.NoError(t, err)
+	watcher := &uploadWatcher{
+		UploadCommand:   uc,
+		servicesManager: servicesManager,
+		uploadParams:    []services.UploadParams{params},
+		vcsCache:        clientUtils.NewVcsDetails(),
+		observed:        make(map[string]watchedFile),
+		uploaded:        make(map[string]watchedFile),
+		failed:          make(map[string]bool),
+	}
+
+	// Only the file which wasn't modified during the debounce period is uploaded.
+	require.NoError(t, os.WriteFile(stopFile, nil, 0644))
+	pending, err := watcher.scan(false)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	assert.Equal(t, 1, watcher.successCount)
+	assert.Contains(t, watcher.uploaded, stable)
+	assert.NotContains(t, watcher.uploaded, stopFile)
+
+	// Unchanged files are not uploaded again.
+	pending, err = watcher.scan(false)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	assert.Equal(t, 1, watcher.successCount)
+
+	// When stopping, the remaining files are uploaded.
+	pending, err = watcher.scan(true)
+	require.NoError(t, err)
+	assert.False(t, pending)
+	assert.Equal(t, 2, watcher.successCount)
+	assert.Contains(t, watcher.uploaded, writing)
+	assert.Empty(t, watcher.observed)
+}
+
+func TestUploadWatcherScanFailure(t *testing.T) {
+	sourceDir := t.TempDir()
+	localPath := filepath.Join(sourceDir, "app.bin")
+	require.NoError(t, os.WriteFile(localPath, []byte("app"), 0644))
+	var failUploads atomic.Bool
+	failUploads.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failUploads.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	uc := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	uc.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	servicesManager, err := utils.CreateUploadServiceManager(uc.serverDetails, 1, 0, 0, false, nil)
+	require.NoError(t, err)
+	params, err := getUploadParams(&spec.File{Pattern: filepath.Join(sourceDir, "*"), Target: "repo/"}, uc.uploadConfiguration, "", false, false)
+	require.NoError(t, err)
+	watcher := &uploadWatcher{
+		UploadCommand:   uc,
+		servicesManager: servicesManager,
+		uploadParams:    []services.UploadParams{params},
+		vcsCache:        clientUtils.NewVcsDetails(),
+		observed:        make(map[string]watchedFile),
+		uploaded:        make(map[string]watchedFile),
+		failed:          make(map[string]bool),
+	}
+
+	// A failed upload doesn't stop the watch, and the file isn't recorded as uploaded.
+	_, err = watcher.scan(true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{localPath: true}, watcher.failed)
+	assert.NotContains(t, watcher.uploaded, localPath)
+	assert.Equal(t, watchRetryInitialDelay, watcher.retryDelay)
+
+	// The retry delay is doubled after every failed retry.
+	_, err = watcher.scan(true)
+	require.NoError(t, err)
+	assert.Equal(t, 2*watchRetryInitialDelay, watcher.retryDelay)
+
+	// The file is uploaded again on the next scan.
+	failUploads.Store(false)
+	_, err = watcher.scan(true)
+	require.NoError(t, err)
+	assert.Empty(t, watcher.failed)
+	assert.Contains(t, watcher.uploaded, localPath)
+	assert.Equal(t, 1, watcher.successCount)
+	assert.Zero(t, watcher.retryDelay)
+}
+
+func TestNextRetryDelay(t *testing.T) {
+	assert.Equal(t, watchRetryInitialDelay, nextRetryDelay(0))
+	assert.Equal(t, 2*watchRetryInitialDelay, nextRetryDelay(watchRetryInitialDelay))
+	assert.Equal(t, watchRetryMaxDelay, nextRetryDelay(watchRetryMaxDelay))
+}
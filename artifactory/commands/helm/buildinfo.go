@@ -12,6 +12,11 @@ import (
 
 // CollectHelmBuildInfoWithFlexPack collects Helm build info using FlexPack
 func CollectHelmBuildInfoWithFlexPack(workingDir, buildName, buildNumber, project, commandName string, helmArgs []string, serverDetails *config.ServerDetails) error {
+	return collectHelmBuildInfo(workingDir, buildName, buildNumber, project, commandName, helmArgs, "", serverDetails)
+}
+
+// collectHelmBuildInfo collects Helm build info. renderedManifests holds the output of an executed 'helm template' command, if available.
+func collectHelmBuildInfo(workingDir, buildName, buildNumber, project, commandName string, helmArgs []string, renderedManifests string, serverDetails *config.ServerDetails) error {
 	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
 	if err != nil {
 		return fmt.Errorf("failed to create services manager: %w", err)
@@ -32,6 +37,10 @@ func CollectHelmBuildInfoWithFlexPack(workingDir, buildName, buildNumber, projec
 		return handlePackageCommand(buildInfo, helmArgs, serviceManager, buildName, buildNumber, project)
 	case "dependency":
 		return handleDependencyCommand(buildInfo, helmArgs, serviceManager, workingDir, buildName, buildNumber, project)
+	case "install", "upgrade":
+		return handleInstallCommand(buildInfo, helmArgs, workingDir, serviceManager, buildName, buildNumber, project)
+	case "template":
+		return handleTemplateCommand(buildInfo, helmArgs, renderedManifests, workingDir, serviceManager, buildName, buildNumber, project)
 	}
 	log.Info("Skipping helm build info because", commandName, "command is not collecting build info")
 	return nil
@@ -0,0 +1,96 @@
+package helm
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const helmApiPath = "/api/helm/"
+
+// extractClassicRepositoryName extracts the repository name from a classic Helm repository URL, such as
+// https://acme.jfrog.io/artifactory/api/helm/helm-local, or returns the argument itself if it's a repository name.
+func extractClassicRepositoryName(repositoryURL string) string {
+	if _, repoPath, found := strings.Cut(repositoryURL, helmApiPath); found {
+		repoName, _, _ := strings.Cut(repoPath, "/")
+		return repoName
+	}
+	if !strings.Contains(repositoryURL, "://") {
+		return strings.Trim(repositoryURL, "/")
+	}
+	return ""
+}
+
+// pushClassicChart deploys a packaged chart, along with its provenance file if it exists, to the root of a classic Helm repository.
+func pushClassicChart(chartPath, repositoryURL string, serviceManager artifactory.ArtifactoryServicesManager) error {
+	repoName := extractClassicRepositoryName(repositoryURL)
+	if repoName == "" {
+		return fmt.Errorf("could not extract the repository name from %s", repositoryURL)
+	}
+	patterns := []string{chartPath}
+	exists, err := fileutils.IsFileExists(chartPath+provenanceFileSuffix, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		patterns = append(patterns, chartPath+provenanceFileSuffix)
+	}
+	var uploadParams []services.UploadParams
+	for _, pattern := range patterns {
+		params := services.NewUploadParams()
+		params.Pattern = pattern
+		params.Target = repoName + "/"
+		params.Flat = true
+		uploadParams = append(uploadParams, params)
+	}
+	uploaded, failed, err := serviceManager.UploadFiles(artifactory.UploadServiceOptions{FailFast: true}, uploadParams...)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || uploaded != len(patterns) {
+		return fmt.Errorf("failed to deploy %d of %d files to %s", len(patterns)-uploaded, len(patterns), repoName)
+	}
+	log.Info("Deployed", filepath.Base(chartPath), "to", repoName)
+	return nil
+}
+
+// handleClassicPushCommand records the deployed chart and its provenance file as build-info artifacts.
+func handleClassicPushCommand(buildInfo *entities.BuildInfo, chartPath, repositoryURL, chartName, chartVersion, buildProps string, serviceManager artifactory.ArtifactoryServicesManager) error {
+	repoName := extractClassicRepositoryName(repositoryURL)
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = fmt.Sprintf("%s/%s*", repoName, filepath.Base(chartPath))
+	searchParams.Recursive = false
+	reader, err := serviceManager.SearchFiles(searchParams)
+	if err != nil {
+		return fmt.Errorf("failed to search for the pushed chart: %w", err)
+	}
+	var closeErr error
+	defer func() {
+		ioutils.Close(reader, &closeErr)
+		if closeErr != nil {
+			log.Debug("Failed to close search reader: ", closeErr)
+		}
+	}()
+	var artifacts []entities.Artifact
+	for item := new(servicesUtils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesUtils.ResultItem) {
+		if item.Name == filepath.Base(chartPath) || item.Name == filepath.Base(chartPath)+provenanceFileSuffix {
+			artifacts = append(artifacts, item.ToArtifact())
+		}
+	}
+	if len(artifacts) == 0 {
+		return fmt.Errorf("could not find chart %s in repository %s", filepath.Base(chartPath), repoName)
+	}
+	reader.Reset()
+	addBuildPropertiesOnArtifacts(serviceManager, reader, buildProps)
+	addArtifactsInBuildInfo(buildInfo, artifacts, chartName, chartVersion)
+	removeDuplicateArtifacts(buildInfo)
+	return nil
+}
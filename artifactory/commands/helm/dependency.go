@@ -7,12 +7,7 @@ import (
 )
 
 func handleDependencyCommand(buildInfoOld *entities.BuildInfo, args []string, serviceManager artifactory.ArtifactoryServicesManager, workingDir, buildName, buildNumber, project string) error {
-	chartPath := workingDir
-	chartPaths := getPaths(args)
-	if len(chartPaths) >= 2 {
-		chartPath = chartPaths[1]
-	}
-	buildInfo, err := collectBuildInfoWithFlexPack(chartPath, buildName, buildNumber)
+	buildInfo, err := collectBuildInfoWithFlexPack(getDependencyChartPath(args, workingDir), buildName, buildNumber)
 	if err != nil {
 		return fmt.Errorf("failed to collect build info: %w", err)
 	}
@@ -30,3 +25,18 @@ func handleDependencyCommand(buildInfoOld *entities.BuildInfo, args []string, se
 	}
 	return nil
 }
+
+// getDependencyChartPath returns the chart of 'helm dependency <subcommand> [CHART]', which defaults to the working directory.
+func getDependencyChartPath(args []string, workingDir string) string {
+	chartPaths := getPaths(args)
+	if len(chartPaths) >= 2 {
+		return chartPaths[1]
+	}
+	return workingDir
+}
+
+// isDependencyUpdate returns true if the arguments are of 'helm dependency update', or its 'up' alias.
+func isDependencyUpdate(args []string) bool {
+	paths := getPaths(args)
+	return len(paths) > 0 && (paths[0] == "update" || paths[0] == "up")
+}
@@ -1,7 +1,9 @@
 package helm
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/auth"
@@ -26,6 +28,11 @@ type HelmCommand struct {
 	username           string
 	password           string
 	buildConfiguration *buildUtils.BuildConfiguration
+	signingKey         string
+	keyring            string
+	verifyProvenance   bool
+	// The output of helm template, from which the rendered images are collected.
+	renderedManifests bytes.Buffer
 }
 
 // NewHelmCommand creates a new HelmCommand instance
@@ -86,6 +93,24 @@ func (hc *HelmCommand) SetServerId(serverId string) *HelmCommand {
 	return hc
 }
 
+// SetSigningKey sets the name of the PGP key used to sign the pushed chart
+func (hc *HelmCommand) SetSigningKey(signingKey string) *HelmCommand {
+	hc.signingKey = signingKey
+	return hc
+}
+
+// SetKeyring sets the keyring used for signing and verifying charts
+func (hc *HelmCommand) SetKeyring(keyring string) *HelmCommand {
+	hc.keyring = keyring
+	return hc
+}
+
+// SetVerifyProvenance sets whether to verify the provenance of the charts resolved by helm dependency
+func (hc *HelmCommand) SetVerifyProvenance(verifyProvenance bool) *HelmCommand {
+	hc.verifyProvenance = verifyProvenance
+	return hc
+}
+
 // ServerDetails returns the server details
 func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
 	return hc.serverDetails, nil
@@ -94,9 +119,15 @@ func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
 // Run executes the Helm command
 func (hc *HelmCommand) Run() error {
 	hc.appendCredentialsInArguments()
+	if err := hc.signChartIfNeeded(); err != nil {
+		return errorutils.CheckError(err)
+	}
 	if err := hc.executeHelmCommand(); err != nil {
 		return errorutils.CheckErrorf("helm %s failed: %w", hc.cmdName, err)
 	}
+	if err := hc.verifyProvenanceIfNeeded(); err != nil {
+		return errorutils.CheckError(err)
+	}
 	if err := hc.collectBuildInfoIfNeeded(); err != nil {
 		return errorutils.CheckError(err)
 	}
@@ -166,9 +197,18 @@ func (hc *HelmCommand) executeHelmCommand() error {
 	if hc.cmdName == "registry" {
 		return hc.performRegistryLogin()
 	}
+	if hc.cmdName == "push" {
+		if chartPath, registryURL := getPushChartPathAndRegistryURL(hc.helmArgs); registryURL != "" && !isOCIRepository(registryURL) {
+			// Helm pushes to OCI registries only, so charts are deployed to classic repositories directly.
+			return hc.pushClassicChart(chartPath, registryURL)
+		}
+	}
 	args := append([]string{hc.cmdName}, hc.helmArgs...)
 	helmCmd := exec.Command("helm", args...)
 	helmCmd.Stdout = os.Stdout
+	if hc.cmdName == "template" && hc.buildConfiguration != nil {
+		helmCmd.Stdout = io.MultiWriter(os.Stdout, &hc.renderedManifests)
+	}
 	helmCmd.Stderr = os.Stderr
 	helmCmd.Dir = hc.workingDirectory
 	if hc.hasPasswordStdinFlag() && hc.password != "" {
@@ -178,6 +218,46 @@ func (hc *HelmCommand) executeHelmCommand() error {
 	return helmCmd.Run()
 }
 
+// signChartIfNeeded creates the provenance file of the pushed chart when a signing key is provided.
+// For OCI registries, helm push uploads the provenance file as a layer of the chart.
+func (hc *HelmCommand) signChartIfNeeded() error {
+	if hc.cmdName != "push" || hc.signingKey == "" {
+		return nil
+	}
+	chartPath, _ := getPushChartPathAndRegistryURL(hc.helmArgs)
+	if chartPath == "" {
+		return fmt.Errorf("invalid helm chart path")
+	}
+	return signChart(chartPath, hc.signingKey, hc.getKeyring())
+}
+
+func (hc *HelmCommand) pushClassicChart(chartPath, registryURL string) error {
+	serviceManager, err := utils.CreateServiceManager(hc.serverDetails, -1, 0, false)
+	if err != nil {
+		return fmt.Errorf("failed to create services manager: %w", err)
+	}
+	return pushClassicChart(chartPath, registryURL, serviceManager)
+}
+
+// verifyProvenanceIfNeeded verifies the provenance of the charts resolved by helm dependency update, when requested.
+func (hc *HelmCommand) verifyProvenanceIfNeeded() error {
+	if !hc.verifyProvenance || hc.cmdName != "dependency" || !isDependencyUpdate(hc.helmArgs) {
+		return nil
+	}
+	serviceManager, err := utils.CreateServiceManager(hc.serverDetails, -1, 0, false)
+	if err != nil {
+		return fmt.Errorf("failed to create services manager: %w", err)
+	}
+	return verifyDependenciesProvenance(getDependencyChartPath(hc.helmArgs, hc.workingDirectory), hc.getKeyring(), serviceManager)
+}
+
+func (hc *HelmCommand) getKeyring() string {
+	if hc.keyring != "" {
+		return hc.keyring
+	}
+	return defaultKeyring()
+}
+
 // collectBuildInfoIfNeeded collects build info if configuration is provided
 func (hc *HelmCommand) collectBuildInfoIfNeeded() error {
 	if hc.buildConfiguration == nil {
@@ -204,7 +284,7 @@ func (hc *HelmCommand) collectBuildInfoIfNeeded() error {
 		return errorutils.CheckError(err)
 	}
 	project := hc.buildConfiguration.GetProject()
-	err = CollectHelmBuildInfoWithFlexPack(hc.workingDirectory, buildName, buildNumber, project, hc.cmdName, hc.helmArgs, hc.serverDetails)
+	err = collectHelmBuildInfo(hc.workingDirectory, buildName, buildNumber, project, hc.cmdName, hc.helmArgs, hc.renderedManifests.String(), hc.serverDetails)
 	return errorutils.CheckError(err)
 }
 
@@ -0,0 +1,60 @@
+package helm
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Matches 'image: <reference>' entries in rendered manifests, including list items and quoted values.
+var imageLinePattern = regexp.MustCompile(`(?m)^\s*(?:-\s+)?image:\s*["']?([^"'\s#]+)["']?\s*(?:#.*)?$`)
+
+// RenderChartImages runs 'helm template' on the given chart and returns the container images referenced by the rendered templates.
+// chartPath may be a chart directory or a packaged chart archive.
+func RenderChartImages(chartPath string, valuesFiles []string) ([]string, error) {
+	args := []string{chartPath}
+	for _, valuesFile := range valuesFiles {
+		args = append(args, "--values", valuesFile)
+	}
+	manifests, err := renderTemplates(args, "")
+	if err != nil {
+		return nil, err
+	}
+	return ExtractImagesFromManifests(manifests), nil
+}
+
+// renderTemplates runs 'helm template' with the given arguments and returns the rendered manifests.
+func renderTemplates(helmArgs []string, workingDirectory string) (string, error) {
+	args := append([]string{"template"}, helmArgs...)
+	log.Debug("Rendering chart templates: helm", strings.Join(args, " "))
+	helmCmd := exec.Command("helm", args...)
+	helmCmd.Dir = workingDirectory
+	output, err := helmCmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", errorutils.CheckErrorf("failed rendering the chart templates: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", errorutils.CheckErrorf("failed running 'helm template': %s", err.Error())
+	}
+	return string(output), nil
+}
+
+// ExtractImagesFromManifests returns the unique container images referenced by the given rendered manifests, in order of appearance.
+func ExtractImagesFromManifests(manifests string) []string {
+	var images []string
+	encountered := make(map[string]bool)
+	for _, match := range imageLinePattern.FindAllStringSubmatch(manifests, -1) {
+		image := match[1]
+		if encountered[image] {
+			continue
+		}
+		encountered[image] = true
+		images = append(images, image)
+	}
+	return images
+}
@@ -0,0 +1,43 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractImagesFromManifests(t *testing.T) {
+	manifests := `---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: "acme.jfrog.io/docker-local/busybox:1.36"
+      containers:
+        - image: acme.jfrog.io/docker-local/app:1.0.0
+          name: app
+        - name: sidecar
+          image: 'acme.jfrog.io/docker-local/proxy@sha256:0123456789abcdef' # pinned
+---
+# Source: app/templates/job.yaml
+apiVersion: batch/v1
+kind: Job
+spec:
+  template:
+    spec:
+      containers:
+        - name: migrate
+          image: acme.jfrog.io/docker-local/app:1.0.0
+          imagePullPolicy: IfNotPresent
+`
+	assert.Equal(t, []string{
+		"acme.jfrog.io/docker-local/busybox:1.36",
+		"acme.jfrog.io/docker-local/app:1.0.0",
+		"acme.jfrog.io/docker-local/proxy@sha256:0123456789abcdef",
+	}, ExtractImagesFromManifests(manifests))
+	assert.Empty(t, ExtractImagesFromManifests("apiVersion: v1\nkind: ConfigMap\n"))
+}
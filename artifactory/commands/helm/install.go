@@ -0,0 +1,188 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+const (
+	defaultNamespace = "default"
+	// Build properties recorded for every deployment, suffixed by the deployment module ID.
+	releaseNameProperty      = "helm.release.name"
+	releaseNamespaceProperty = "helm.release.namespace"
+	kubeContextProperty      = "helm.kube.context"
+	chartProperty            = "helm.chart"
+	valuesFileProperty       = "helm.values"
+)
+
+// deployedChart describes the chart installed by helm install or helm upgrade.
+type deployedChart struct {
+	name       string
+	version    string
+	repository string
+	sha256     string
+}
+
+// handleInstallCommand records the deployment done by helm install or helm upgrade.
+// The deployment is recorded as a module, identified by the namespace and release name, whose dependency is the installed chart.
+func handleInstallCommand(buildInfo *entities.BuildInfo, helmArgs []string, workingDir string, serviceManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, project string) error {
+	args := parseChartArgs(helmArgs)
+	if args.chart == "" {
+		return fmt.Errorf("invalid helm chart path")
+	}
+	chart, err := resolveDeployedChart(args, helmArgs, workingDir, serviceManager)
+	if err != nil {
+		return err
+	}
+	if args.releaseName == "" {
+		log.Warn("The release name is generated by helm, so the chart name is used to identify the deployment")
+		args.releaseName = chart.name
+	}
+	namespace := getDeploymentNamespace(args)
+	module := entities.Module{
+		Id:   fmt.Sprintf("%s/%s", namespace, args.releaseName),
+		Type: "helm",
+	}
+	if chart.sha256 != "" {
+		module.Dependencies = append(module.Dependencies, entities.Dependency{
+			Id:         fmt.Sprintf("%s:%s", chart.name, chart.version),
+			Type:       "helm",
+			Repository: chart.repository,
+			Checksum:   entities.Checksum{Sha256: chart.sha256},
+		})
+	} else {
+		log.Warn("Chart", args.chart, "is not a packaged chart, so it is not recorded as a dependency")
+	}
+	properties, err := getDeploymentProperties(args, namespace, chart, workingDir)
+	if err != nil {
+		return err
+	}
+	if buildInfo.Properties == nil {
+		buildInfo.Properties = entities.Env{}
+	}
+	for key, value := range properties {
+		buildInfo.Properties[key+"."+module.Id] = value
+	}
+	module.Properties = properties
+	if buildInfo.BuildAgent == nil || buildInfo.BuildAgent.Version == "" {
+		buildInfo.BuildAgent = &entities.Agent{Name: "Helm", Version: getHelmVersion()}
+	}
+	appendModuleInExistingBuildInfo(buildInfo, &module)
+	return saveBuildInfo(buildInfo, buildName, buildNumber, project)
+}
+
+// getDeploymentNamespace returns the namespace of the release, as resolved by helm.
+func getDeploymentNamespace(args chartArgs) string {
+	if args.namespace != "" {
+		return args.namespace
+	}
+	if namespace := os.Getenv("HELM_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return defaultNamespace
+}
+
+func getDeploymentProperties(args chartArgs, namespace string, chart deployedChart, workingDir string) (map[string]string, error) {
+	properties := map[string]string{
+		releaseNameProperty:      args.releaseName,
+		releaseNamespaceProperty: namespace,
+		chartProperty:            fmt.Sprintf("%s:%s", chart.name, chart.version),
+	}
+	kubeContext := args.kubeContext
+	if kubeContext == "" {
+		kubeContext = os.Getenv("HELM_KUBECONTEXT")
+	}
+	if kubeContext != "" {
+		properties[kubeContextProperty] = kubeContext
+	}
+	for _, valuesFile := range args.valuesFiles {
+		valuesFile = resolvePath(valuesFile, workingDir)
+		exists, err := fileutils.IsFileExists(valuesFile, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			log.Debug("Skipping the hash of values file", valuesFile, "which is not a local file")
+			continue
+		}
+		digest, err := provenance.DigestFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash values file %s: %w", valuesFile, err)
+		}
+		properties[fmt.Sprintf("%s.%s.sha256", valuesFileProperty, filepath.Base(valuesFile))] = digest
+	}
+	return properties, nil
+}
+
+// resolveDeployedChart returns the details of the installed chart. Remote charts are pulled to find their digest,
+// which is then used to find the repository the chart is stored in. Local charts are resolved against the working directory.
+func resolveDeployedChart(args chartArgs, helmArgs []string, workingDir string, serviceManager artifactory.ArtifactoryServicesManager) (chart deployedChart, err error) {
+	archive := resolvePath(args.chart, workingDir)
+	if !fileutils.IsPathExists(archive, false) {
+		var tempDirPath string
+		if tempDirPath, err = fileutils.CreateTempDir(); err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+		}()
+		if archive, err = pullChart(args.chart, helmArgs, tempDirPath, workingDir); err != nil {
+			return
+		}
+	}
+	if chart.name, chart.version, err = getChartDetails(archive); err != nil {
+		return chart, fmt.Errorf("could not extract chart name/version from %s: %w", args.chart, err)
+	}
+	isDir, err := fileutils.IsDirExists(archive, false)
+	if err != nil || isDir {
+		return
+	}
+	if chart.sha256, err = provenance.DigestFile(archive); err != nil {
+		return
+	}
+	chart.repository = findChartRepository(chart, serviceManager)
+	return
+}
+
+// pullChart downloads the chart archive to the target directory and returns its path.
+// The flags which locate the chart, such as the version, repository, credentials and TLS settings, are passed on from the install command.
+func pullChart(chartRef string, helmArgs []string, targetDir, workingDir string) (string, error) {
+	pullArgs := append([]string{"pull", chartRef, "--destination", targetDir}, getChartLocationFlags(helmArgs)...)
+	log.Debug("Pulling the installed chart: helm", strings.Join(pullArgs, " "))
+	helmCmd := exec.Command("helm", pullArgs...)
+	helmCmd.Dir = workingDir
+	if output, err := helmCmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to pull chart %s: %s", chartRef, strings.TrimSpace(string(output)))
+	}
+	archives, err := filepath.Glob(filepath.Join(targetDir, "*.tgz"))
+	if err != nil || len(archives) != 1 {
+		return "", fmt.Errorf("failed to find the pulled archive of chart %s", chartRef)
+	}
+	return archives[0], nil
+}
+
+// findChartRepository returns the repository storing the chart archive, or an OCI chart with the same content.
+func findChartRepository(chart deployedChart, serviceManager artifactory.ArtifactoryServicesManager) string {
+	results, err := utils.ExecuteAqlQuery(serviceManager,
+		fmt.Sprintf(`items.find({"sha256": "%s", "type": "file"}).include("repo", "path", "name")`, chart.sha256))
+	if err != nil {
+		log.Debug("Failed to search for chart ", chart.name, ": ", err)
+		return ""
+	}
+	if len(results) == 0 {
+		log.Debug("Chart ", chart.name, " was not found in Artifactory")
+		return ""
+	}
+	return results[0].Repo
+}
@@ -0,0 +1,47 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeploymentNamespace(t *testing.T) {
+	t.Setenv("HELM_NAMESPACE", "")
+	assert.Equal(t, "apps", getDeploymentNamespace(chartArgs{namespace: "apps"}))
+	assert.Equal(t, "default", getDeploymentNamespace(chartArgs{}))
+	t.Setenv("HELM_NAMESPACE", "staging")
+	assert.Equal(t, "staging", getDeploymentNamespace(chartArgs{}))
+}
+
+func TestGetDeploymentProperties(t *testing.T) {
+	t.Setenv("HELM_KUBECONTEXT", "")
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "prod.yaml"), []byte("replicas: 3\n"), 0644))
+	args := chartArgs{releaseName: "shop", kubeContext: "prod-cluster", valuesFiles: []string{"prod.yaml", "https://acme.com/values.yaml"}}
+
+	properties, err := getDeploymentProperties(args, "apps", deployedChart{name: "shop", version: "1.2.0"}, workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"helm.release.name":            "shop",
+		"helm.release.namespace":       "apps",
+		"helm.chart":                   "shop:1.2.0",
+		"helm.kube.context":            "prod-cluster",
+		"helm.values.prod.yaml.sha256": "9cf3a5f89adc05f90e87b284d40f8e39e1b763d9f9df307327e6f127ed492175",
+	}, properties)
+}
+
+func TestResolveLocalChartDirectory(t *testing.T) {
+	workingDir := t.TempDir()
+	chartDir := filepath.Join(workingDir, "shop")
+	require.NoError(t, os.MkdirAll(chartDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("apiVersion: v2\nname: shop\nversion: 1.2.0\n"), 0644))
+
+	// The chart path is relative to the working directory of the helm command.
+	chart, err := resolveDeployedChart(chartArgs{chart: "shop"}, nil, workingDir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, deployedChart{name: "shop", version: "1.2.0"}, chart)
+}
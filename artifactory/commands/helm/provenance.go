@@ -0,0 +1,184 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	artutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+const (
+	provenanceFileSuffix = ".prov"
+	provenanceMediaType  = "application/vnd.cncf.helm.chart.provenance.v1.prov"
+	// The environment variable used by 'helm package --sign' for the passphrase of the signing key.
+	helmKeyPassphraseEnv = "HELM_KEY_PASSPHRASE"
+)
+
+// defaultKeyring returns the keyring used by Helm when no keyring is provided.
+func defaultKeyring() string {
+	if gnupgHome := os.Getenv("GNUPGHOME"); gnupgHome != "" {
+		return filepath.Join(gnupgHome, "pubring.gpg")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gnupg", "pubring.gpg")
+}
+
+// signChart creates the provenance file of a packaged chart next to the chart archive, unless it already exists.
+func signChart(chartPath, keyName, keyring string) error {
+	provenancePath := chartPath + provenanceFileSuffix
+	exists, err := fileutils.IsFileExists(provenancePath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Info("Using the existing provenance file", provenancePath)
+		return nil
+	}
+	signatory, err := provenance.NewFromKeyring(keyring, keyName)
+	if err != nil {
+		return fmt.Errorf("failed to load the signing key '%s' from keyring '%s': %w", keyName, keyring, err)
+	}
+	if err = signatory.DecryptKey(func(_ string) ([]byte, error) {
+		passphrase, found := os.LookupEnv(helmKeyPassphraseEnv)
+		if !found {
+			return nil, fmt.Errorf("the signing key is encrypted. Provide its passphrase using the %s environment variable", helmKeyPassphraseEnv)
+		}
+		return []byte(passphrase), nil
+	}); err != nil {
+		return err
+	}
+	signature, err := signatory.ClearSign(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to sign chart %s: %w", chartPath, err)
+	}
+	log.Info("Signed chart", chartPath)
+	return errorutils.CheckError(os.WriteFile(provenancePath, []byte(signature), 0644))
+}
+
+// verifyDependenciesProvenance verifies the provenance of the dependencies of the given chart which were resolved from a chart repository.
+// The provenance file is taken from next to the chart archive, or from Artifactory if it's missing locally.
+func verifyDependenciesProvenance(chartPath, keyring string, serviceManager artifactory.ArtifactoryServicesManager) error {
+	archives, err := getRepositoryDependencyArchives(chartPath)
+	if err != nil {
+		return err
+	}
+	signatory, err := provenance.NewFromKeyring(keyring, "")
+	if err != nil {
+		return fmt.Errorf("failed to load keyring '%s': %w", keyring, err)
+	}
+	for _, archive := range archives {
+		if err = verifyChartProvenance(archive, signatory, serviceManager); err != nil {
+			return err
+		}
+	}
+	log.Info("Verified the provenance of", len(archives), "chart dependencies")
+	return nil
+}
+
+// getRepositoryDependencyArchives returns the archives of the dependencies resolved from a chart repository, as listed in the lock file of the chart.
+// Dependencies from the local file system (file://) are not expected to be signed, so they are skipped.
+func getRepositoryDependencyArchives(chartPath string) ([]string, error) {
+	chart, err := loader.LoadDir(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %w", chartPath, err)
+	}
+	if chart.Lock == nil {
+		log.Debug("Chart", chartPath, "has no lock file. Skipping the provenance verification of its dependencies.")
+		return nil, nil
+	}
+	var archives []string
+	for _, dependency := range chart.Lock.Dependencies {
+		if dependency.Repository == "" || strings.HasPrefix(dependency.Repository, "file://") {
+			continue
+		}
+		archives = append(archives, filepath.Join(chartPath, "charts", fmt.Sprintf("%s-%s.tgz", dependency.Name, dependency.Version)))
+	}
+	return archives, nil
+}
+
+func verifyChartProvenance(archive string, signatory *provenance.Signatory, serviceManager artifactory.ArtifactoryServicesManager) (err error) {
+	provenancePath := archive + provenanceFileSuffix
+	exists, err := fileutils.IsFileExists(provenancePath, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		var tempDirPath string
+		if tempDirPath, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+		}()
+		provenancePath = filepath.Join(tempDirPath, filepath.Base(archive)+provenanceFileSuffix)
+		if err = downloadChartProvenance(archive, provenancePath, serviceManager); err != nil {
+			return err
+		}
+	}
+	verification, err := signatory.Verify(archive, provenancePath)
+	if err != nil {
+		return fmt.Errorf("provenance verification of chart %s failed: %w", archive, err)
+	}
+	log.Debug("Verified chart", archive, "signed by", verification.SignedBy.PrimaryKey.KeyIdString())
+	return nil
+}
+
+// downloadChartProvenance locates the chart archive in Artifactory by its checksum and downloads its provenance file.
+// Classic charts have the provenance file next to the archive, while OCI charts have it as a layer of the chart manifest.
+func downloadChartProvenance(archive, provenancePath string, serviceManager artifactory.ArtifactoryServicesManager) error {
+	digest, err := provenance.DigestFile(archive)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	results, err := utils.ExecuteAqlQuery(serviceManager,
+		fmt.Sprintf(`items.find({"sha256": "%s", "type": "file"}).include("repo", "path", "name")`, digest))
+	if err != nil {
+		return err
+	}
+	for _, item := range results {
+		var remotePath string
+		if strings.HasPrefix(item.Name, "sha256__") {
+			remotePath, err = getOCIProvenanceLayerPath(item, serviceManager)
+		} else {
+			remotePath = item.GetItemRelativePath() + provenanceFileSuffix
+		}
+		if err == nil {
+			err = utils.DownloadRemoteFile(serviceManager, remotePath, provenancePath)
+		}
+		if err == nil {
+			return nil
+		}
+		log.Debug("Failed to get the provenance file of", item.GetItemRelativePath(), ":", err)
+	}
+	return fmt.Errorf("could not find the provenance file of chart %s in Artifactory", archive)
+}
+
+// getOCIProvenanceLayerPath returns the path of the provenance layer of the OCI chart whose content layer is the given item.
+func getOCIProvenanceLayerPath(contentLayer servicesUtils.ResultItem, serviceManager artifactory.ArtifactoryServicesManager) (string, error) {
+	var chartManifest manifest
+	manifestItem := servicesUtils.ResultItem{Repo: contentLayer.Repo, Path: contentLayer.Path, Name: "manifest.json"}
+	if err := artutils.RemoteUnmarshal(serviceManager, manifestItem.GetItemRelativePath(), &chartManifest); err != nil {
+		return "", err
+	}
+	for _, layerItem := range chartManifest.Layers {
+		if layerItem.MediaType == provenanceMediaType {
+			return path.Join(contentLayer.Repo, contentLayer.Path, "sha256__"+strings.TrimPrefix(layerItem.Digest, "sha256:")), nil
+		}
+	}
+	return "", fmt.Errorf("the chart manifest has no provenance layer")
+}
@@ -0,0 +1,118 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp" //nolint
+)
+
+func createTestKeyring(t *testing.T, dir string) string {
+	entity, err := openpgp.NewEntity("Chart Signer", "", "signer@example.com", nil)
+	require.NoError(t, err)
+	keyring := filepath.Join(dir, "secring.gpg")
+	file, err := os.Create(keyring)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(file, nil))
+	require.NoError(t, file.Close())
+	return keyring
+}
+
+func createTestChartArchive(t *testing.T, archivePath, chartName, description string) {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	chartYaml := []byte("apiVersion: v2\nname: " + chartName + "\nversion: 0.1.0\ndescription: " + description + "\n")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: chartName + "/Chart.yaml", Mode: 0644, Size: int64(len(chartYaml))}))
+	_, err = tarWriter.Write(chartYaml)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestSignAndVerifyChartProvenance(t *testing.T) {
+	tempDir := t.TempDir()
+	keyring := createTestKeyring(t, tempDir)
+	chartsDir := filepath.Join(tempDir, "app", "charts")
+	require.NoError(t, os.MkdirAll(chartsDir, 0755))
+	archive := filepath.Join(chartsDir, "lib-0.1.0.tgz")
+	createTestChartArchive(t, archive, "lib", "signed")
+	// Dependencies from the local file system aren't signed.
+	createTestChartArchive(t, filepath.Join(chartsDir, "common-0.1.0.tgz"), "common", "unsigned")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app", "Chart.yaml"), []byte(`apiVersion: v2
+name: app
+version: 1.0.0
+dependencies:
+  - name: lib
+    version: 0.1.0
+    repository: https://acme.jfrog.io/artifactory/api/helm/helm-remote
+  - name: common
+    version: 0.1.0
+    repository: file://../common
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app", "Chart.lock"), []byte(`dependencies:
+- name: lib
+  repository: https://acme.jfrog.io/artifactory/api/helm/helm-remote
+  version: 0.1.0
+- name: common
+  repository: file://../common
+  version: 0.1.0
+digest: sha256:0000000000000000000000000000000000000000000000000000000000000000
+generated: "2024-01-01T00:00:00Z"
+`), 0644))
+
+	require.NoError(t, signChart(archive, "Chart Signer", keyring))
+	assert.FileExists(t, archive+provenanceFileSuffix)
+	// An existing provenance file is kept.
+	require.NoError(t, signChart(archive, "Unknown Signer", keyring))
+
+	assert.NoError(t, verifyDependenciesProvenance(filepath.Join(tempDir, "app"), keyring, nil))
+
+	createTestChartArchive(t, archive, "lib", "tampered")
+	assert.ErrorContains(t, verifyDependenciesProvenance(filepath.Join(tempDir, "app"), keyring, nil), "provenance verification of chart")
+}
+
+func TestSignChartWithUnknownKey(t *testing.T) {
+	tempDir := t.TempDir()
+	keyring := createTestKeyring(t, tempDir)
+	archive := filepath.Join(tempDir, "lib-0.1.0.tgz")
+	createTestChartArchive(t, archive, "lib", "unsigned")
+	assert.Error(t, signChart(archive, "Unknown Signer", keyring))
+	assert.NoFileExists(t, archive+provenanceFileSuffix)
+}
+
+func TestExtractClassicRepositoryName(t *testing.T) {
+	tests := []struct {
+		repositoryURL string
+		expected      string
+	}{
+		{"https://acme.jfrog.io/artifactory/api/helm/helm-local", "helm-local"},
+		{"https://acme.jfrog.io/artifactory/api/helm/helm-local/", "helm-local"},
+		{"helm-local", "helm-local"},
+		{"https://acme.jfrog.io/artifactory/helm-local", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repositoryURL, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractClassicRepositoryName(tt.repositoryURL))
+		})
+	}
+}
+
+func TestIsDependencyUpdate(t *testing.T) {
+	assert.True(t, isDependencyUpdate([]string{"update", "./app"}))
+	assert.True(t, isDependencyUpdate([]string{"--skip-refresh", "up"}))
+	assert.False(t, isDependencyUpdate([]string{"build", "./app"}))
+	assert.False(t, isDependencyUpdate([]string{"list"}))
+}
+
+func TestGetDependencyChartPath(t *testing.T) {
+	assert.Equal(t, "./app", getDependencyChartPath([]string{"update", "./app", "--skip-refresh"}, "/work"))
+	assert.Equal(t, "/work", getDependencyChartPath([]string{"build"}, "/work"))
+}
@@ -29,12 +29,18 @@ func handlePushCommand(buildInfo *entities.BuildInfo, helmArgs []string, service
 	}
 	appendModuleAndBuildAgentIfAbsent(buildInfo, chartName, chartVersion)
 	log.Debug("Processing push command for chart: ", filePath, " to registry: ", registryURL)
-	repoName := extractRepositoryNameFromURL(registryURL)
 	timestamp := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
 	buildProps := fmt.Sprintf("build.name=%s;build.number=%s;build.timestamp=%s", buildName, buildNumber, timestamp)
 	if project != "" {
 		buildProps += fmt.Sprintf(";build.project=%s", project)
 	}
+	if !isOCIRepository(registryURL) {
+		if err = handleClassicPushCommand(buildInfo, filePath, registryURL, chartName, chartVersion, buildProps, serviceManager); err != nil {
+			return err
+		}
+		return saveBuildInfo(buildInfo, buildName, buildNumber, project)
+	}
+	repoName := extractRepositoryNameFromURL(registryURL)
 	resultMap, err := searchPushedArtifacts(serviceManager, repoName, chartName, chartVersion, buildProps)
 	if err != nil {
 		return fmt.Errorf("failed to search oci layers for %s : %s: %w", chartName, chartVersion, err)
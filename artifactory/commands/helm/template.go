@@ -0,0 +1,260 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+const (
+	dockerDependencyType     = "docker"
+	imageManifestFileName    = "manifest.json"
+	imageFatManifestFileName = "list.manifest.json"
+)
+
+// Helm flags which take a value as the next argument, when not provided in the --flag=value form.
+var helmFlagsWithValue = map[string]bool{
+	"-f": true, "--values": true, "-n": true, "--namespace": true, "--set": true, "--set-string": true, "--set-file": true,
+	"--set-json": true, "--set-literal": true, "--version": true, "--repo": true, "--kube-version": true, "-a": true,
+	"--api-versions": true, "--output-dir": true, "-s": true, "--show-only": true, "--post-renderer": true,
+	"--post-renderer-args": true, "--name-template": true, "--description": true, "--timeout": true, "--username": true,
+	"--password": true, "--ca-file": true, "--cert-file": true, "--key-file": true, "--keyring": true, "--kubeconfig": true,
+	"--kube-context": true, "--kube-apiserver": true, "--kube-token": true, "--registry-config": true,
+	"--repository-cache": true, "--repository-config": true, "-l": true, "--labels": true, "-o": true, "--output": true,
+	"--history-max": true, "--kube-as-user": true, "--kube-as-group": true, "--kube-ca-file": true, "--kube-tls-server-name": true,
+	"--burst-limit": true, "--qps": true,
+}
+
+// chartArgs holds the arguments of commands such as 'helm template [NAME] [CHART]' and 'helm upgrade [RELEASE] [CHART]'.
+type chartArgs struct {
+	releaseName string
+	chart       string
+	namespace   string
+	kubeContext string
+	version     string
+	repo        string
+	outputDir   string
+	valuesFiles []string
+}
+
+// parseChartArgs parses the arguments of commands which take a release name followed by a chart.
+// The chart is the first positional argument with --generate-name, or when it is the only positional argument, and the second otherwise.
+func parseChartArgs(helmArgs []string) (args chartArgs) {
+	var positionalArgs []string
+	generateName := false
+	for i := 0; i < len(helmArgs); i++ {
+		arg := helmArgs[i]
+		if !strings.HasPrefix(arg, "-") {
+			positionalArgs = append(positionalArgs, arg)
+			continue
+		}
+		flag, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue && helmFlagsWithValue[flag] && i+1 < len(helmArgs) {
+			i++
+			value = helmArgs[i]
+		}
+		switch flag {
+		case "-g", "--generate-name":
+			generateName = value == "" || value == "true"
+		case "-f", "--values":
+			for _, valuesFile := range strings.Split(value, ",") {
+				if valuesFile != "" {
+					args.valuesFiles = append(args.valuesFiles, valuesFile)
+				}
+			}
+		case "-n", "--namespace":
+			args.namespace = value
+		case "--kube-context":
+			args.kubeContext = value
+		case "--version":
+			args.version = value
+		case "--repo":
+			args.repo = value
+		case "--output-dir":
+			args.outputDir = value
+		}
+	}
+	switch {
+	case len(positionalArgs) == 0:
+	case generateName || len(positionalArgs) == 1:
+		args.chart = positionalArgs[0]
+	default:
+		args.releaseName = positionalArgs[0]
+		args.chart = positionalArgs[1]
+	}
+	return
+}
+
+// handleTemplateCommand records the images referenced by the rendered templates as dependencies.
+// renderedManifests holds the output of the executed 'helm template' command. If empty, the templates are rendered again with the same arguments.
+func handleTemplateCommand(buildInfo *entities.BuildInfo, helmArgs []string, renderedManifests, workingDir string, serviceManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, project string) error {
+	args := parseChartArgs(helmArgs)
+	if args.chart == "" {
+		return fmt.Errorf("invalid helm chart path")
+	}
+	chartName, chartVersion, err := getTemplateChartDetails(args, helmArgs, workingDir)
+	if err != nil {
+		return fmt.Errorf("could not extract chart name/version from %s: %w", args.chart, err)
+	}
+	manifests, err := getRenderedManifests(args, helmArgs, renderedManifests, workingDir)
+	if err != nil {
+		return err
+	}
+	module := entities.Module{
+		Id:           fmt.Sprintf("%s:%s", chartName, chartVersion),
+		Type:         "helm",
+		Dependencies: getImagesDependencies(ExtractImagesFromManifests(manifests), serviceManager),
+	}
+	appendModuleAndBuildAgentIfAbsent(buildInfo, chartName, chartVersion)
+	appendModuleInExistingBuildInfo(buildInfo, &module)
+	removeDuplicateDependencies(buildInfo)
+	return saveBuildInfo(buildInfo, buildName, buildNumber, project)
+}
+
+// getRenderedManifests returns the manifests rendered by 'helm template'. With --output-dir, helm writes the manifests to files instead of the standard output.
+func getRenderedManifests(args chartArgs, helmArgs []string, renderedManifests, workingDir string) (string, error) {
+	if args.outputDir != "" {
+		return readRenderedManifests(resolvePath(args.outputDir, workingDir))
+	}
+	if renderedManifests != "" {
+		return renderedManifests, nil
+	}
+	return renderTemplates(helmArgs, workingDir)
+}
+
+func readRenderedManifests(outputDir string) (string, error) {
+	var manifests strings.Builder
+	err := filepath.WalkDir(outputDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		manifests.WriteString("---\n")
+		manifests.Write(content)
+		manifests.WriteString("\n")
+		return nil
+	})
+	return manifests.String(), errorutils.CheckError(err)
+}
+
+// getTemplateChartDetails returns the name and version of the rendered chart.
+// Charts which aren't available locally, such as 'repo/chart' and 'oci://' references, are resolved by 'helm show chart'.
+func getTemplateChartDetails(args chartArgs, helmArgs []string, workingDir string) (string, string, error) {
+	if args.repo == "" && !registry.IsOCI(args.chart) {
+		chartPath := resolvePath(args.chart, workingDir)
+		if _, err := os.Stat(chartPath); err == nil {
+			return getChartDetails(chartPath)
+		}
+	}
+	showArgs := append([]string{"show", "chart", args.chart}, getChartLocationFlags(helmArgs)...)
+	log.Debug("Resolving the chart details: helm", strings.Join(showArgs, " "))
+	helmCmd := exec.Command("helm", showArgs...)
+	helmCmd.Dir = workingDir
+	output, err := helmCmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", "", errorutils.CheckErrorf("failed running 'helm show chart': %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", "", errorutils.CheckError(err)
+	}
+	var metadata struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	}
+	if err = yaml.Unmarshal(output, &metadata); err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	return metadata.Name, metadata.Version, nil
+}
+
+// Flags of 'helm template' which take part in locating the chart, and are also accepted by 'helm show chart'.
+var chartLocationFlags = map[string]bool{
+	"--version": true, "--repo": true, "--devel": true, "--username": true, "--password": true, "--ca-file": true, "--cert-file": true,
+	"--key-file": true, "--insecure-skip-tls-verify": true, "--plain-http": true, "--pass-credentials": true, "--keyring": true,
+	"--verify": true, "--registry-config": true, "--repository-config": true, "--repository-cache": true,
+}
+
+// getChartLocationFlags returns the flags of the given arguments which locate the chart, with their values.
+func getChartLocationFlags(helmArgs []string) []string {
+	var flags []string
+	for i := 0; i < len(helmArgs); i++ {
+		flag, _, hasValue := strings.Cut(helmArgs[i], "=")
+		if !strings.HasPrefix(flag, "-") {
+			continue
+		}
+		takesNextArg := !hasValue && helmFlagsWithValue[flag] && i+1 < len(helmArgs)
+		if chartLocationFlags[flag] {
+			flags = append(flags, helmArgs[i])
+			if takesNextArg {
+				flags = append(flags, helmArgs[i+1])
+			}
+		}
+		if takesNextArg {
+			i++
+		}
+	}
+	return flags
+}
+
+func resolvePath(path, workingDir string) string {
+	if filepath.IsAbs(path) || workingDir == "" {
+		return path
+	}
+	return filepath.Join(workingDir, path)
+}
+
+// getImagesDependencies resolves the images in Artifactory and returns their manifests and layers as dependencies.
+func getImagesDependencies(images []string, serviceManager artifactory.ArtifactoryServicesManager) []entities.Dependency {
+	var dependencies []entities.Dependency
+	for _, image := range images {
+		files, err := ocicontainer.FetchImageFiles(image, serviceManager)
+		if err != nil {
+			// Charts commonly reference public images which are not stored in Artifactory.
+			log.Warn(fmt.Sprintf("Skipping image %s, which could not be found in Artifactory: %s", image, err.Error()))
+			continue
+		}
+		log.Debug("Found ", len(files), " files for image ", image)
+		dependencies = append(dependencies, imageFilesToDependencies(image, files)...)
+	}
+	return dependencies
+}
+
+// imageFilesToDependencies converts the files of an image to dependencies.
+// The lead manifest of the image is identified by the image reference, and its checksum is the image digest.
+func imageFilesToDependencies(image string, files []servicesUtils.ResultItem) []entities.Dependency {
+	leadManifestName := imageManifestFileName
+	for _, file := range files {
+		if file.Name == imageFatManifestFileName {
+			leadManifestName = imageFatManifestFileName
+			break
+		}
+	}
+	dependencies := make([]entities.Dependency, 0, len(files))
+	for _, file := range files {
+		dependency := file.ToDependency()
+		dependency.Type = dockerDependencyType
+		dependency.Repository = file.Repo
+		if file.Name == leadManifestName {
+			dependency.Id = image
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies
+}
@@ -0,0 +1,121 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChartArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected chartArgs
+	}{
+		{
+			name:     "Chart only",
+			args:     []string{"./mychart"},
+			expected: chartArgs{chart: "./mychart"},
+		},
+		{
+			name:     "Release name, chart and values files",
+			args:     []string{"my-release", "./mychart", "-f", "values.yaml", "--values=prod.yaml,eu.yaml"},
+			expected: chartArgs{releaseName: "my-release", chart: "./mychart", valuesFiles: []string{"values.yaml", "prod.yaml", "eu.yaml"}},
+		},
+		{
+			name: "Flags with values before the chart",
+			args: []string{"--namespace", "apps", "--set", "replicas=2", "my-release", "--values", "values.yaml", "oci://acme.jfrog.io/helm-local/mychart",
+				"--version", "1.2.0", "--kube-context=prod", "--install"},
+			expected: chartArgs{releaseName: "my-release", chart: "oci://acme.jfrog.io/helm-local/mychart", namespace: "apps", kubeContext: "prod",
+				version: "1.2.0", valuesFiles: []string{"values.yaml"}},
+		},
+		{
+			name:     "Chart from a repository URL",
+			args:     []string{"my-release", "mychart", "--repo", "https://acme.jfrog.io/artifactory/api/helm/helm-remote"},
+			expected: chartArgs{releaseName: "my-release", chart: "mychart", repo: "https://acme.jfrog.io/artifactory/api/helm/helm-remote"},
+		},
+		{
+			name:     "Upgrade with install and a flag value following the chart",
+			args:     []string{"--install", "my-release", "./mychart", "--history-max", "5"},
+			expected: chartArgs{releaseName: "my-release", chart: "./mychart"},
+		},
+		{
+			name:     "Generated release name",
+			args:     []string{"--generate-name", "./mychart", "--qps", "20"},
+			expected: chartArgs{chart: "./mychart"},
+		},
+		{
+			name: "No chart",
+			args: []string{"--generate-name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseChartArgs(tt.args))
+		})
+	}
+}
+
+func TestImageFilesToDependencies(t *testing.T) {
+	files := []servicesUtils.ResultItem{
+		{Repo: "docker-local", Path: "app/1.0.0", Name: "list.manifest.json", Sha256: "list-sha"},
+		{Repo: "docker-local", Path: "app/sha256:amd64", Name: "manifest.json", Sha256: "amd64-sha"},
+		{Repo: "docker-local", Path: "app/sha256:amd64", Name: "sha256__layer", Sha256: "layer-sha"},
+	}
+	dependencies := imageFilesToDependencies("acme.jfrog.io/docker-local/app:1.0.0", files)
+	assert.Len(t, dependencies, 3)
+	assert.Equal(t, "acme.jfrog.io/docker-local/app:1.0.0", dependencies[0].Id)
+	assert.Equal(t, "list-sha", dependencies[0].Sha256)
+	assert.Equal(t, "manifest.json", dependencies[1].Id)
+	assert.Equal(t, "sha256__layer", dependencies[2].Id)
+	for _, dependency := range dependencies {
+		assert.Equal(t, "docker", dependency.Type)
+		assert.Equal(t, "docker-local", dependency.Repository)
+	}
+
+	dependencies = imageFilesToDependencies("acme.jfrog.io/docker-local/app:1.0.0", files[1:])
+	assert.Equal(t, "acme.jfrog.io/docker-local/app:1.0.0", dependencies[0].Id)
+}
+
+func TestGetChartLocationFlags(t *testing.T) {
+	args := []string{"my-release", "mychart", "--set", "image.tag=1.0.0", "--repo", "https://acme.jfrog.io/artifactory/api/helm/helm-remote",
+		"--namespace=apps", "--version=1.2.0", "--devel", "-f", "values.yaml", "--username", "admin"}
+	assert.Equal(t, []string{"--repo", "https://acme.jfrog.io/artifactory/api/helm/helm-remote", "--version=1.2.0", "--devel", "--username", "admin"},
+		getChartLocationFlags(args))
+	assert.Empty(t, getChartLocationFlags([]string{"./mychart", "--set-string", "--version=1"}))
+}
+
+func TestGetRenderedManifests(t *testing.T) {
+	workingDir := t.TempDir()
+	templatesDir := filepath.Join(workingDir, "out", "app", "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "deployment.yaml"), []byte("image: acme.jfrog.io/docker-local/app:1.0.0"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "NOTES.txt"), []byte("image: ignored"), 0644))
+
+	// The captured output of the executed command is used as is.
+	manifests, err := getRenderedManifests(parseChartArgs([]string{"./app"}), nil, "image: captured", workingDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "image: captured", manifests)
+
+	// With --output-dir, the manifests are read from the output directory.
+	helmArgs := []string{"./app", "--output-dir", "out"}
+	manifests, err = getRenderedManifests(parseChartArgs(helmArgs), helmArgs, "wrote out/app/templates/deployment.yaml", workingDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme.jfrog.io/docker-local/app:1.0.0"}, ExtractImagesFromManifests(manifests))
+}
+
+func TestGetTemplateChartDetailsOfLocalChart(t *testing.T) {
+	workingDir := t.TempDir()
+	chartDir := filepath.Join(workingDir, "app")
+	require.NoError(t, os.MkdirAll(chartDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("apiVersion: v2\nname: app\nversion: 1.2.0\n"), 0644))
+
+	name, version, err := getTemplateChartDetails(parseChartArgs([]string{"my-release", "app", "--set", "replicas=2"}), nil, workingDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", name)
+	assert.Equal(t, "1.2.0", version)
+}
@@ -16,6 +16,9 @@ func needBuildInfo(cmdName string) bool {
 		"dependency": true,
 		"package":    true,
 		"push":       true,
+		"template":   true,
+		"install":    true,
+		"upgrade":    true,
 	}
 	return buildInfoNeededCommands[cmdName]
 }
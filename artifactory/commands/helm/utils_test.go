@@ -29,8 +29,23 @@ func TestNeedBuildInfo(t *testing.T) {
 			expected: true,
 		},
 		{
-			name:     "Other command does not need build info",
+			name:     "Template command needs build info",
+			cmdName:  "template",
+			expected: true,
+		},
+		{
+			name:     "Install command needs build info",
 			cmdName:  "install",
+			expected: true,
+		},
+		{
+			name:     "Upgrade command needs build info",
+			cmdName:  "upgrade",
+			expected: true,
+		},
+		{
+			name:     "Other command does not need build info",
+			cmdName:  "lint",
 			expected: false,
 		},
 		{
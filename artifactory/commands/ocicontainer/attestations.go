@@ -0,0 +1,166 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The build-info module property linking an attestation manifest to the image manifest it describes.
+	attestationReferenceProperty  = "docker.attestation.reference.digest"
+	attestationPlatformProperty   = "docker.attestation.platform"
+	inTotoPredicateTypeAnnotation = "in-toto.io/predicate-type"
+	spdxPredicateTypePrefix       = "https://spdx.dev/Document"
+	slsaPredicateTypePrefix       = "https://slsa.dev/provenance/"
+	sbomPredicateFileName         = "sbom.spdx.json"
+	provenancePredicateFileName   = "provenance.slsa.json"
+)
+
+// To unmarshal the manifest.json file of an attestation manifest.
+type attestationManifest struct {
+	Layers []attestationLayer `json:"layers,omitempty"`
+}
+
+type attestationLayer struct {
+	Digest      string            `json:"digest,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// To unmarshal the in-toto statement stored in an attestation layer.
+type inTotoStatement struct {
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+func isAttestationManifest(manifest ManifestDetails) bool {
+	return manifest.Annotations.ReferenceType == attestationManifestRefType
+}
+
+// Return the platform of the image manifest described by the attestation manifest.
+func getAttestedPlatform(attestation ManifestDetails, fatManifest *FatManifest) (Platform, bool) {
+	for _, manifest := range fatManifest.Manifests {
+		if manifest.Digest == attestation.Annotations.ReferenceDigest && !isAttestationManifest(manifest) {
+			return manifest.Platform, true
+		}
+	}
+	return Platform{}, false
+}
+
+// Return the name of the file to which the predicate is deployed, or an empty string for unsupported predicate types.
+func getPredicateFileName(predicateType string) string {
+	switch {
+	case strings.HasPrefix(predicateType, spdxPredicateTypePrefix):
+		return sbomPredicateFileName
+	case strings.HasPrefix(predicateType, slsaPredicateTypePrefix):
+		return provenancePredicateFileName
+	}
+	return ""
+}
+
+// Extract the SBOM and provenance predicates from the in-toto statements of the attestation manifest,
+// and deploy them as readable JSON files next to the attestation manifest.
+// Return the deployed files as build-info artifacts.
+func (builder *buildInfoBuilder) deployAttestationPredicates(attestationFiles []*utils.ResultItem) (artifacts []buildinfo.Artifact, err error) {
+	filesByName := make(map[string]*utils.ResultItem, len(attestationFiles))
+	for _, file := range attestationFiles {
+		filesByName[file.Name] = file
+	}
+	manifestItem, ok := filesByName[ManifestJsonFile]
+	if !ok {
+		return nil, errorutils.CheckErrorf("the attestation manifest was not found in Artifactory")
+	}
+	var manifest attestationManifest
+	if err = downloadLayer(*manifestItem, &manifest, builder.serviceManager, builder.repositoryDetails.key); err != nil {
+		return nil, err
+	}
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	for _, attestation := range manifest.Layers {
+		fileName := getPredicateFileName(attestation.Annotations[inTotoPredicateTypeAnnotation])
+		if fileName == "" {
+			continue
+		}
+		layerItem, ok := filesByName[digestToLayer(attestation.Digest)]
+		if !ok {
+			log.Debug("Attestation layer " + attestation.Digest + " was not found in Artifactory")
+			continue
+		}
+		var statement inTotoStatement
+		if err = downloadLayer(*layerItem, &statement, builder.serviceManager, builder.repositoryDetails.key); err != nil {
+			return nil, err
+		}
+		localPath := filepath.Join(tempDirPath, fileName)
+		if err = writePredicate(localPath, statement.Predicate); err != nil {
+			return nil, err
+		}
+		predicateItem := utils.ResultItem{Repo: builder.repositoryDetails.key, Path: manifestItem.Path, Name: fileName}
+		artifact, err := deployPredicate(localPath, predicateItem, builder.serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Deployed the " + statement.PredicateType + " predicate to " + predicateItem.GetItemRelativePath())
+		artifacts = append(artifacts, artifact)
+		builder.imageLayers = append(builder.imageLayers, predicateItem)
+	}
+	return artifacts, nil
+}
+
+func writePredicate(localPath string, predicate json.RawMessage) error {
+	content, err := json.MarshalIndent(predicate, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(localPath, content, 0644))
+}
+
+func deployPredicate(localPath string, target utils.ResultItem, serviceManager artifactory.ArtifactoryServicesManager) (buildinfo.Artifact, error) {
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return buildinfo.Artifact{}, err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = localPath
+	uploadParams.Target = target.GetItemRelativePath()
+	uploadParams.Flat = true
+	uploaded, failed, err := serviceManager.UploadFiles(artifactory.UploadServiceOptions{FailFast: true}, uploadParams)
+	if err != nil {
+		return buildinfo.Artifact{}, err
+	}
+	if uploaded != 1 || failed > 0 {
+		return buildinfo.Artifact{}, errorutils.CheckErrorf("failed to deploy %s", target.GetItemRelativePath())
+	}
+	return buildinfo.Artifact{
+		Name:                   target.Name,
+		Type:                   "json",
+		Checksum:               details.Checksum,
+		Path:                   path.Join(target.Path, target.Name),
+		OriginalDeploymentRepo: target.Repo,
+	}, nil
+}
+
+// Return the module properties of an attestation manifest, linking it to the attested image manifest.
+func getAttestationModuleProperties(attestation ManifestDetails, fatManifest *FatManifest) map[string]string {
+	properties := map[string]string{attestationReferenceProperty: attestation.Annotations.ReferenceDigest}
+	if platform, found := getAttestedPlatform(attestation, fatManifest); found {
+		properties[attestationPlatformProperty] = fmt.Sprintf("%s/%s", platform.Os, platform.Architecture)
+	}
+	return properties
+}
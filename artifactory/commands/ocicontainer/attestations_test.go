@@ -0,0 +1,60 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestFatManifest() *FatManifest {
+	attestation := ManifestDetails{Digest: "sha256:attestation"}
+	attestation.Annotations.ReferenceType = attestationManifestRefType
+	attestation.Annotations.ReferenceDigest = "sha256:arm64"
+	orphanAttestation := ManifestDetails{Digest: "sha256:orphan"}
+	orphanAttestation.Annotations.ReferenceType = attestationManifestRefType
+	orphanAttestation.Annotations.ReferenceDigest = "sha256:missing"
+	amd64 := ManifestDetails{Digest: "sha256:amd64"}
+	amd64.Platform.Os = "linux"
+	amd64.Platform.Architecture = "amd64"
+	arm64 := ManifestDetails{Digest: "sha256:arm64"}
+	arm64.Platform.Os = "linux"
+	arm64.Platform.Architecture = "arm64"
+	return &FatManifest{Manifests: []ManifestDetails{amd64, arm64, attestation, orphanAttestation}}
+}
+
+func TestGetModuleIdByAttestationManifest(t *testing.T) {
+	fatManifest := createTestFatManifest()
+	assert.Equal(t, "linux/amd64/app:1.0", getModuleIdByManifest(fatManifest.Manifests[0], fatManifest, "app:1.0"))
+	assert.Equal(t, "attestations/linux/arm64/app:1.0", getModuleIdByManifest(fatManifest.Manifests[2], fatManifest, "app:1.0"))
+	assert.Equal(t, "attestations/app:1.0", getModuleIdByManifest(fatManifest.Manifests[3], fatManifest, "app:1.0"))
+}
+
+func TestGetAttestationModuleProperties(t *testing.T) {
+	fatManifest := createTestFatManifest()
+	assert.Equal(t, map[string]string{
+		attestationReferenceProperty: "sha256:arm64",
+		attestationPlatformProperty:  "linux/arm64",
+	}, getAttestationModuleProperties(fatManifest.Manifests[2], fatManifest))
+	assert.Equal(t, map[string]string{attestationReferenceProperty: "sha256:missing"},
+		getAttestationModuleProperties(fatManifest.Manifests[3], fatManifest))
+}
+
+func TestGetPredicateFileName(t *testing.T) {
+	assert.Equal(t, sbomPredicateFileName, getPredicateFileName("https://spdx.dev/Document"))
+	assert.Equal(t, provenancePredicateFileName, getPredicateFileName("https://slsa.dev/provenance/v0.2"))
+	assert.Empty(t, getPredicateFileName("https://cyclonedx.org/bom"))
+}
+
+func TestWritePredicate(t *testing.T) {
+	var statement inTotoStatement
+	require.NoError(t, json.Unmarshal([]byte(`{"predicateType":"https://spdx.dev/Document","predicate":{"spdxVersion":"SPDX-2.3"}}`), &statement))
+	localPath := filepath.Join(t.TempDir(), sbomPredicateFileName)
+	require.NoError(t, writePredicate(localPath, statement.Predicate))
+	content, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"spdxVersion\": \"SPDX-2.3\"\n}", string(content))
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"path"
 	"strings"
 
@@ -53,6 +52,12 @@ type buildInfoBuilder struct {
 	// If true, don't set layers props in Artifactory.
 	skipTaggingLayers bool
 	imageLayers       []utils.ResultItem
+	// If true, deploy the SBOM and provenance predicates of attestation manifests as JSON files.
+	extractAttestations bool
+	// If true, record the cosign and Notation signatures of the image manifests as artifacts.
+	includeSignatures bool
+	// The image Artifactory served for a verified pull.
+	resolvedImage *ResolvedImage
 }
 
 type RepositoryDetails struct {
@@ -305,40 +310,17 @@ func getManifestDependency(searchResults *utils.ResultItem) (dependency buildinf
 	}
 }
 
-// Read the file which contains the following format: 'IMAGE-TAG-IN-ARTIFACTORY'@sha256'SHA256-OF-THE-IMAGE-MANIFEST'.
+// Read the file which contains the following format: 'IMAGE-TAG-IN-ARTIFACTORY'@sha256'SHA256-OF-THE-IMAGE-MANIFEST',
+// or the buildx metadata file. See ParseImageFile for all the supported formats.
 func GetImageTagWithDigest(filePath string) (*Image, string, error) {
-	var buildxMetaData buildxMetaData
-	data, err := os.ReadFile(filePath)
-	if errorutils.CheckError(err) != nil {
-		log.Debug("os.ReadFile failed with '%s'\n", err)
-		return nil, "", err
-	}
-	err = json.Unmarshal(data, &buildxMetaData)
+	images, err := ParseImageFile(filePath, "")
 	if err != nil {
-		log.Debug("failed unmarshalling buildxMetaData file with error: " + err.Error() + ". falling back to Kanico/OC file format...")
-	}
-	// Try to read buildx metadata file.
-	if buildxMetaData.ImageName != "" && buildxMetaData.ImageSha256 != "" {
-		return NewImage(buildxMetaData.ImageName), buildxMetaData.ImageSha256, nil
+		return nil, "", err
 	}
-	// Try read Kaniko/oc file.
-	splittedData := strings.Split(string(data), `@`)
-	if len(splittedData) != 2 {
-		return nil, "", errorutils.CheckErrorf(`unexpected file format "%s". The file should include one line in the following format: image-tag@sha256`, filePath)
+	if len(images) > 1 {
+		log.Warn("File " + filePath + " describes multiple images. Using image " + images[0].Image.Name())
 	}
-	tag, sha256 := splittedData[0], strings.Trim(splittedData[1], "\n")
-	if tag == "" || sha256 == "" {
-		err = errorutils.CheckErrorf(`missing image-tag/sha256 in file: "%s"`, filePath)
-		if err != nil {
-			return nil, "", err
-		}
-	}
-	return NewImage(tag), sha256, nil
-}
-
-type buildxMetaData struct {
-	ImageName   string `json:"image.name"`
-	ImageSha256 string `json:"containerimage.digest"`
+	return images[0].Image, images[0].ManifestSha256, nil
 }
 
 // Search for manifest digest in fat manifest, which contains specific platforms.
@@ -467,11 +449,15 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 	switch commandType {
 	case Pull:
 		dependencies = builder.createPullBuildProperties(manifest, candidateLayers)
+		if builder.resolvedImage != nil {
+			imageProperties["docker.image.digest"] = builder.resolvedImage.Digest
+		}
 	case Push:
 		artifacts, dependencies, builder.imageLayers, err = builder.createPushBuildProperties(manifest, candidateLayers)
 		if err != nil {
 			return nil, err
 		}
+		artifacts = append(artifacts, builder.collectSignatures(candidateLayers[ManifestJsonFile])...)
 		if !builder.skipTaggingLayers {
 			if err := setBuildProperties(builder.buildName, builder.buildNumber, builder.project, builder.imageLayers, builder.serviceManager, builder.repositoryDetails.key, &builder.repositoryDetails); err != nil {
 				return nil, err
@@ -507,7 +493,7 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 		Id:         baseModuleId,
 		Type:       buildinfo.Docker,
 		Properties: imageProperties,
-		Artifacts:  []buildinfo.Artifact{getFatManifestArtifact(searchResultFatManifest)},
+		Artifacts:  append([]buildinfo.Artifact{getFatManifestArtifact(searchResultFatManifest)}, builder.collectSignatures(searchResultFatManifest)...),
 	}}}
 	imageLongNameWithoutRepo, err := builder.image.GetImageLongNameWithoutRepoWithTag()
 	if err != nil {
@@ -517,27 +503,48 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 	for _, manifest := range fatManifest.Manifests {
 		image := candidateImages[manifest.Digest]
 		var artifacts []buildinfo.Artifact
+		var manifestItem *utils.ResultItem
 		for _, layer := range image {
 			builder.imageLayers = append(builder.imageLayers, *layer)
 			if layer.Name == ManifestJsonFile {
+				manifestItem = layer
 				artifacts = append(artifacts, getManifestArtifact(layer))
 			} else {
 				artifacts = append(artifacts, layer.ToArtifact())
 			}
 		}
-		buildInfo.Modules = append(buildInfo.Modules, buildinfo.Module{
-			Id:        getModuleIdByManifest(manifest, baseModuleId),
+		if !isAttestationManifest(manifest) {
+			// Images may be signed per platform, in addition to the fat-manifest signature.
+			artifacts = append(artifacts, builder.collectSignatures(manifestItem)...)
+		}
+		module := buildinfo.Module{
+			Id:        getModuleIdByManifest(manifest, fatManifest, baseModuleId),
 			Type:      buildinfo.Docker,
 			Artifacts: artifacts,
 			Parent:    imageLongNameWithoutRepo,
-		})
+		}
+		if isAttestationManifest(manifest) {
+			module.Properties = getAttestationModuleProperties(manifest, fatManifest)
+			if builder.extractAttestations {
+				predicateArtifacts, err := builder.deployAttestationPredicates(image)
+				if err != nil {
+					return nil, err
+				}
+				module.Artifacts = append(module.Artifacts, predicateArtifacts...)
+			}
+		}
+		buildInfo.Modules = append(buildInfo.Modules, module)
 	}
 	return buildInfo, setBuildProperties(builder.buildName, builder.buildNumber, builder.project, builder.imageLayers, builder.serviceManager, builder.repositoryDetails.key, &builder.repositoryDetails)
 }
 
 // Construct the manifest's module ID by its type (attestation) or its platform.
-func getModuleIdByManifest(manifest ManifestDetails, baseModuleId string) string {
-	if manifest.Annotations.ReferenceType == attestationManifestRefType {
+// Attestation manifests are identified by the platform of the image manifest they describe.
+func getModuleIdByManifest(manifest ManifestDetails, fatManifest *FatManifest, baseModuleId string) string {
+	if isAttestationManifest(manifest) {
+		if platform, found := getAttestedPlatform(manifest, fatManifest); found {
+			return path.Join(AttestationsModuleIdPrefix, platform.Os, platform.Architecture, baseModuleId)
+		}
 		return path.Join(AttestationsModuleIdPrefix, baseModuleId)
 	}
 	if manifest.Platform.Os != unknownPlatformPlaceholder && manifest.Platform.Architecture != unknownPlatformPlaceholder {
@@ -595,6 +602,12 @@ func (builder *buildInfoBuilder) createPullBuildProperties(imageManifest *manife
 		log.Debug(err.Error())
 		return nil
 	}
+	// Record the manifest by the digest the pull resolved to, rather than by the mutable tag.
+	if builder.resolvedImage != nil {
+		if imageName, err := builder.image.GetImageLongNameWithoutRepoAndTag(); err == nil {
+			configDependencies[0].Id = imageName + "@" + builder.resolvedImage.ManifestDigest
+		}
+	}
 
 	return append(configDependencies, layerDependencies...)
 }
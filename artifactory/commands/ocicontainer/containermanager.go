@@ -2,6 +2,7 @@ package ocicontainer
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
@@ -51,6 +52,12 @@ type ContainerManager interface {
 	GetContainerManagerType() ContainerManagerType
 }
 
+// DiffIdsProvider is implemented by container managers which can list the layers of local images.
+type DiffIdsProvider interface {
+	// The diff IDs of the image layers, which are the digests of the uncompressed layers.
+	DiffIds(image *Image) ([]string, error)
+}
+
 type containerManager struct {
 	Type ContainerManagerType
 }
@@ -107,6 +114,39 @@ func (containerManager *containerManager) OsCompatibility(image *Image) (string,
 	return content[:firstSeparator], content[firstSeparator+1:], err
 }
 
+// Get the diff IDs of the local image layers
+func (containerManager *containerManager) DiffIds(image *Image) ([]string, error) {
+	if containerManager.GetContainerManagerType() == DockerClient {
+		ref, err := name.ParseReference(image.Name())
+		if err != nil {
+			return nil, err
+		}
+		localImage, err := daemon.Image(ref)
+		if err != nil {
+			return nil, err
+		}
+		configFile, err := localImage.ConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		diffIds := make([]string, 0, len(configFile.RootFS.DiffIDs))
+		for _, diffId := range configFile.RootFS.DiffIDs {
+			diffIds = append(diffIds, diffId.String())
+		}
+		return diffIds, nil
+	}
+	cmd := &getImageDiffIdsCmd{image: image, containerManager: containerManager.GetContainerManagerType()}
+	content, err := cmd.RunCmd()
+	if err != nil {
+		return nil, err
+	}
+	var diffIds []string
+	if err = json.Unmarshal([]byte(strings.TrimSpace(content)), &diffIds); err != nil {
+		return nil, errorutils.CheckErrorf("couldn't read the layers of image %s: %s", image.name, err.Error())
+	}
+	return diffIds, nil
+}
+
 func (containerManager *containerManager) GetContainerManagerType() ContainerManagerType {
 	return containerManager.Type
 }
@@ -180,6 +220,31 @@ func (getImageSystemCompatibilityCmd *getImageSystemCompatibilityCmd) RunCmd() (
 	return buffer.String(), err
 }
 
+// Get image layers diff IDs
+type getImageDiffIdsCmd struct {
+	image            *Image
+	containerManager ContainerManagerType
+}
+
+func (getImageDiffIds *getImageDiffIdsCmd) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, "image")
+	cmd = append(cmd, "inspect")
+	cmd = append(cmd, getImageDiffIds.image.name)
+	cmd = append(cmd, "--format")
+	cmd = append(cmd, "{{json .RootFS.Layers}}")
+	return exec.Command(getImageDiffIds.containerManager.String(), cmd...)
+}
+
+func (getImageDiffIds *getImageDiffIdsCmd) RunCmd() (string, error) {
+	command := getImageDiffIds.GetCmd()
+	buffer := bytes.NewBuffer([]byte{})
+	command.Stderr = os.Stderr
+	command.Stdout = buffer
+	err := command.Run()
+	return buffer.String(), err
+}
+
 // Login command
 type LoginCmd struct {
 	DockerRegistry   string
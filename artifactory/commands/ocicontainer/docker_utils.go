@@ -36,6 +36,41 @@ func GetRemoteRepoAndManifestTypeWithLeadSha(imageRef string, serviceManager art
 	return repository, manifestType, leadSha, nil
 }
 
+// FetchImageFiles returns the files stored in Artifactory for the given image reference.
+// The reference may point to a tag or to a digest. For multi-platform images, the fat manifest is returned
+// along with the manifests and layers of every platform.
+func FetchImageFiles(imageRef string, serviceManager artifactory.ArtifactoryServicesManager) ([]utils.ResultItem, error) {
+	remoteRepo, manifestType, leadSha, err := GetRemoteRepoAndManifestTypeWithLeadSha(imageRef, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	searchableRepository, _, err := GetSearchableRepositoryAndDetails(remoteRepo, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	var files []utils.ResultItem
+	if manifestType == Manifest && strings.Contains(imageRef, "@"+sha256Prefix) {
+		// Images pulled by digest are stored under their tag folder, which is located by the manifest digest.
+		manifestPath, err := SearchManifestPathByDigest(searchableRepository, sha256Prefix+leadSha, serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		files, err = searchArtifactoryForFilesByPath(searchableRepository, []string{manifestPath}, serviceManager)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files, _, err = NewDockerManifestHandler(serviceManager).FetchLayersOfPushedImage(imageRef, searchableRepository, manifestType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, errorutils.CheckErrorf("could not find the files of image '%s' in repository '%s'", imageRef, searchableRepository)
+	}
+	return files, nil
+}
+
 // GetSearchableRepositoryAndDetails resolves the repository name based on type (adds -cache for remote repos)
 func GetSearchableRepositoryAndDetails(repositoryName string, serviceManager artifactory.ArtifactoryServicesManager) (string, *DockerRepositoryDetails, error) {
 	repositoryDetails := &DockerRepositoryDetails{}
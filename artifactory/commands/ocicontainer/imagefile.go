@@ -0,0 +1,131 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildkitImageNameKey   = "image.name"
+	buildkitImageDigestKey = "containerimage.digest"
+	ociImageIndexMediaType = "application/vnd.oci.image.index.v1+json"
+	dockerManifestListType = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// ImageWithDigest is an image built without a local daemon, along with the digest of its manifest or fat-manifest.
+type ImageWithDigest struct {
+	Image          *Image
+	ManifestSha256 string
+}
+
+// To unmarshal the metadata file written by BuildKit (buildctl --metadata-file) and buildx (--metadata-file).
+type buildkitMetadata struct {
+	ImageName   string         `json:"image.name"`
+	ImageSha256 string         `json:"containerimage.digest"`
+	Descriptor  *ociDescriptor `json:"containerimage.descriptor,omitempty"`
+}
+
+type ociDescriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+// ParseImageFile reads the images and digests from the file written by the image builder. Supported formats:
+//   - Kaniko --image-name-with-digest-file and OpenShift: <image>@sha256:<digest>
+//   - Kaniko --digest-file and Buildah --digestfile: sha256:<digest>. The image name is taken from imageName.
+//   - BuildKit and buildx --metadata-file, including buildx bake metadata of multiple targets.
+//
+// If set, imageName is also used for BuildKit metadata without an image name.
+func ParseImageFile(filePath, imageName string) ([]ImageWithDigest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	content := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(content, "{"):
+		return parseBuildkitMetadata([]byte(content), filePath, imageName)
+	case strings.Contains(content, "@"):
+		return parseImageNameWithDigest(content, filePath)
+	case IsDigestReference(content):
+		if imageName == "" {
+			return nil, errorutils.CheckErrorf(`file "%s" includes the image digest only. The image name must be provided as well`, filePath)
+		}
+		return []ImageWithDigest{{Image: NewImage(imageName), ManifestSha256: content}}, nil
+	}
+	return nil, errorutils.CheckErrorf(`unexpected file format "%s". The file should include one line in the following format: image-tag@sha256`, filePath)
+}
+
+func parseImageNameWithDigest(content, filePath string) ([]ImageWithDigest, error) {
+	splittedData := strings.Split(content, "@")
+	if len(splittedData) != 2 {
+		return nil, errorutils.CheckErrorf(`unexpected file format "%s". The file should include one line in the following format: image-tag@sha256`, filePath)
+	}
+	tag, sha256 := splittedData[0], splittedData[1]
+	if tag == "" || sha256 == "" {
+		return nil, errorutils.CheckErrorf(`missing image-tag/sha256 in file: "%s"`, filePath)
+	}
+	return []ImageWithDigest{{Image: NewImage(tag), ManifestSha256: sha256}}, nil
+}
+
+// Parse a metadata file of a single build, or the metadata of a buildx bake, which maps each target to its build metadata.
+func parseBuildkitMetadata(content []byte, filePath, imageName string) ([]ImageWithDigest, error) {
+	var rawMetadata map[string]json.RawMessage
+	if err := json.Unmarshal(content, &rawMetadata); err != nil {
+		return nil, errorutils.CheckErrorf(`failed to parse metadata file "%s": %s`, filePath, err.Error())
+	}
+	var targets []string
+	if _, ok := rawMetadata[buildkitImageDigestKey]; ok {
+		targets = append(targets, "")
+		rawMetadata = map[string]json.RawMessage{"": content}
+	} else {
+		for target := range rawMetadata {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+	}
+	var images []ImageWithDigest
+	for _, target := range targets {
+		var metadata buildkitMetadata
+		if err := json.Unmarshal(rawMetadata[target], &metadata); err != nil || metadata.ImageSha256 == "" {
+			log.Debug("Skipping metadata entry '" + target + "' which doesn't describe an image")
+			continue
+		}
+		image, err := metadata.toImageWithDigest(imageName)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	if len(images) == 0 {
+		return nil, errorutils.CheckErrorf(`missing %s/%s in metadata file: "%s"`, buildkitImageNameKey, buildkitImageDigestKey, filePath)
+	}
+	return images, nil
+}
+
+func (metadata *buildkitMetadata) toImageWithDigest(imageName string) (ImageWithDigest, error) {
+	if metadata.ImageName != "" {
+		imageName = metadata.ImageName
+	}
+	if imageName == "" {
+		return ImageWithDigest{}, errorutils.CheckErrorf("the image name of digest %s is missing in the metadata file and must be provided", metadata.ImageSha256)
+	}
+	if descriptor := metadata.Descriptor; descriptor != nil {
+		if descriptor.Digest != "" && descriptor.Digest != metadata.ImageSha256 {
+			return ImageWithDigest{}, errorutils.CheckErrorf("the image descriptor digest %s doesn't match the image digest %s", descriptor.Digest, metadata.ImageSha256)
+		}
+		switch {
+		case descriptor.MediaType == ociImageIndexMediaType || descriptor.MediaType == dockerManifestListType:
+			log.Debug("Image " + imageName + " is a multi-platform image")
+		case descriptor.Platform != nil:
+			log.Debug("Image " + imageName + " was built for platform " + descriptor.Platform.Os + "/" + descriptor.Platform.Architecture)
+		}
+	}
+	return ImageWithDigest{Image: NewImage(imageName), ManifestSha256: metadata.ImageSha256}, nil
+}
@@ -0,0 +1,78 @@
+package ocicontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageFile(t *testing.T) {
+	testdataDir := filepath.Join("..", "testdata", "container")
+	tests := []struct {
+		name      string
+		file      string
+		imageName string
+		expected  map[string]string
+	}{
+		{"Kaniko image name with digest", "imageTagWithDigest", "", map[string]string{"my-image-tag": "sha256:12345"}},
+		{"Kaniko or Buildah digest", "kanikoDigestFile", "acme.jfrog.io/docker-local/app:1.0", map[string]string{"acme.jfrog.io/docker-local/app:1.0": "sha256:a1b2c3"}},
+		{"BuildKit metadata", "buildkitMetadata.json", "", map[string]string{"acme.jfrog.io/docker-local/app:1.0": "sha256:d1e2f3"}},
+		{"Buildx bake metadata", "buildxBakeMetadata.json", "", map[string]string{
+			"acme.jfrog.io/docker-local/api:1.0": "sha256:a1a1a1",
+			"acme.jfrog.io/docker-local/web:1.0": "sha256:b2b2b2",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images, err := ParseImageFile(filepath.Join(testdataDir, tt.file), tt.imageName)
+			require.NoError(t, err)
+			actual := make(map[string]string, len(images))
+			for _, image := range images {
+				actual[image.Image.Name()] = image.ManifestSha256
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseImageFileErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+		return filePath
+	}
+	_, err := ParseImageFile(filepath.Join("..", "testdata", "container", "kanikoDigestFile"), "")
+	assert.ErrorContains(t, err, "image digest only")
+
+	_, err = ParseImageFile(writeFile("mismatch.json", `{"containerimage.digest":"sha256:a","containerimage.descriptor":{"digest":"sha256:b"},"image.name":"app:1"}`), "")
+	assert.ErrorContains(t, err, "doesn't match")
+
+	_, err = ParseImageFile(writeFile("nameless.json", `{"containerimage.digest":"sha256:a"}`), "")
+	assert.ErrorContains(t, err, "image name")
+
+	images, err := ParseImageFile(writeFile("named.json", `{"containerimage.digest":"sha256:a"}`), "app:1")
+	require.NoError(t, err)
+	assert.Equal(t, "app:1", images[0].Image.Name())
+
+	_, err = ParseImageFile(writeFile("empty.json", `{"buildx.build.ref":"builder/builder0/abc"}`), "")
+	assert.ErrorContains(t, err, "missing")
+
+	_, err = ParseImageFile(writeFile("invalid", "app:1"), "")
+	assert.ErrorContains(t, err, "unexpected file format")
+}
+
+func TestAddDependenciesIfAbsent(t *testing.T) {
+	module := &buildinfo.Module{Dependencies: []buildinfo.Dependency{{Id: "sha256__layer", Checksum: buildinfo.Checksum{Sha256: "layer"}}}}
+	addDependenciesIfAbsent(module, []buildinfo.Dependency{
+		{Id: "sha256__layer", Checksum: buildinfo.Checksum{Sha256: "layer"}},
+		{Id: "sha256__base", Checksum: buildinfo.Checksum{Sha256: "base"}},
+		{Id: "sha256__base", Checksum: buildinfo.Checksum{Sha256: "base"}},
+	})
+	assert.Equal(t, []string{"layer", "base"}, []string{module.Dependencies[0].Sha256, module.Dependencies[1].Sha256})
+	assert.Len(t, module.Dependencies, 2)
+}
@@ -0,0 +1,405 @@
+package ocicontainer
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ociLayoutIndexFile            = "index.json"
+	dockerSaveManifestFile        = "manifest.json"
+	ociImageManifestMediaType     = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType       = "application/vnd.docker.distribution.manifest.v2+json"
+	ociImageConfigMediaType       = "application/vnd.oci.image.config.v1+json"
+	ociUncompressedLayerMediaType = "application/vnd.oci.image.layer.v1.tar"
+	ociRefNameAnnotation          = "org.opencontainers.image.ref.name"
+)
+
+// LayoutBlob is a config or layer blob of an image, stored in a local file.
+type LayoutBlob struct {
+	Digest    string
+	Size      int64
+	LocalPath string
+}
+
+// LayoutManifest is an image manifest or index of an image, along with its media type.
+type LayoutManifest struct {
+	Digest    string
+	MediaType string
+	Content   []byte
+}
+
+// LayoutImage holds the content of an image read from an OCI image layout or a 'docker save' tarball.
+type LayoutImage struct {
+	Blobs []LayoutBlob
+	// The manifests referenced by the lead manifest, ordered so that every manifest follows the manifests it references.
+	ReferencedManifests []LayoutManifest
+	// The image manifest or fat-manifest, which is pushed with the image tag.
+	Lead LayoutManifest
+}
+
+type layoutDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type layoutIndex struct {
+	MediaType string             `json:"mediaType,omitempty"`
+	Manifests []layoutDescriptor `json:"manifests"`
+}
+
+type layoutImageManifest struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	MediaType     string             `json:"mediaType,omitempty"`
+	Config        layoutDescriptor   `json:"config"`
+	Layers        []layoutDescriptor `json:"layers"`
+}
+
+// To unmarshal the manifest.json file of a 'docker save' tarball.
+type dockerSaveManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// ReadImageLayout reads an image from an OCI image layout directory, or from a tarball of an OCI image layout or of 'docker save'.
+// Tarballs are extracted into tempDir. If the layout includes multiple images, the image is selected by the tag.
+func ReadImageLayout(imagePath, tag, tempDir string) (*LayoutImage, error) {
+	isDir, err := fileutils.IsDirExists(imagePath, false)
+	if err != nil {
+		return nil, err
+	}
+	layoutDir := imagePath
+	if !isDir {
+		log.Debug("Extracting " + imagePath + " to " + tempDir)
+		if err = extractImageTarball(imagePath, tempDir); err != nil {
+			return nil, err
+		}
+		layoutDir = tempDir
+	}
+	// 'docker save' of Docker 25 and above writes an OCI image layout, along with the legacy manifest.json.
+	if exists, err := fileutils.IsFileExists(filepath.Join(layoutDir, ociLayoutIndexFile), false); err != nil || exists {
+		if err != nil {
+			return nil, err
+		}
+		return readOciLayout(layoutDir, tag)
+	}
+	if exists, err := fileutils.IsFileExists(filepath.Join(layoutDir, dockerSaveManifestFile), false); err != nil || exists {
+		if err != nil {
+			return nil, err
+		}
+		return readDockerSave(layoutDir, tag)
+	}
+	return nil, errorutils.CheckErrorf("%s is neither an OCI image layout nor a 'docker save' tarball", imagePath)
+}
+
+// Extract a tarball, which may be gzip compressed, into the target directory.
+func extractImageTarball(tarballPath, targetDir string) (err error) {
+	file, err := os.Open(tarballPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errorutils.CheckError(errors.Join(err, file.Close()))
+	}()
+	reader := bufio.NewReader(file)
+	var tarReader *tar.Reader
+	if magic, _ := reader.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		defer func() {
+			err = errors.Join(err, gzipReader.Close())
+		}()
+		tarReader = tar.NewReader(gzipReader)
+	} else {
+		tarReader = tar.NewReader(reader)
+	}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = extractTarEntry(tarReader, header, targetDir); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+}
+
+func extractTarEntry(tarReader *tar.Reader, header *tar.Header, targetDir string) error {
+	targetPath, err := getPathInDir(targetDir, header.Name)
+	if err != nil {
+		return err
+	}
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(targetPath, 0755)
+	case tar.TypeReg:
+		if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return err
+		}
+		targetFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(targetFile, tarReader)
+		return errors.Join(err, targetFile.Close())
+	case tar.TypeSymlink, tar.TypeLink:
+		// Legacy 'docker save' links identical layers to each other.
+		linkTarget := header.Linkname
+		if header.Typeflag == tar.TypeSymlink {
+			linkTarget = filepath.Join(filepath.Dir(header.Name), header.Linkname)
+		}
+		sourcePath, err := getPathInDir(targetDir, linkTarget)
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return err
+		}
+		return os.Link(sourcePath, targetPath)
+	default:
+		log.Debug(fmt.Sprintf("Skipping entry %s of type %c", header.Name, header.Typeflag))
+		return nil
+	}
+}
+
+// Return the path of the entry in the directory. Entries outside the directory are rejected.
+func getPathInDir(dir, entryName string) (string, error) {
+	cleanName := filepath.Clean(filepath.FromSlash(entryName))
+	if filepath.IsAbs(cleanName) || cleanName == ".." || strings.HasPrefix(cleanName, ".."+string(filepath.Separator)) {
+		return "", errorutils.CheckErrorf("illegal path in tarball: %s", entryName)
+	}
+	return filepath.Join(dir, cleanName), nil
+}
+
+func readOciLayout(layoutDir, tag string) (*LayoutImage, error) {
+	var index layoutIndex
+	if err := readJsonFile(filepath.Join(layoutDir, ociLayoutIndexFile), &index); err != nil {
+		return nil, err
+	}
+	lead, err := selectLayoutImage(index.Manifests, tag)
+	if err != nil {
+		return nil, err
+	}
+	image := &LayoutImage{}
+	if err = image.addManifest(layoutDir, lead, true); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// Select the image of the layout. If the layout includes multiple images, the image is selected by its reference name annotation.
+func selectLayoutImage(descriptors []layoutDescriptor, tag string) (layoutDescriptor, error) {
+	if len(descriptors) == 0 {
+		return layoutDescriptor{}, errorutils.CheckErrorf("the OCI image layout includes no images")
+	}
+	var refNames []string
+	for _, descriptor := range descriptors {
+		refName := descriptor.Annotations[ociRefNameAnnotation]
+		if tag != "" && (refName == tag || strings.HasSuffix(refName, ":"+tag)) {
+			return descriptor, nil
+		}
+		refNames = append(refNames, refName)
+	}
+	if len(descriptors) == 1 {
+		return descriptors[0], nil
+	}
+	return layoutDescriptor{}, errorutils.CheckErrorf("the OCI image layout includes %d images and none of them is tagged %s. Available reference names: %s", len(descriptors), tag, strings.Join(refNames, ", "))
+}
+
+// Add the manifest or index to the image, along with the blobs and manifests it references.
+func (image *LayoutImage) addManifest(layoutDir string, descriptor layoutDescriptor, isLead bool) error {
+	content, err := readLayoutBlob(layoutDir, descriptor.Digest)
+	if err != nil {
+		return err
+	}
+	if digest := sha256Prefix + getSha256(content); digest != descriptor.Digest {
+		return errorutils.CheckErrorf("the digest of manifest %s doesn't match its content digest %s", descriptor.Digest, digest)
+	}
+	switch descriptor.MediaType {
+	case ociImageIndexMediaType, dockerManifestListType:
+		var index layoutIndex
+		if err = json.Unmarshal(content, &index); err != nil {
+			return errorutils.CheckErrorf("failed to parse image index %s: %s", descriptor.Digest, err.Error())
+		}
+		for _, child := range index.Manifests {
+			if err = image.addManifest(layoutDir, child, false); err != nil {
+				return err
+			}
+		}
+	case ociImageManifestMediaType, dockerManifestMediaType:
+		var manifest layoutImageManifest
+		if err = json.Unmarshal(content, &manifest); err != nil {
+			return errorutils.CheckErrorf("failed to parse image manifest %s: %s", descriptor.Digest, err.Error())
+		}
+		for _, blob := range append([]layoutDescriptor{manifest.Config}, manifest.Layers...) {
+			if err = image.addBlob(layoutDir, blob); err != nil {
+				return err
+			}
+		}
+	default:
+		return errorutils.CheckErrorf("unsupported media type %s of manifest %s", descriptor.MediaType, descriptor.Digest)
+	}
+	manifest := LayoutManifest{Digest: descriptor.Digest, MediaType: descriptor.MediaType, Content: content}
+	if isLead {
+		image.Lead = manifest
+		return nil
+	}
+	for _, existing := range image.ReferencedManifests {
+		if existing.Digest == manifest.Digest {
+			return nil
+		}
+	}
+	image.ReferencedManifests = append(image.ReferencedManifests, manifest)
+	return nil
+}
+
+func (image *LayoutImage) addBlob(layoutDir string, descriptor layoutDescriptor) error {
+	for _, existing := range image.Blobs {
+		if existing.Digest == descriptor.Digest {
+			return nil
+		}
+	}
+	localPath, err := getLayoutBlobPath(layoutDir, descriptor.Digest)
+	if err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(localPath, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// Non-distributable layers, such as Windows base layers, may be missing from the layout.
+		if strings.Contains(descriptor.MediaType, "nondistributable") || strings.Contains(descriptor.MediaType, "foreign") {
+			log.Debug("Skipping non-distributable layer " + descriptor.Digest)
+			return nil
+		}
+		return errorutils.CheckErrorf("blob %s is missing from the OCI image layout", descriptor.Digest)
+	}
+	image.Blobs = append(image.Blobs, LayoutBlob{Digest: descriptor.Digest, Size: descriptor.Size, LocalPath: localPath})
+	return nil
+}
+
+// Blobs are stored in the layout under blobs/<algorithm>/<encoded digest>.
+func getLayoutBlobPath(layoutDir, digest string) (string, error) {
+	algorithm, encoded, ok := strings.Cut(digest, ":")
+	if !ok || algorithm == "" || encoded == "" || strings.ContainsAny(encoded, `/\.`) {
+		return "", errorutils.CheckErrorf("invalid digest %s", digest)
+	}
+	return filepath.Join(layoutDir, "blobs", algorithm, encoded), nil
+}
+
+func readLayoutBlob(layoutDir, digest string) ([]byte, error) {
+	blobPath, err := getLayoutBlobPath(layoutDir, digest)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(blobPath)
+	return content, errorutils.CheckError(err)
+}
+
+// Read a legacy 'docker save' tarball, and convert its image to an OCI image manifest with uncompressed layers.
+func readDockerSave(dir, tag string) (*LayoutImage, error) {
+	var manifests []dockerSaveManifest
+	if err := readJsonFile(filepath.Join(dir, dockerSaveManifestFile), &manifests); err != nil {
+		return nil, err
+	}
+	saved, err := selectDockerSaveImage(manifests, tag)
+	if err != nil {
+		return nil, err
+	}
+	image := &LayoutImage{}
+	config, err := image.addLocalFileBlob(dir, saved.Config, ociImageConfigMediaType)
+	if err != nil {
+		return nil, err
+	}
+	manifest := layoutImageManifest{SchemaVersion: 2, MediaType: ociImageManifestMediaType, Config: config, Layers: []layoutDescriptor{}}
+	for _, layerPath := range saved.Layers {
+		layer, err := image.addLocalFileBlob(dir, layerPath, ociUncompressedLayerMediaType)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Layers = append(manifest.Layers, layer)
+	}
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	image.Lead = LayoutManifest{Digest: sha256Prefix + getSha256(content), MediaType: ociImageManifestMediaType, Content: content}
+	return image, nil
+}
+
+func selectDockerSaveImage(manifests []dockerSaveManifest, tag string) (*dockerSaveManifest, error) {
+	if len(manifests) == 0 {
+		return nil, errorutils.CheckErrorf("the 'docker save' tarball includes no images")
+	}
+	var repoTags []string
+	for i := range manifests {
+		for _, repoTag := range manifests[i].RepoTags {
+			if tag != "" && (repoTag == tag || strings.HasSuffix(repoTag, ":"+tag)) {
+				return &manifests[i], nil
+			}
+			repoTags = append(repoTags, repoTag)
+		}
+	}
+	if len(manifests) == 1 {
+		return &manifests[0], nil
+	}
+	return nil, errorutils.CheckErrorf("the 'docker save' tarball includes %d images and none of them is tagged %s. Available tags: %s", len(manifests), tag, strings.Join(repoTags, ", "))
+}
+
+func (image *LayoutImage) addLocalFileBlob(dir, relativePath, mediaType string) (layoutDescriptor, error) {
+	localPath, err := getPathInDir(dir, relativePath)
+	if err != nil {
+		return layoutDescriptor{}, err
+	}
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return layoutDescriptor{}, err
+	}
+	descriptor := layoutDescriptor{MediaType: mediaType, Digest: sha256Prefix + details.Checksum.Sha256, Size: details.Size}
+	for _, existing := range image.Blobs {
+		if existing.Digest == descriptor.Digest {
+			return descriptor, nil
+		}
+	}
+	image.Blobs = append(image.Blobs, LayoutBlob{Digest: descriptor.Digest, Size: descriptor.Size, LocalPath: localPath})
+	return descriptor, nil
+}
+
+func readJsonFile(filePath string, target interface{}) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, target); err != nil {
+		return errorutils.CheckErrorf("failed to parse %s: %s", filePath, err.Error())
+	}
+	return nil
+}
+
+func getSha256(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:])
+}
@@ -0,0 +1,141 @@
+package ocicontainer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Write a blob to the layout and return its descriptor.
+func writeLayoutBlob(t *testing.T, layoutDir, mediaType string, content []byte) layoutDescriptor {
+	digest := sha256Prefix + getSha256(content)
+	blobPath, err := getLayoutBlobPath(layoutDir, digest)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(blobPath), 0755))
+	require.NoError(t, os.WriteFile(blobPath, content, 0644))
+	return layoutDescriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content))}
+}
+
+func writeLayoutImageManifest(t *testing.T, layoutDir, layerContent string) layoutDescriptor {
+	config := writeLayoutBlob(t, layoutDir, ociImageConfigMediaType, []byte(`{"architecture":"amd64","os":"linux"}`))
+	layer := writeLayoutBlob(t, layoutDir, ociUncompressedLayerMediaType, []byte(layerContent))
+	manifest, err := json.Marshal(layoutImageManifest{SchemaVersion: 2, MediaType: ociImageManifestMediaType, Config: config, Layers: []layoutDescriptor{layer}})
+	require.NoError(t, err)
+	return writeLayoutBlob(t, layoutDir, ociImageManifestMediaType, manifest)
+}
+
+func writeLayoutIndex(t *testing.T, layoutDir string, manifests ...layoutDescriptor) {
+	content, err := json.Marshal(layoutIndex{Manifests: manifests})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(layoutDir, ociLayoutIndexFile), content, 0644))
+}
+
+func TestReadImageLayoutMultiPlatform(t *testing.T) {
+	layoutDir := t.TempDir()
+	amd64 := writeLayoutImageManifest(t, layoutDir, "amd64 layer")
+	arm64 := writeLayoutImageManifest(t, layoutDir, "arm64 layer")
+	indexContent, err := json.Marshal(layoutIndex{MediaType: ociImageIndexMediaType, Manifests: []layoutDescriptor{amd64, arm64}})
+	require.NoError(t, err)
+	index := writeLayoutBlob(t, layoutDir, ociImageIndexMediaType, indexContent)
+	index.Annotations = map[string]string{ociRefNameAnnotation: "1.0"}
+	writeLayoutIndex(t, layoutDir, index)
+
+	image, err := ReadImageLayout(layoutDir, "1.0", t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, index.Digest, image.Lead.Digest)
+	assert.Equal(t, ociImageIndexMediaType, image.Lead.MediaType)
+	require.Len(t, image.ReferencedManifests, 2)
+	assert.Equal(t, amd64.Digest, image.ReferencedManifests[0].Digest)
+	assert.Equal(t, arm64.Digest, image.ReferencedManifests[1].Digest)
+	// Both platforms share the same config blob.
+	assert.Len(t, image.Blobs, 3)
+}
+
+func TestReadImageLayoutSelectByTag(t *testing.T) {
+	layoutDir := t.TempDir()
+	first := writeLayoutImageManifest(t, layoutDir, "first layer")
+	first.Annotations = map[string]string{ociRefNameAnnotation: "docker.io/library/app:1.0"}
+	second := writeLayoutImageManifest(t, layoutDir, "second layer")
+	second.Annotations = map[string]string{ociRefNameAnnotation: "docker.io/library/app:2.0"}
+	writeLayoutIndex(t, layoutDir, first, second)
+
+	image, err := ReadImageLayout(layoutDir, "2.0", t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, second.Digest, image.Lead.Digest)
+	assert.Empty(t, image.ReferencedManifests)
+	assert.Len(t, image.Blobs, 2)
+
+	_, err = ReadImageLayout(layoutDir, "3.0", t.TempDir())
+	assert.ErrorContains(t, err, "none of them is tagged 3.0")
+}
+
+func TestReadImageLayoutMissingBlob(t *testing.T) {
+	layoutDir := t.TempDir()
+	manifest := writeLayoutImageManifest(t, layoutDir, "layer")
+	writeLayoutIndex(t, layoutDir, manifest)
+	var imageManifest layoutImageManifest
+	content, err := readLayoutBlob(layoutDir, manifest.Digest)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, &imageManifest))
+	layerPath, err := getLayoutBlobPath(layoutDir, imageManifest.Layers[0].Digest)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(layerPath))
+
+	_, err = ReadImageLayout(layoutDir, "", t.TempDir())
+	assert.ErrorContains(t, err, "is missing from the OCI image layout")
+}
+
+func TestReadImageLayoutDockerSaveTarball(t *testing.T) {
+	tarballPath := filepath.Join(t.TempDir(), "app.tar.gz")
+	writeTarball(t, tarballPath, map[string]string{
+		"manifest.json":  `[{"Config":"abc123.json","RepoTags":["app:1.0"],"Layers":["l1/layer.tar","l2/layer.tar"]}]`,
+		"abc123.json":    `{"architecture":"amd64","os":"linux"}`,
+		"l1/layer.tar":   "first layer",
+		"l2/layer.tar":   "second layer",
+		"l1/VERSION":     "1.0",
+		"repositories":   `{"app":{"1.0":"l2"}}`,
+		"unrelated.json": "{}",
+	})
+
+	image, err := ReadImageLayout(tarballPath, "1.0", t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, image.Blobs, 3)
+	assert.Equal(t, sha256Prefix+getSha256([]byte(`{"architecture":"amd64","os":"linux"}`)), image.Blobs[0].Digest)
+	assert.Equal(t, sha256Prefix+getSha256([]byte("first layer")), image.Blobs[1].Digest)
+	assert.Equal(t, ociImageManifestMediaType, image.Lead.MediaType)
+	assert.Equal(t, sha256Prefix+getSha256(image.Lead.Content), image.Lead.Digest)
+
+	var manifest layoutImageManifest
+	require.NoError(t, json.Unmarshal(image.Lead.Content, &manifest))
+	assert.Equal(t, image.Blobs[0].Digest, manifest.Config.Digest)
+	require.Len(t, manifest.Layers, 2)
+	assert.Equal(t, ociUncompressedLayerMediaType, manifest.Layers[1].MediaType)
+	assert.Equal(t, int64(len("second layer")), manifest.Layers[1].Size)
+}
+
+func TestExtractImageTarballIllegalPath(t *testing.T) {
+	tarballPath := filepath.Join(t.TempDir(), "image.tar.gz")
+	writeTarball(t, tarballPath, map[string]string{"../outside": "content"})
+	assert.ErrorContains(t, extractImageTarball(tarballPath, t.TempDir()), "illegal path")
+}
+
+func writeTarball(t *testing.T, tarballPath string, files map[string]string) {
+	file, err := os.Create(tarballPath)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err = tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+}
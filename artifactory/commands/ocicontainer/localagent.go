@@ -43,6 +43,11 @@ func (labib *localAgentBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
 	return &labib.buildInfoBuilder.imageLayers
 }
 
+// Set the image Artifactory served for the pull, after verifying it matches the local image.
+func (labib *localAgentBuildInfoBuilder) SetResolvedImage(resolvedImage *ResolvedImage) {
+	labib.buildInfoBuilder.resolvedImage = resolvedImage
+}
+
 func (labib *localAgentBuildInfoBuilder) SetSkipTaggingLayers(skipTaggingLayers bool) {
 	labib.buildInfoBuilder.skipTaggingLayers = skipTaggingLayers
 }
@@ -90,6 +95,9 @@ func (labib *localAgentBuildInfoBuilder) handleImageByDigest() (*buildinfo.Build
 		"docker.image.id":  labib.buildInfoBuilder.imageSha2,
 		"docker.image.tag": labib.buildInfoBuilder.image.Name(),
 	}
+	if resolvedImage := labib.buildInfoBuilder.resolvedImage; resolvedImage != nil {
+		imageProperties["docker.image.digest"] = resolvedImage.Digest
+	}
 
 	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
 		Id:           dockerImageTag,
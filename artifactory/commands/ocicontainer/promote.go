@@ -0,0 +1,175 @@
+package ocicontainer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ImagePromotionPlan holds the folders storing an image in a repository, which should be promoted together.
+type ImagePromotionPlan struct {
+	// The digest of the image manifest or fat-manifest.
+	Digest string
+	// The folder of the image manifest or fat-manifest, relative to the repository.
+	LeadFolder string
+	// The folders of the platform manifests and attestations referenced by the fat-manifest, relative to the repository.
+	ReferencedFolders []string
+}
+
+func (plan *ImagePromotionPlan) IsMultiPlatform() bool {
+	return len(plan.ReferencedFolders) > 0
+}
+
+// IsDigestReference returns true if the reference is a manifest digest (sha256:...) rather than a tag.
+func IsDigestReference(reference string) bool {
+	return strings.HasPrefix(reference, sha256Prefix)
+}
+
+// ResolveImageForPromotion finds the folders of the image in the repository. The reference is either a tag or a manifest digest.
+// For multi-platform images, the folders of all the manifests referenced by the fat-manifest are resolved too.
+func ResolveImageForPromotion(repo, imageName, reference string, serviceManager artifactory.ArtifactoryServicesManager) (*ImagePromotionPlan, error) {
+	var leadFiles map[string]*utils.ResultItem
+	var err error
+	if IsDigestReference(reference) {
+		leadFiles, err = searchManifestFolderByDigest(repo, imageName, reference, serviceManager)
+	} else {
+		leadFiles, err = performSearch(path.Join(repo, imageName, reference, "*"), serviceManager)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fatManifestItem, isMultiPlatform := leadFiles["list.manifest.json"]
+	if !isMultiPlatform {
+		manifestItem, ok := leadFiles[ManifestJsonFile]
+		if !ok {
+			return nil, errorutils.CheckErrorf("could not find image %s:%s in repository %s", imageName, reference, repo)
+		}
+		return &ImagePromotionPlan{Digest: sha256Prefix + manifestItem.Sha256, LeadFolder: manifestItem.Path}, nil
+	}
+	plan := &ImagePromotionPlan{Digest: sha256Prefix + fatManifestItem.Sha256, LeadFolder: fatManifestItem.Path}
+	fatManifest, err := getFatManifest(leadFiles, serviceManager, repo)
+	if err != nil {
+		return nil, err
+	}
+	images, err := performMultiPlatformImageSearch(path.Join(repo, imageName, "*"), serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	for _, manifest := range fatManifest.Manifests {
+		files, ok := images[manifest.Digest]
+		if !ok || len(files) == 0 {
+			return nil, errorutils.CheckErrorf("manifest %s referenced by image %s:%s was not found in repository %s", manifest.Digest, imageName, reference, repo)
+		}
+		plan.ReferencedFolders = appendFolderIfAbsent(plan.ReferencedFolders, files[0].Path)
+	}
+	log.Debug(fmt.Sprintf("Resolved image %s:%s to %s, referencing %d manifests", imageName, reference, plan.Digest, len(plan.ReferencedFolders)))
+	return plan, nil
+}
+
+// Search for the folder of the image manifest or fat-manifest with the given digest.
+// Digest folders (sha256:...) are preferred over tag folders storing the same manifest.
+func searchManifestFolderByDigest(repo, imageName, digest string, serviceManager artifactory.ArtifactoryServicesManager) (map[string]*utils.ResultItem, error) {
+	aqlQuery := fmt.Sprintf(`items.find({
+		"repo": "%s",
+		"path": {"$match": "%s/*"},
+		"name": {"$in": ["list.manifest.json", "manifest.json"]},
+		"sha256": "%s"
+	}).include("repo", "path", "name", "sha256")`, repo, imageName, strings.TrimPrefix(digest, sha256Prefix))
+	results, err := artUtils.ExecuteAqlQuery(serviceManager, aqlQuery)
+	if err != nil {
+		return nil, err
+	}
+	var folders []string
+	for _, result := range results {
+		// Skip manifests of nested images.
+		if path.Dir(result.Path) == imageName {
+			folders = appendFolderIfAbsent(folders, result.Path)
+		}
+	}
+	if len(folders) == 0 {
+		return nil, errorutils.CheckErrorf("could not find image %s@%s in repository %s", imageName, digest, repo)
+	}
+	sort.SliceStable(folders, func(i, j int) bool {
+		return isDigestFolder(folders[i]) && !isDigestFolder(folders[j])
+	})
+	return performSearch(path.Join(repo, folders[0], "*"), serviceManager)
+}
+
+func isDigestFolder(folder string) bool {
+	name := path.Base(folder)
+	return strings.HasPrefix(name, sha256Prefix) || strings.HasPrefix(name, sha256RemoteFormat)
+}
+
+func appendFolderIfAbsent(folders []string, folder string) []string {
+	for _, existing := range folders {
+		if existing == folder {
+			return folders
+		}
+	}
+	return append(folders, folder)
+}
+
+// GetFoldersToDelete returns the folders of the image which can be deleted once it was moved to another repository.
+// Digest folders referenced by other fat-manifests of the image, and tag folders of other images, are returned as shared and must be kept.
+func GetFoldersToDelete(repo, imageName string, plan *ImagePromotionPlan, serviceManager artifactory.ArtifactoryServicesManager) (toDelete, shared []string, err error) {
+	referencedDigests, err := getDigestsReferencedByOtherImages(repo, imageName, plan.LeadFolder, serviceManager)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDelete, shared = splitSharedFolders(plan, referencedDigests)
+	return
+}
+
+// Return the manifest digests referenced by the fat-manifests of the image, except for the fat-manifest stored in the excluded folder.
+func getDigestsReferencedByOtherImages(repo, imageName, excludedFolder string, serviceManager artifactory.ArtifactoryServicesManager) (map[string]bool, error) {
+	aqlQuery := fmt.Sprintf(`items.find({
+		"repo": "%s",
+		"path": {"$match": "%s/*"},
+		"name": "list.manifest.json"
+	}).include("repo", "path", "name", "sha256")`, repo, imageName)
+	results, err := artUtils.ExecuteAqlQuery(serviceManager, aqlQuery)
+	if err != nil {
+		return nil, err
+	}
+	referencedDigests := make(map[string]bool)
+	for _, result := range results {
+		if result.Path == excludedFolder || path.Dir(result.Path) != imageName {
+			continue
+		}
+		var fatManifest FatManifest
+		if err = downloadLayer(result, &fatManifest, serviceManager, repo); err != nil {
+			return nil, err
+		}
+		for _, manifest := range fatManifest.Manifests {
+			referencedDigests[manifest.Digest] = true
+		}
+	}
+	return referencedDigests, nil
+}
+
+func splitSharedFolders(plan *ImagePromotionPlan, referencedDigests map[string]bool) (toDelete, shared []string) {
+	if isDigestFolder(plan.LeadFolder) && referencedDigests[plan.Digest] {
+		shared = append(shared, plan.LeadFolder)
+	} else {
+		toDelete = append(toDelete, plan.LeadFolder)
+	}
+	for _, folder := range plan.ReferencedFolders {
+		if isDigestFolder(folder) && !referencedDigests[getFolderDigest(folder)] {
+			toDelete = append(toDelete, folder)
+		} else {
+			shared = append(shared, folder)
+		}
+	}
+	return
+}
+
+func getFolderDigest(folder string) string {
+	return strings.Replace(path.Base(folder), sha256RemoteFormat, sha256Prefix, 1)
+}
@@ -0,0 +1,41 @@
+package ocicontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDigestReference(t *testing.T) {
+	assert.True(t, IsDigestReference("sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e"))
+	assert.False(t, IsDigestReference("1.0.0"))
+	assert.False(t, IsDigestReference("sha256-30daa5c1.sig"))
+}
+
+func TestIsDigestFolder(t *testing.T) {
+	assert.True(t, isDigestFolder("org/app/sha256:30daa5c1"))
+	assert.True(t, isDigestFolder("org/app/sha256__30daa5c1"))
+	assert.False(t, isDigestFolder("org/app/1.0.0"))
+}
+
+func TestImagePromotionPlanIsMultiPlatform(t *testing.T) {
+	assert.False(t, (&ImagePromotionPlan{LeadFolder: "app/1.0"}).IsMultiPlatform())
+	assert.True(t, (&ImagePromotionPlan{LeadFolder: "app/1.0", ReferencedFolders: []string{"app/sha256:amd64"}}).IsMultiPlatform())
+}
+
+func TestSplitSharedFolders(t *testing.T) {
+	plan := &ImagePromotionPlan{
+		Digest:            "sha256:list",
+		LeadFolder:        "app/1.0",
+		ReferencedFolders: []string{"app/sha256:amd64", "app/sha256__arm64", "app/sha256:attestation", "app/amd64-only"},
+	}
+	// The amd64 and arm64 manifests are also referenced by the fat-manifest of another tag. Tag folders are never deleted.
+	toDelete, shared := splitSharedFolders(plan, map[string]bool{"sha256:amd64": true, "sha256:arm64": true})
+	assert.Equal(t, []string{"app/1.0", "app/sha256:attestation"}, toDelete)
+	assert.Equal(t, []string{"app/sha256:amd64", "app/sha256__arm64", "app/amd64-only"}, shared)
+
+	// A platform manifest promoted by digest is kept if a fat-manifest references it.
+	toDelete, shared = splitSharedFolders(&ImagePromotionPlan{Digest: "sha256:amd64", LeadFolder: "app/sha256:amd64"}, map[string]bool{"sha256:amd64": true})
+	assert.Empty(t, toDelete)
+	assert.Equal(t, []string{"app/sha256:amd64"}, shared)
+}
@@ -0,0 +1,272 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const manifestAcceptHeader = "application/vnd.oci.image.index.v1+json, application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+
+// ResolvedImage describes the image served by Artifactory for a pulled tag or digest.
+type ResolvedImage struct {
+	// The digest the reference resolved to. For multi-platform images, this is the digest of the fat-manifest.
+	Digest string
+	// The digest of the image manifest of the local platform.
+	ManifestDigest string
+	ConfigDigest   string
+	DiffIds        []string
+}
+
+// To unmarshal the rootfs of the image config.
+type imageConfig struct {
+	RootFS struct {
+		DiffIds []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// To unmarshal the media type of a manifest or fat-manifest.
+type manifestMediaType struct {
+	MediaType string `json:"mediaType"`
+}
+
+// VerifyPulledImage verifies that the local image matches the image Artifactory serves for the image reference.
+// The image ID and the layers diff IDs of the local image are compared with the manifest and config in Artifactory.
+// With the classic Docker image store the image ID is the config digest, while with the containerd image store it is the digest
+// of the pulled fat-manifest or manifest, so the image ID may match either.
+// This detects a mutable tag which changed during the pull, or a mirror which served a different image.
+func VerifyPulledImage(image *Image, repo string, containerManager ContainerManager, serviceManager artifactory.ArtifactoryServicesManager) (*ResolvedImage, error) {
+	diffIdsProvider, ok := containerManager.(DiffIdsProvider)
+	if !ok {
+		return nil, errorutils.CheckErrorf("verifying pulled images isn't supported by %s", containerManager.GetContainerManagerType())
+	}
+	reference, err := image.GetImageTag()
+	if err != nil {
+		return nil, err
+	}
+	localImageId, err := containerManager.Id(image)
+	if err != nil {
+		return nil, err
+	}
+	localImageId = toDigest(localImageId)
+	localDiffIds, err := diffIdsProvider.DiffIds(image)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := resolveImageInRepo(image, repo, reference, localImageId, localDiffIds, containerManager, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	if err = compareImages(resolved, localImageId, localDiffIds); err != nil {
+		return nil, errorutils.CheckErrorf("verification of pulled image %s failed: %s", image.Name(), err.Error())
+	}
+	log.Info(fmt.Sprintf("Verified that the pulled image %s matches %s in Artifactory", image.Name(), resolved.Digest))
+	return resolved, nil
+}
+
+func compareImages(resolved *ResolvedImage, localImageId string, localDiffIds []string) error {
+	if !resolved.matchesImageId(localImageId) {
+		return fmt.Errorf("the local image ID %s doesn't match the config digest %s of manifest %s, nor the digest of the manifest", localImageId, resolved.ConfigDigest, resolved.ManifestDigest)
+	}
+	if len(resolved.DiffIds) != len(localDiffIds) {
+		return fmt.Errorf("the local image has %d layers, while manifest %s has %d layers", len(localDiffIds), resolved.ManifestDigest, len(resolved.DiffIds))
+	}
+	for i := range localDiffIds {
+		if localDiffIds[i] != resolved.DiffIds[i] {
+			return fmt.Errorf("layer %d of the local image %s doesn't match layer %s of manifest %s", i, localDiffIds[i], resolved.DiffIds[i], resolved.ManifestDigest)
+		}
+	}
+	return nil
+}
+
+// Returns true if the image ID is the config digest (classic Docker image store), or the digest of the fat-manifest or manifest (containerd image store).
+func (ri *ResolvedImage) matchesImageId(imageId string) bool {
+	return imageId == ri.ConfigDigest || imageId == ri.ManifestDigest || imageId == ri.Digest
+}
+
+// Image IDs may be listed without the algorithm prefix.
+func toDigest(imageId string) string {
+	imageId = strings.TrimSpace(imageId)
+	if imageId == "" || strings.Contains(imageId, ":") {
+		return imageId
+	}
+	return sha256Prefix + imageId
+}
+
+// Resolve the reference through the registry API of the repository.
+// The image path in the repository depends on the Docker access method, so both the path with and without the first segment are tried.
+func resolveImageInRepo(image *Image, repo, reference, localImageId string, localDiffIds []string, containerManager ContainerManager, serviceManager artifactory.ArtifactoryServicesManager) (*ResolvedImage, error) {
+	imagePathCandidates, err := getImagePathCandidates(image)
+	if err != nil {
+		return nil, err
+	}
+	for _, imagePath := range imagePathCandidates {
+		client := newRegistryClient(repo, imagePath, serviceManager)
+		content, digest, found, err := client.getManifest(reference)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Debug("Image " + imagePath + ":" + reference + " was not found in " + repo)
+			continue
+		}
+		resolved := &ResolvedImage{Digest: digest, ManifestDigest: digest}
+		if err = client.selectPlatformManifest(content, resolved, image, localImageId, localDiffIds, containerManager); err != nil {
+			return nil, err
+		}
+		if resolved.DiffIds == nil {
+			if resolved.DiffIds, err = client.getDiffIds(resolved.ConfigDigest); err != nil {
+				return nil, err
+			}
+		}
+		return resolved, nil
+	}
+	return nil, errorutils.CheckErrorf("could not find image %s in repository %s", image.Name(), repo)
+}
+
+func getImagePathCandidates(image *Image) ([]string, error) {
+	withoutRepo, err := image.GetImageLongNameWithoutRepoAndTag()
+	if err != nil {
+		return nil, err
+	}
+	longName, err := image.GetImageLongName()
+	if err != nil {
+		return nil, err
+	}
+	if withoutRepo == longName {
+		return []string{longName}, nil
+	}
+	return []string{withoutRepo, longName}, nil
+}
+
+// Set the manifest digest and config digest of the resolved image. If the manifest is a fat-manifest, the manifest of the local image platform is used.
+// Platform variants, such as arm/v6 and arm/v7, aren't reported by the local image, so if multiple manifests match its OS and architecture,
+// the one matching the local image ID is preferred. When the local image ID is the digest of the fat-manifest itself (containerd image store),
+// the one whose layers match the local layers is preferred.
+func (rc *registryClient) selectPlatformManifest(content []byte, resolved *ResolvedImage, image *Image, localImageId string, localDiffIds []string, containerManager ContainerManager) error {
+	var mediaType manifestMediaType
+	if err := json.Unmarshal(content, &mediaType); err != nil {
+		return errorutils.CheckErrorf("failed to parse manifest %s: %s", resolved.Digest, err.Error())
+	}
+	if mediaType.MediaType != ociImageIndexMediaType && mediaType.MediaType != dockerManifestListType {
+		configDigest, err := getConfigDigest(content, resolved.ManifestDigest)
+		resolved.ConfigDigest = configDigest
+		return err
+	}
+	var fatManifest FatManifest
+	if err := json.Unmarshal(content, &fatManifest); err != nil {
+		return errorutils.CheckErrorf("failed to parse fat-manifest %s: %s", resolved.Digest, err.Error())
+	}
+	imageOs, imageArch, err := containerManager.OsCompatibility(image)
+	if err != nil {
+		return err
+	}
+	candidates := searchPlatformManifestDigests(imageOs, imageArch, fatManifest.Manifests)
+	if len(candidates) == 0 {
+		return errorutils.CheckErrorf("fat-manifest %s has no manifest for platform %s/%s", resolved.Digest, imageOs, imageArch)
+	}
+	for _, candidate := range candidates {
+		content, _, found, err := rc.getManifest(candidate)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errorutils.CheckErrorf("manifest %s referenced by fat-manifest %s was not found", candidate, resolved.Digest)
+		}
+		configDigest, err := getConfigDigest(content, candidate)
+		if err != nil {
+			return err
+		}
+		matched := configDigest == localImageId || candidate == localImageId
+		var diffIds []string
+		if !matched && localImageId == resolved.Digest {
+			if diffIds, err = rc.getDiffIds(configDigest); err != nil {
+				return err
+			}
+			matched = slices.Equal(diffIds, localDiffIds)
+		}
+		if resolved.ConfigDigest == "" || matched {
+			resolved.ManifestDigest, resolved.ConfigDigest, resolved.DiffIds = candidate, configDigest, diffIds
+		}
+		if matched {
+			break
+		}
+	}
+	log.Debug(fmt.Sprintf("Resolved platform %s/%s of fat-manifest %s to manifest %s", imageOs, imageArch, resolved.Digest, resolved.ManifestDigest))
+	return nil
+}
+
+// Return the digests of all the manifests of the platform, in the fat-manifest order.
+func searchPlatformManifestDigests(imageOs, imageArch string, manifestList []ManifestDetails) (digests []string) {
+	for _, manifest := range manifestList {
+		if manifest.Platform.Os == imageOs && manifest.Platform.Architecture == imageArch {
+			digests = append(digests, manifest.Digest)
+		}
+	}
+	return
+}
+
+func getConfigDigest(content []byte, manifestDigest string) (string, error) {
+	var imageManifest manifest
+	if err := json.Unmarshal(content, &imageManifest); err != nil {
+		return "", errorutils.CheckErrorf("failed to parse manifest %s: %s", manifestDigest, err.Error())
+	}
+	return imageManifest.Config.Digest, nil
+}
+
+// Get the manifest with the given reference, along with the digest of its content.
+func (rc *registryClient) getManifest(reference string) (content []byte, digest string, found bool, err error) {
+	httpClientDetails := rc.createHttpClientDetails(map[string]string{"Accept": manifestAcceptHeader})
+	resp, body, _, err := rc.serviceManager.Client().SendGet(rc.imageApiUrl+"manifests/"+reference, true, &httpClientDetails)
+	if err != nil {
+		return
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return
+	default:
+		err = errorutils.CheckErrorf("failed to get manifest %s. Artifactory response: %s", reference, resp.Status)
+		return
+	}
+	digest = sha256Prefix + getSha256(body)
+	if IsDigestReference(reference) && reference != digest {
+		err = errorutils.CheckErrorf("the content of manifest %s doesn't match its digest. Content digest: %s", reference, digest)
+		return
+	}
+	return body, digest, true, nil
+}
+
+// Get the layers diff IDs from the image config.
+func (rc *registryClient) getDiffIds(configDigest string) ([]string, error) {
+	config, err := rc.getBlob(configDigest)
+	if err != nil {
+		return nil, err
+	}
+	var configContent imageConfig
+	if err = json.Unmarshal(config, &configContent); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse image config %s: %s", configDigest, err.Error())
+	}
+	return configContent.RootFS.DiffIds, nil
+}
+
+func (rc *registryClient) getBlob(digest string) ([]byte, error) {
+	httpClientDetails := rc.createHttpClientDetails(nil)
+	resp, body, _, err := rc.serviceManager.Client().SendGet(rc.imageApiUrl+"blobs/"+digest, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckErrorf("failed to get blob %s. Artifactory response: %s", digest, resp.Status)
+	}
+	if contentDigest := sha256Prefix + getSha256(body); contentDigest != digest {
+		return nil, errorutils.CheckErrorf("the content of blob %s doesn't match its digest. Content digest: %s", digest, contentDigest)
+	}
+	return body, nil
+}
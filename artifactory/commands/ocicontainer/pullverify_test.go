@@ -0,0 +1,148 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContainerManager struct {
+	imageId string
+	diffIds []string
+}
+
+func (tcm *testContainerManager) Id(*Image) (string, error) {
+	return tcm.imageId, nil
+}
+
+func (tcm *testContainerManager) OsCompatibility(*Image) (string, string, error) {
+	return "linux", "arm64", nil
+}
+
+func (tcm *testContainerManager) DiffIds(*Image) ([]string, error) {
+	return tcm.diffIds, nil
+}
+
+func (tcm *testContainerManager) RunNativeCmd([]string) error {
+	return nil
+}
+
+func (tcm *testContainerManager) GetContainerManagerType() ContainerManagerType {
+	return DockerClient
+}
+
+// Serve a multi-platform image through the registry API of repository docker-remote, at path library/app.
+func createTestImageRegistry(t *testing.T) (server *httptest.Server, indexDigest, manifestDigest, configDigest string) {
+	configContent := []byte(`{"architecture":"arm64","os":"linux","rootfs":{"type":"layers","diff_ids":["sha256:d1","sha256:d2"]}}`)
+	configDigest = sha256Prefix + getSha256(configContent)
+	manifestContent, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     ociImageManifestMediaType,
+		"config":        map[string]string{"digest": configDigest},
+		"layers":        []map[string]string{{"digest": "sha256:l1"}, {"digest": "sha256:l2"}},
+	})
+	require.NoError(t, err)
+	manifestDigest = sha256Prefix + getSha256(manifestContent)
+	// Another variant of the same platform, listed first.
+	variantConfigContent := []byte(`{"architecture":"arm64","os":"linux","variant":"v9","rootfs":{"type":"layers","diff_ids":["sha256:d3"]}}`)
+	variantConfigDigest := sha256Prefix + getSha256(variantConfigContent)
+	variantManifestContent, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     ociImageManifestMediaType,
+		"config":        map[string]string{"digest": variantConfigDigest},
+		"layers":        []map[string]string{{"digest": "sha256:l3"}},
+	})
+	require.NoError(t, err)
+	variantManifestDigest := sha256Prefix + getSha256(variantManifestContent)
+	index, err := json.Marshal(map[string]interface{}{
+		"mediaType": ociImageIndexMediaType,
+		"manifests": []map[string]interface{}{
+			{"digest": "sha256:amd64", "platform": map[string]string{"os": "linux", "architecture": "amd64"}},
+			{"digest": variantManifestDigest, "platform": map[string]string{"os": "linux", "architecture": "arm64", "variant": "v9"}},
+			{"digest": manifestDigest, "platform": map[string]string{"os": "linux", "architecture": "arm64"}},
+		},
+	})
+	require.NoError(t, err)
+	indexDigest = sha256Prefix + getSha256(index)
+	content := map[string][]byte{
+		"manifests/1.0":                      index,
+		"manifests/" + indexDigest:           index,
+		"manifests/" + manifestDigest:        manifestContent,
+		"manifests/" + variantManifestDigest: variantManifestContent,
+		"blobs/" + configDigest:              configContent,
+		"blobs/" + variantConfigDigest:       variantConfigContent,
+	}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := content[strings.TrimPrefix(r.URL.Path, "/api/docker/docker-remote/v2/library/app/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	return
+}
+
+func TestVerifyPulledImage(t *testing.T) {
+	server, indexDigest, manifestDigest, configDigest := createTestImageRegistry(t)
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, -1, 0, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		image         string
+		imageId       string
+		diffIds       []string
+		expectedError string
+	}{
+		{"matching image", "acme.jfrog.io/docker-remote/library/app:1.0", configDigest, []string{"sha256:d1", "sha256:d2"}, ""},
+		{"matching image by digest", "acme.jfrog.io/docker-remote/library/app@" + indexDigest, strings.TrimPrefix(configDigest, sha256Prefix), []string{"sha256:d1", "sha256:d2"}, ""},
+		{"containerd image store by fat-manifest", "acme.jfrog.io/docker-remote/library/app:1.0", indexDigest, []string{"sha256:d1", "sha256:d2"}, ""},
+		{"containerd image store by manifest", "acme.jfrog.io/docker-remote/library/app:1.0", manifestDigest, []string{"sha256:d1", "sha256:d2"}, ""},
+		{"different image ID", "acme.jfrog.io/docker-remote/library/app:1.0", "sha256:other", []string{"sha256:d1", "sha256:d2"}, "doesn't match the config digest"},
+		{"different layer", "acme.jfrog.io/docker-remote/library/app:1.0", configDigest, []string{"sha256:d1", "sha256:other"}, "layer 1 of the local image"},
+		{"missing layer", "acme.jfrog.io/docker-remote/library/app:1.0", configDigest, []string{"sha256:d1"}, "has 1 layers"},
+		{"missing image", "acme.jfrog.io/docker-remote/library/app:2.0", configDigest, nil, "could not find image"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			containerManager := &testContainerManager{imageId: tt.imageId, diffIds: tt.diffIds}
+			resolved, err := VerifyPulledImage(NewImage(tt.image), "docker-remote", containerManager, serviceManager)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, indexDigest, resolved.Digest)
+			assert.Equal(t, manifestDigest, resolved.ManifestDigest)
+			assert.Equal(t, configDigest, resolved.ConfigDigest)
+		})
+	}
+}
+
+// A container manager which can't list the layers of local images.
+type noDiffIdsContainerManager struct {
+	ContainerManager
+}
+
+func TestVerifyPulledImageUnsupported(t *testing.T) {
+	_, err := VerifyPulledImage(NewImage("acme.jfrog.io/docker-remote/library/app:1.0"), "docker-remote", noDiffIdsContainerManager{&testContainerManager{}}, nil)
+	assert.ErrorContains(t, err, "verifying pulled images isn't supported by docker")
+}
+
+func TestGetImagePathCandidates(t *testing.T) {
+	candidates, err := getImagePathCandidates(NewImage("acme.jfrog.io/docker-remote/library/app:1.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"library/app", "docker-remote/library/app"}, candidates)
+	candidates, err = getImagePathCandidates(NewImage("docker-remote.acme.jfrog.io/app:1.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app"}, candidates)
+}
@@ -0,0 +1,266 @@
+package ocicontainer
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	blobUploadChunkSize = 16 * 1024 * 1024
+	// The number of times an interrupted blob upload is resumed from the last offset accepted by Artifactory.
+	blobUploadRetries = 3
+)
+
+// Push images to Artifactory through the Docker registry API, without a container daemon.
+type registryClient struct {
+	serviceManager artifactory.ArtifactoryServicesManager
+	// <artifactory url>/api/docker/<repo>/v2/
+	registryApiUrl string
+	// <artifactory url>/api/docker/<repo>/v2/<image>/
+	imageApiUrl string
+}
+
+func newRegistryClient(repo, imageName string, serviceManager artifactory.ArtifactoryServicesManager) *registryClient {
+	registryApiUrl := serviceManager.GetConfig().GetServiceDetails().GetUrl() + "api/docker/" + repo + "/v2/"
+	return &registryClient{serviceManager: serviceManager, registryApiUrl: registryApiUrl, imageApiUrl: registryApiUrl + imageName + "/"}
+}
+
+// PushLayoutImage pushes the image read from an OCI image layout or a 'docker save' tarball to the repository, and tags it.
+// Blobs which already exist in the repository are skipped, and the others are uploaded in parallel.
+// Referenced manifests are pushed by digest before the lead manifest is pushed with the tag.
+func PushLayoutImage(image *LayoutImage, repo, imageName, tag string, threads int, serviceManager artifactory.ArtifactoryServicesManager) error {
+	client := newRegistryClient(repo, imageName, serviceManager)
+	if err := client.pushBlobs(image.Blobs, threads); err != nil {
+		return err
+	}
+	for _, manifest := range image.ReferencedManifests {
+		if err := client.pushManifest(manifest.Digest, manifest); err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("Pushing %s:%s (%s) to %s", imageName, tag, image.Lead.Digest, repo))
+	return client.pushManifest(tag, image.Lead)
+}
+
+func (rc *registryClient) pushBlobs(blobs []LayoutBlob, threads int) error {
+	if threads < 1 {
+		threads = 1
+	}
+	producerConsumer := parallel.NewRunner(threads, uint(len(blobs)), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for _, blob := range blobs {
+			_, _ = producerConsumer.AddTaskWithError(func(int) error {
+				return rc.pushBlob(blob)
+			}, errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+func (rc *registryClient) createHttpClientDetails(headers map[string]string) httputils.HttpClientDetails {
+	httpClientDetails := rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	for key, value := range headers {
+		httpClientDetails.Headers[key] = value
+	}
+	return httpClientDetails
+}
+
+func (rc *registryClient) blobExists(digest string) (bool, error) {
+	httpClientDetails := rc.createHttpClientDetails(nil)
+	resp, _, err := rc.serviceManager.Client().SendHead(rc.imageApiUrl+"blobs/"+digest, &httpClientDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, errorutils.CheckErrorf("failed to check whether blob %s exists. Artifactory response: %s", digest, resp.Status)
+}
+
+// Upload the blob in chunks. If a chunk fails, the upload is resumed from the offset reported by Artifactory.
+func (rc *registryClient) pushBlob(blob LayoutBlob) (err error) {
+	exists, err := rc.blobExists(blob.Digest)
+	if err != nil || exists {
+		if exists {
+			log.Debug("Blob " + blob.Digest + " already exists")
+		}
+		return err
+	}
+	log.Info(fmt.Sprintf("Uploading blob %s (%d bytes)", blob.Digest, blob.Size))
+	location, err := rc.startBlobUpload()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(blob.LocalPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	buffer := make([]byte, min(blobUploadChunkSize, max(blob.Size, 1)))
+	var offset int64
+	retries := 0
+	for offset < blob.Size {
+		n, readErr := file.ReadAt(buffer, offset)
+		if readErr != nil && readErr != io.EOF {
+			return errorutils.CheckError(readErr)
+		}
+		if n == 0 {
+			return errorutils.CheckErrorf("blob %s is shorter than its expected size %d", blob.Digest, blob.Size)
+		}
+		nextLocation, chunkErr := rc.uploadChunk(location, buffer[:n], offset)
+		if chunkErr == nil {
+			location = nextLocation
+			offset += int64(n)
+			retries = 0
+			continue
+		}
+		if retries >= blobUploadRetries {
+			return chunkErr
+		}
+		retries++
+		log.Warn(fmt.Sprintf("Failed to upload a chunk of blob %s: %s. Resuming the upload (attempt %d/%d)...", blob.Digest, chunkErr.Error(), retries, blobUploadRetries))
+		if offset, err = rc.getUploadOffset(location); err != nil {
+			return err
+		}
+	}
+	return rc.completeBlobUpload(location, blob.Digest)
+}
+
+func (rc *registryClient) startBlobUpload() (string, error) {
+	httpClientDetails := rc.createHttpClientDetails(nil)
+	resp, _, err := rc.serviceManager.Client().SendPost(rc.imageApiUrl+"blobs/uploads/", nil, &httpClientDetails)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return "", errorutils.CheckErrorf("failed to start a blob upload. Artifactory response: %s", resp.Status)
+	}
+	return rc.resolveLocation(resp.Header.Get("Location"))
+}
+
+func (rc *registryClient) uploadChunk(location string, chunk []byte, offset int64) (string, error) {
+	httpClientDetails := rc.createHttpClientDetails(map[string]string{
+		"Content-Type":  "application/octet-stream",
+		"Content-Range": getContentRange(offset, len(chunk)),
+	})
+	resp, _, err := rc.serviceManager.Client().SendPatch(location, chunk, &httpClientDetails)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return "", errorutils.CheckErrorf("Artifactory response: %s", resp.Status)
+	}
+	return rc.resolveLocation(resp.Header.Get("Location"))
+}
+
+// Get the offset to resume the upload from, by the range of bytes Artifactory already received.
+func (rc *registryClient) getUploadOffset(location string) (int64, error) {
+	httpClientDetails := rc.createHttpClientDetails(nil)
+	resp, _, _, err := rc.serviceManager.Client().SendGet(location, true, &httpClientDetails)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return 0, errorutils.CheckErrorf("failed to get the status of the blob upload. Artifactory response: %s", resp.Status)
+	}
+	return parseUploadedRange(resp.Header.Get("Range"))
+}
+
+func (rc *registryClient) completeBlobUpload(location, digest string) error {
+	separator := "?"
+	if strings.Contains(location, "?") {
+		separator = "&"
+	}
+	httpClientDetails := rc.createHttpClientDetails(map[string]string{"Content-Type": "application/octet-stream"})
+	resp, _, err := rc.serviceManager.Client().SendPut(location+separator+"digest="+url.QueryEscape(digest), nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return errorutils.CheckErrorf("failed to complete the upload of blob %s. Artifactory response: %s", digest, resp.Status)
+	}
+	return nil
+}
+
+// Push the manifest with the given reference, which is either a tag or the manifest digest.
+func (rc *registryClient) pushManifest(reference string, manifest LayoutManifest) error {
+	log.Debug("Pushing manifest " + manifest.Digest + " as " + reference)
+	httpClientDetails := rc.createHttpClientDetails(map[string]string{"Content-Type": manifest.MediaType})
+	resp, body, err := rc.serviceManager.Client().SendPut(rc.imageApiUrl+"manifests/"+reference, manifest.Content, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("failed to push manifest %s. Artifactory response: %s\n%s", manifest.Digest, resp.Status, clientutils.IndentJson(body))
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" && digest != manifest.Digest {
+		return errorutils.CheckErrorf("manifest %s was stored with a different digest %s", manifest.Digest, digest)
+	}
+	return nil
+}
+
+// Resolve the upload location returned by Artifactory. Relative locations of the registry API (/v2/...) are resolved
+// against the Docker API of the repository, and other relative locations against the Artifactory URL.
+func (rc *registryClient) resolveLocation(location string) (string, error) {
+	if location == "" {
+		return "", errorutils.CheckErrorf("the blob upload location is missing from the Artifactory response")
+	}
+	locationUrl, err := url.Parse(location)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if locationUrl.IsAbs() {
+		return location, nil
+	}
+	if strings.HasPrefix(location, "/v2/") {
+		return rc.registryApiUrl + strings.TrimPrefix(location, "/v2/"), nil
+	}
+	baseUrl, err := url.Parse(rc.registryApiUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return baseUrl.ResolveReference(locationUrl).String(), nil
+}
+
+// The Content-Range of a chunk is the inclusive range of its bytes: <first>-<last>
+func getContentRange(offset int64, length int) string {
+	return fmt.Sprintf("%d-%d", offset, offset+int64(length)-1)
+}
+
+// Parse the Range header of a blob upload status: 0-<last received byte>. No header means nothing was received.
+func parseUploadedRange(rangeHeader string) (int64, error) {
+	if rangeHeader == "" {
+		return 0, nil
+	}
+	_, last, found := strings.Cut(strings.TrimPrefix(rangeHeader, "bytes="), "-")
+	if !found {
+		return 0, errorutils.CheckErrorf("unexpected blob upload range: %s", rangeHeader)
+	}
+	lastByte, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("unexpected blob upload range: %s", rangeHeader)
+	}
+	return lastByte + 1, nil
+}
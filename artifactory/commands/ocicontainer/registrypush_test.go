@@ -0,0 +1,157 @@
+package ocicontainer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLocation(t *testing.T) {
+	client := &registryClient{registryApiUrl: "https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/"}
+	tests := []struct {
+		location string
+		expected string
+	}{
+		{"https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/app/blobs/uploads/123", "https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/app/blobs/uploads/123"},
+		{"/v2/app/blobs/uploads/123?state=1", "https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/app/blobs/uploads/123?state=1"},
+		{"/artifactory/api/docker/docker-local/v2/app/blobs/uploads/123", "https://acme.jfrog.io/artifactory/api/docker/docker-local/v2/app/blobs/uploads/123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			actual, err := client.resolveLocation(tt.location)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+	_, err := client.resolveLocation("")
+	assert.Error(t, err)
+}
+
+func TestParseUploadedRange(t *testing.T) {
+	offset, err := parseUploadedRange("0-1023")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), offset)
+	offset, err = parseUploadedRange("bytes=0-9")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), offset)
+	offset, err = parseUploadedRange("")
+	require.NoError(t, err)
+	assert.Zero(t, offset)
+	_, err = parseUploadedRange("invalid")
+	assert.Error(t, err)
+	assert.Equal(t, "100-149", getContentRange(100, 50))
+}
+
+// A minimal registry, which fails the first chunk of every upload after receiving half of it.
+// Chunks which don't continue the received bytes are rejected.
+type testRegistry struct {
+	mutex     sync.Mutex
+	blobs     map[string][]byte
+	uploads   map[string][]byte
+	manifests map[string]string
+	failed    map[string]bool
+}
+
+func (registry *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	const prefix = "/api/docker/docker-local/v2/app/"
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+	switch {
+	case r.Method == http.MethodHead && strings.HasPrefix(path, "blobs/"):
+		if _, ok := registry.blobs[strings.TrimPrefix(path, "blobs/")]; ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodPost && path == "blobs/uploads/":
+		id := string(rune('a' + len(registry.uploads)))
+		registry.uploads[id] = nil
+		w.Header().Set("Location", "/v2/app/blobs/uploads/"+id)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch:
+		id := strings.TrimPrefix(path, "blobs/uploads/")
+		chunk, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Range") != getContentRange(int64(len(registry.uploads[id])), len(chunk)) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if !registry.failed[id] {
+			registry.failed[id] = true
+			registry.uploads[id] = append(registry.uploads[id], chunk[:len(chunk)/2]...)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		registry.uploads[id] = append(registry.uploads[id], chunk...)
+		w.Header().Set("Location", r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "blobs/uploads/"):
+		uploaded := registry.uploads[strings.TrimPrefix(path, "blobs/uploads/")]
+		if len(uploaded) > 0 {
+			w.Header().Set("Range", "0-"+strconv.Itoa(len(uploaded)-1))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "blobs/uploads/"):
+		id := strings.TrimPrefix(path, "blobs/uploads/")
+		digest := r.URL.Query().Get("digest")
+		if digest != sha256Prefix+getSha256(registry.uploads[id]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		registry.blobs[digest] = registry.uploads[id]
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "manifests/"):
+		content, _ := io.ReadAll(r.Body)
+		registry.manifests[strings.TrimPrefix(path, "manifests/")] = r.Header.Get("Content-Type")
+		w.Header().Set("Docker-Content-Digest", sha256Prefix+getSha256(content))
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestPushLayoutImage(t *testing.T) {
+	existingLayer := []byte("existing layer")
+	registry := &testRegistry{
+		blobs:     map[string][]byte{sha256Prefix + getSha256(existingLayer): existingLayer},
+		uploads:   map[string][]byte{},
+		manifests: map[string]string{},
+		failed:    map[string]bool{},
+	}
+	testServer := httptest.NewServer(registry)
+	defer testServer.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}, -1, 0, false)
+	require.NoError(t, err)
+
+	blobsDir := t.TempDir()
+	var blobs []LayoutBlob
+	for i, content := range [][]byte{existingLayer, []byte(`{"os":"linux"}`), []byte("new layer content")} {
+		localPath := filepath.Join(blobsDir, strconv.Itoa(i))
+		require.NoError(t, os.WriteFile(localPath, content, 0644))
+		blobs = append(blobs, LayoutBlob{Digest: sha256Prefix + getSha256(content), Size: int64(len(content)), LocalPath: localPath})
+	}
+	manifestContent := []byte(`{"schemaVersion":2}`)
+	image := &LayoutImage{
+		Blobs: blobs,
+		Lead:  LayoutManifest{Digest: sha256Prefix + getSha256(manifestContent), MediaType: ociImageManifestMediaType, Content: manifestContent},
+	}
+
+	require.NoError(t, PushLayoutImage(image, "docker-local", "app", "1.0", 2, serviceManager))
+	for _, blob := range blobs {
+		assert.Contains(t, registry.blobs, blob.Digest)
+	}
+	// The existing blob is not uploaded again.
+	assert.Len(t, registry.uploads, 2)
+	assert.Equal(t, map[string]string{"1.0": ociImageManifestMediaType}, registry.manifests)
+}
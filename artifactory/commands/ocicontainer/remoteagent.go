@@ -1,6 +1,7 @@
 package ocicontainer
 
 import (
+	"fmt"
 	"strings"
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
@@ -14,6 +15,8 @@ import (
 type RemoteAgentBuildInfoBuilder struct {
 	buildInfoBuilder *buildInfoBuilder
 	manifestSha2     string
+	// The base images of the Dockerfile, recorded as dependencies of the image.
+	baseImages []DockerImage
 }
 
 func NewRemoteAgentBuildInfoBuilder(image *Image, repository, buildName, buildNumber, project string, serviceManager artifactory.ArtifactoryServicesManager, manifestSha256 string) (*RemoteAgentBuildInfoBuilder, error) {
@@ -28,7 +31,52 @@ func (rabib *RemoteAgentBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
 	return &rabib.buildInfoBuilder.imageLayers
 }
 
+// If set, the SBOM and provenance predicates of the image's attestation manifests are deployed as JSON files next to them.
+func (rabib *RemoteAgentBuildInfoBuilder) SetExtractAttestations(extractAttestations bool) {
+	rabib.buildInfoBuilder.extractAttestations = extractAttestations
+}
+
+// If set, the signatures of the image manifests are recorded as artifacts of the image.
+func (rabib *RemoteAgentBuildInfoBuilder) SetIncludeSignatures(includeSignatures bool) {
+	rabib.buildInfoBuilder.includeSignatures = includeSignatures
+}
+
+// Set the base images of the Dockerfile the image was built from, to record them as dependencies of the image.
+func (rabib *RemoteAgentBuildInfoBuilder) SetBaseImages(baseImages []DockerImage) {
+	rabib.baseImages = baseImages
+}
+
 func (rabib *RemoteAgentBuildInfoBuilder) Build(module string) (*buildinfo.BuildInfo, error) {
+	buildInfo, err := rabib.build(module)
+	if err != nil || buildInfo == nil || len(buildInfo.Modules) == 0 || len(rabib.baseImages) == 0 {
+		return buildInfo, err
+	}
+	dependencies, err := NewDockerDependenciesBuilder(rabib.baseImages, rabib.buildInfoBuilder.serviceManager).getDependencies()
+	if err != nil {
+		// Just warn, as a docker build does when failing to collect the base images.
+		log.Warn(fmt.Sprintf("Failed to get the base images dependencies of '%s'. Error: %v", rabib.buildInfoBuilder.image.Name(), err))
+		return buildInfo, nil
+	}
+	addDependenciesIfAbsent(&buildInfo.Modules[0], dependencies)
+	return buildInfo, nil
+}
+
+// Add dependencies to the module, skipping layers which are already recorded.
+func addDependenciesIfAbsent(module *buildinfo.Module, dependencies []buildinfo.Dependency) {
+	existing := make(map[string]bool, len(module.Dependencies))
+	for _, dependency := range module.Dependencies {
+		existing[dependency.Sha256] = true
+	}
+	for _, dependency := range dependencies {
+		if dependency.Sha256 != "" && existing[dependency.Sha256] {
+			continue
+		}
+		existing[dependency.Sha256] = true
+		module.Dependencies = append(module.Dependencies, dependency)
+	}
+}
+
+func (rabib *RemoteAgentBuildInfoBuilder) build(module string) (*buildinfo.BuildInfo, error) {
 	// Search for and image in Artifactory.
 	results, err := rabib.searchImage()
 	if err != nil {
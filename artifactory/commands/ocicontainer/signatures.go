@@ -0,0 +1,142 @@
+package ocicontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Cosign pushes the signature of an image as a tag named after the image digest: sha256-<hex>.sig
+	cosignSignatureTagSuffix = ".sig"
+	// Notation pushes the signature of an image as an OCI 1.1 referrer of the image manifest.
+	notationSignatureArtifactType = "application/vnd.cncf.notary.signature"
+	// Cosign pushes the signature of an image as an OCI 1.1 referrer if the new bundle format is used.
+	sigstoreBundleArtifactTypePrefix = "application/vnd.dev.sigstore.bundle"
+)
+
+// To unmarshal the image index returned by the referrers API, or stored in the referrers tag.
+type referrersIndex struct {
+	Manifests []referrerDescriptor `json:"manifests"`
+}
+
+type referrerDescriptor struct {
+	Digest       string `json:"digest"`
+	ArtifactType string `json:"artifactType"`
+}
+
+// Digest of type sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e to
+// sha256-30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e
+func digestToReferrersTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
+
+func digestToCosignSignatureTag(digest string) string {
+	return digestToReferrersTag(digest) + cosignSignatureTagSuffix
+}
+
+func isSignatureArtifactType(artifactType string) bool {
+	return artifactType == notationSignatureArtifactType || strings.HasPrefix(artifactType, sigstoreBundleArtifactTypePrefix)
+}
+
+// Search for the cosign and Notation signatures of the image manifest with the given digest.
+// imagePath is the path of the image in the repository, without the tag.
+// Return the files of the signature manifests found in Artifactory.
+func SearchImageSignatures(repo, imagePath, digest string, serviceManager artifactory.ArtifactoryServicesManager) ([]utils.ResultItem, error) {
+	log.Debug("Searching for the signatures of image " + path.Join(repo, imagePath) + "@" + digest)
+	signatures, err := searchFolderFiles(path.Join(repo, imagePath, digestToCosignSignatureTag(digest)), serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	referrers, err := getImageReferrers(repo, imagePath, digest, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	for _, referrer := range referrers.Manifests {
+		if !isSignatureArtifactType(referrer.ArtifactType) {
+			continue
+		}
+		files, err := searchFolderFiles(path.Join(repo, imagePath, digestToLayer(referrer.Digest)), serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			log.Debug("Signature " + referrer.Digest + " was not found in Artifactory")
+		}
+		signatures = append(signatures, files...)
+	}
+	return signatures, nil
+}
+
+// Get the referrers of the image manifest using the OCI 1.1 referrers API.
+// If the API isn't available, fall back to the referrers tag schema.
+func getImageReferrers(repo, imagePath, digest string, serviceManager artifactory.ArtifactoryServicesManager) (*referrersIndex, error) {
+	serviceDetails := serviceManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	url := serviceDetails.GetUrl() + "api/docker/" + repo + "/v2/" + imagePath + "/referrers/" + digest
+	resp, body, _, err := serviceManager.Client().SendGet(url, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	referrers := &referrersIndex{}
+	if resp.StatusCode == http.StatusOK {
+		return referrers, errorutils.CheckError(json.Unmarshal(body, referrers))
+	}
+	log.Debug(fmt.Sprintf("The referrers API responded with HTTP %d. Searching for the referrers tag.", resp.StatusCode))
+	files, err := performSearch(path.Join(repo, imagePath, digestToReferrersTag(digest), "*"), serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	if index, ok := files["list.manifest.json"]; ok {
+		err = downloadLayer(*index, referrers, serviceManager, repo)
+	}
+	return referrers, err
+}
+
+// Return the files in the folder, sorted by name.
+func searchFolderFiles(folderPath string, serviceManager artifactory.ArtifactoryServicesManager) ([]utils.ResultItem, error) {
+	resultMap, err := performSearch(folderPath+"/*", serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]utils.ResultItem, 0, len(resultMap))
+	for _, item := range resultMap {
+		files = append(files, *item)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
+
+// Record the signatures of the image manifest as artifacts, so they are promoted along with the image.
+// Signatures are collected only when requested. Failing to search for the signatures doesn't fail the build-info collection.
+func (builder *buildInfoBuilder) collectSignatures(manifestItem *utils.ResultItem) []buildinfo.Artifact {
+	if !builder.includeSignatures || manifestItem == nil || manifestItem.Sha256 == "" {
+		return nil
+	}
+	digest := "sha256:" + manifestItem.Sha256
+	signatures, err := SearchImageSignatures(builder.getSearchableRepo(), path.Dir(manifestItem.Path), digest, builder.serviceManager)
+	if err != nil {
+		log.Warn("Failed to search for the signatures of image " + digest + ": " + err.Error())
+		return nil
+	}
+	var artifacts []buildinfo.Artifact
+	for i := range signatures {
+		builder.imageLayers = append(builder.imageLayers, signatures[i])
+		artifacts = append(artifacts, signatures[i].ToArtifact())
+	}
+	if len(artifacts) > 0 {
+		log.Info(fmt.Sprintf("Found %d signature files of image %s", len(artifacts), digest))
+	}
+	return artifacts
+}
@@ -0,0 +1,28 @@
+package ocicontainer
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestToSignatureTags(t *testing.T) {
+	digest := "sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e"
+	assert.Equal(t, "sha256-30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e", digestToReferrersTag(digest))
+	assert.Equal(t, "sha256-30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e.sig", digestToCosignSignatureTag(digest))
+}
+
+func TestIsSignatureArtifactType(t *testing.T) {
+	assert.True(t, isSignatureArtifactType("application/vnd.cncf.notary.signature"))
+	assert.True(t, isSignatureArtifactType("application/vnd.dev.sigstore.bundle.v0.3+json"))
+	assert.False(t, isSignatureArtifactType("application/spdx+json"))
+	assert.False(t, isSignatureArtifactType(""))
+}
+
+func TestCollectSignaturesNotRequested(t *testing.T) {
+	// Without a services manager, any search for the signatures would fail the test.
+	builder := &buildInfoBuilder{}
+	assert.Nil(t, builder.collectSignatures(&utils.ResultItem{Path: "app/1.0.0", Name: ManifestJsonFile, Sha256: "abc"}))
+	assert.Empty(t, builder.imageLayers)
+}
@@ -0,0 +1,480 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type AccessReportFormat string
+
+const (
+	AccessReportTable AccessReportFormat = "table"
+	AccessReportJson  AccessReportFormat = "json"
+
+	// The repositories keywords of the permission target sections.
+	anyRepository       = "ANY"
+	anyLocalRepository  = "ANY LOCAL"
+	anyRemoteRepository = "ANY REMOTE"
+
+	userPrincipalPrefix  = "user:"
+	groupPrincipalPrefix = "group:"
+)
+
+// The order in which the actions are displayed. Each of the first five actions includes the ones before it,
+// the same as when they are selected in the Artifactory UI.
+var orderedActions = []string{read, annotate, write, delete, manage, managedXrayMeta, distribute}
+
+func GetAccessReportFormat(format string) (AccessReportFormat, error) {
+	switch AccessReportFormat(format) {
+	case "", AccessReportTable:
+		return AccessReportTable, nil
+	case AccessReportJson:
+		return AccessReportJson, nil
+	}
+	return "", errorutils.CheckErrorf("invalid output format '%s'. The valid values are: table and json", format)
+}
+
+// AccessGrant is a single permission target entry which grants actions to the reported user, group or repository.
+type AccessGrant struct {
+	PermissionTarget string   `json:"permissionTarget"`
+	Section          string   `json:"section"`
+	Principal        string   `json:"principal"`
+	Repositories     []string `json:"repositories"`
+	IncludePatterns  []string `json:"includePatterns,omitempty"`
+	ExcludePatterns  []string `json:"excludePatterns,omitempty"`
+	Actions          []string `json:"actions"`
+}
+
+// EffectiveAccess is the union of the actions granted to a principal on a repository.
+// Partial actions are granted only on the paths matching the include and exclude patterns.
+type EffectiveAccess struct {
+	Principal      string   `json:"principal"`
+	Repository     string   `json:"repository"`
+	Actions        []string `json:"actions"`
+	PartialActions []string `json:"partialActions,omitempty"`
+}
+
+type AccessReport struct {
+	Grants    []AccessGrant     `json:"grants"`
+	Effective []EffectiveAccess `json:"effective"`
+}
+
+// AccessReportCommand computes the effective actions granted across all the permission targets,
+// to a user (directly or through its groups), to a group, or on a repository.
+type AccessReportCommand struct {
+	serverDetails *config.ServerDetails
+	user          string
+	group         string
+	// A repository key, optionally followed by a path in the repository.
+	repo   string
+	format AccessReportFormat
+	report *AccessReport
+}
+
+func NewAccessReportCommand() *AccessReportCommand {
+	return &AccessReportCommand{format: AccessReportTable}
+}
+
+func (arc *AccessReportCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessReportCommand {
+	arc.serverDetails = serverDetails
+	return arc
+}
+
+func (arc *AccessReportCommand) SetUser(user string) *AccessReportCommand {
+	arc.user = user
+	return arc
+}
+
+func (arc *AccessReportCommand) SetGroup(group string) *AccessReportCommand {
+	arc.group = group
+	return arc
+}
+
+func (arc *AccessReportCommand) SetRepo(repo string) *AccessReportCommand {
+	arc.repo = repo
+	return arc
+}
+
+func (arc *AccessReportCommand) SetFormat(format AccessReportFormat) *AccessReportCommand {
+	arc.format = format
+	return arc
+}
+
+func (arc *AccessReportCommand) Report() *AccessReport {
+	return arc.report
+}
+
+func (arc *AccessReportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return arc.serverDetails, nil
+}
+
+func (arc *AccessReportCommand) CommandName() string {
+	return "rt_access_report"
+}
+
+func (arc *AccessReportCommand) Run() error {
+	if arc.user == "" && arc.group == "" && arc.repo == "" {
+		return errorutils.CheckErrorf("a user, a group or a repository must be provided")
+	}
+	if arc.user != "" && arc.group != "" {
+		return errorutils.CheckErrorf("a user and a group can't be provided together")
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(arc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	permissionTargets, err := getPermissionTargets(servicesManager, nil)
+	if err != nil {
+		return err
+	}
+	resolver, err := newAccessResolver(servicesManager, arc.user, arc.group, arc.repo)
+	if err != nil {
+		return err
+	}
+	if arc.report, err = resolver.getAccessReport(permissionTargets); err != nil {
+		return err
+	}
+	return arc.printReport()
+}
+
+func (arc *AccessReportCommand) printReport() error {
+	if arc.format == AccessReportJson {
+		content, err := json.MarshalIndent(arc.report, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	grantsTable := table.NewWriter()
+	grantsTable.SetTitle("Grants")
+	grantsTable.AppendHeader(table.Row{"Permission target", "Section", "Principal", "Repositories", "Include patterns", "Exclude patterns", "Actions"})
+	for _, grant := range arc.report.Grants {
+		grantsTable.AppendRow(table.Row{grant.PermissionTarget, grant.Section, grant.Principal, strings.Join(grant.Repositories, ","),
+			strings.Join(grant.IncludePatterns, ","), strings.Join(grant.ExcludePatterns, ","), strings.Join(grant.Actions, ",")})
+	}
+	effectiveTable := table.NewWriter()
+	effectiveTable.SetTitle("Effective access")
+	effectiveTable.AppendHeader(table.Row{"Principal", "Repository", "Actions", "Partial actions"})
+	for _, access := range arc.report.Effective {
+		effectiveTable.AppendRow(table.Row{access.Principal, access.Repository, strings.Join(access.Actions, ","), strings.Join(access.PartialActions, ",")})
+	}
+	log.Output(grantsTable.Render() + "\n" + effectiveTable.Render())
+	return nil
+}
+
+type accessResolver struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	user            string
+	group           string
+	repoKey         string
+	repoPath        string
+	// The groups of the reported user.
+	userGroups []string
+	// The members of the groups which have grants on the reported repository, by group name.
+	groupMembers map[string][]string
+	// The class (local, remote, virtual, ...) of the repositories, fetched when a section uses the "ANY LOCAL" or "ANY REMOTE" keywords.
+	repoClasses map[string]string
+}
+
+func newAccessResolver(servicesManager artifactory.ArtifactoryServicesManager, user, group, repo string) (*accessResolver, error) {
+	resolver := &accessResolver{servicesManager: servicesManager, user: user, group: group, groupMembers: map[string][]string{}}
+	resolver.repoKey, resolver.repoPath, _ = strings.Cut(repo, "/")
+	if user == "" {
+		return resolver, nil
+	}
+	userDetails, err := servicesManager.GetUser(services.UserParams{UserDetails: services.User{Name: user}})
+	if err != nil {
+		return nil, err
+	}
+	if userDetails == nil {
+		return nil, errorutils.CheckErrorf("user '%s' does not exist", user)
+	}
+	if userDetails.Groups != nil {
+		resolver.userGroups = *userDetails.Groups
+	}
+	return resolver, nil
+}
+
+func (ar *accessResolver) getAccessReport(permissionTargets []*services.PermissionTargetParams) (*AccessReport, error) {
+	report := &AccessReport{}
+	effective := map[[2]string]*effectiveActions{}
+	for _, permissionTarget := range permissionTargets {
+		sections := []struct {
+			key     string
+			section *services.PermissionTargetSection
+		}{{Repo, permissionTarget.Repo}, {Build, permissionTarget.Build}, {ReleaseBundle, permissionTarget.ReleaseBundle}}
+		for _, section := range sections {
+			if section.section == nil || section.section.Actions == nil {
+				continue
+			}
+			repositories, err := ar.getMatchingRepositories(section.section)
+			if err != nil {
+				return nil, err
+			}
+			if len(repositories) == 0 || !ar.isPathIncluded(section.section) {
+				continue
+			}
+			partial := ar.repoPath == "" && isRestrictedByPatterns(section.section)
+			grants, err := ar.getGrants(section.section.Actions)
+			if err != nil {
+				return nil, err
+			}
+			for _, grant := range grants {
+				grant.PermissionTarget, grant.Section = permissionTarget.Name, section.key
+				grant.Repositories, grant.IncludePatterns, grant.ExcludePatterns = section.section.Repositories, section.section.IncludePatterns, section.section.ExcludePatterns
+				report.Grants = append(report.Grants, grant.AccessGrant)
+				for _, principal := range grant.effectivePrincipals {
+					for _, repository := range repositories {
+						key := [2]string{principal, repository}
+						if effective[key] == nil {
+							effective[key] = &effectiveActions{full: map[string]bool{}, partial: map[string]bool{}}
+						}
+						effective[key].add(grant.Actions, partial)
+					}
+				}
+			}
+		}
+	}
+	for key, actions := range effective {
+		report.Effective = append(report.Effective, EffectiveAccess{Principal: key[0], Repository: key[1], Actions: actions.getActions(), PartialActions: actions.getPartialActions()})
+	}
+	sort.Slice(report.Effective, func(i, j int) bool {
+		if report.Effective[i].Principal != report.Effective[j].Principal {
+			return report.Effective[i].Principal < report.Effective[j].Principal
+		}
+		return report.Effective[i].Repository < report.Effective[j].Repository
+	})
+	return report, nil
+}
+
+// Returns the repositories on which the section grants actions, as the reported repository if provided,
+// or as the section's repositories otherwise. Returns nothing if the section doesn't apply to the reported repository.
+func (ar *accessResolver) getMatchingRepositories(section *services.PermissionTargetSection) ([]string, error) {
+	if ar.repoKey == "" {
+		return section.Repositories, nil
+	}
+	for _, repository := range section.Repositories {
+		switch repository {
+		case ar.repoKey, anyRepository:
+			return []string{ar.repoKey}, nil
+		case anyLocalRepository, anyRemoteRepository:
+			repoClass, err := ar.getRepoClass()
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(repository, "ANY "+repoClass) {
+				return []string{ar.repoKey}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (ar *accessResolver) getRepoClass() (string, error) {
+	if ar.repoClasses == nil {
+		repositories, err := ar.servicesManager.GetAllRepositories()
+		if err != nil {
+			return "", err
+		}
+		ar.repoClasses = map[string]string{}
+		for _, repository := range *repositories {
+			ar.repoClasses[repository.Key] = repository.Rclass
+		}
+	}
+	return ar.repoClasses[ar.repoKey], nil
+}
+
+// If a path in the repository is reported, the section applies to it only if it matches the include patterns and none of the exclude patterns.
+func (ar *accessResolver) isPathIncluded(section *services.PermissionTargetSection) bool {
+	if ar.repoPath == "" {
+		return true
+	}
+	for _, pattern := range section.ExcludePatterns {
+		if matchAntPattern(pattern, ar.repoPath) {
+			return false
+		}
+	}
+	if len(section.IncludePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range section.IncludePatterns {
+		if matchAntPattern(pattern, ar.repoPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRestrictedByPatterns(section *services.PermissionTargetSection) bool {
+	return len(section.ExcludePatterns) > 0 || len(section.IncludePatterns) > 0 && !slices.Contains(section.IncludePatterns, IncludePatternsDefault)
+}
+
+type resolvedGrant struct {
+	AccessGrant
+	// The principals to which the grant applies in the effective access.
+	// A group grant applies to the reported user if it's a member, and to all of the members if a repository is reported.
+	effectivePrincipals []string
+}
+
+func (ar *accessResolver) getGrants(actions *services.Actions) ([]resolvedGrant, error) {
+	var grants []resolvedGrant
+	for _, user := range sortedKeys(actions.Users) {
+		if ar.group != "" || ar.user != "" && user != ar.user {
+			continue
+		}
+		principal := userPrincipalPrefix + user
+		grants = append(grants, resolvedGrant{AccessGrant{Principal: principal, Actions: expandActions(actions.Users[user])}, []string{principal}})
+	}
+	for _, group := range sortedKeys(actions.Groups) {
+		principal := groupPrincipalPrefix + group
+		grant := resolvedGrant{AccessGrant: AccessGrant{Principal: principal, Actions: expandActions(actions.Groups[group])}}
+		switch {
+		case ar.user != "":
+			if !slices.Contains(ar.userGroups, group) {
+				continue
+			}
+			grant.effectivePrincipals = []string{userPrincipalPrefix + ar.user}
+		case ar.group != "":
+			if group != ar.group {
+				continue
+			}
+			grant.effectivePrincipals = []string{principal}
+		default:
+			members, err := ar.getGroupMembers(group)
+			if err != nil {
+				return nil, err
+			}
+			grant.effectivePrincipals = []string{principal}
+			for _, member := range members {
+				grant.effectivePrincipals = append(grant.effectivePrincipals, userPrincipalPrefix+member)
+			}
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+func (ar *accessResolver) getGroupMembers(group string) ([]string, error) {
+	if members, ok := ar.groupMembers[group]; ok {
+		return members, nil
+	}
+	groupDetails, err := ar.servicesManager.GetGroup(services.GroupParams{GroupDetails: services.Group{Name: group}, IncludeUsers: true})
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	if groupDetails != nil {
+		members = groupDetails.UsersNames
+	}
+	ar.groupMembers[group] = members
+	return members, nil
+}
+
+type effectiveActions struct {
+	full    map[string]bool
+	partial map[string]bool
+}
+
+func (ea *effectiveActions) add(actions []string, partial bool) {
+	for _, action := range actions {
+		if partial {
+			ea.partial[action] = true
+		} else {
+			ea.full[action] = true
+		}
+	}
+}
+
+func (ea *effectiveActions) getActions() []string {
+	return getOrderedActions(ea.full)
+}
+
+// The actions which are only granted on some of the paths.
+func (ea *effectiveActions) getPartialActions() []string {
+	partial := map[string]bool{}
+	for action := range ea.partial {
+		if !ea.full[action] {
+			partial[action] = true
+		}
+	}
+	return getOrderedActions(partial)
+}
+
+// Add the actions which are implied by the granted actions.
+func expandActions(actions []string) []string {
+	expanded := map[string]bool{}
+	for _, action := range actions {
+		expanded[action] = true
+		if index := slices.Index(orderedActions[:5], action); index >= 0 {
+			for _, implied := range orderedActions[:index] {
+				expanded[implied] = true
+			}
+		}
+	}
+	return getOrderedActions(expanded)
+}
+
+func getOrderedActions(actions map[string]bool) []string {
+	var ordered []string
+	for _, action := range orderedActions {
+		if actions[action] {
+			ordered = append(ordered, action)
+		}
+	}
+	// Unknown actions are kept at the end.
+	var unknown []string
+	for action := range actions {
+		if !slices.Contains(orderedActions, action) {
+			unknown = append(unknown, action)
+		}
+	}
+	sort.Strings(unknown)
+	return append(ordered, unknown...)
+}
+
+func sortedKeys(actions map[string][]string) []string {
+	keys := make([]string, 0, len(actions))
+	for key := range actions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Match a path against an Ant-style pattern, as used by the include and exclude patterns.
+// '**' matches any number of directories, '*' matches within a single path segment and '?' matches a single character.
+func matchAntPattern(pattern, path string) bool {
+	var regex strings.Builder
+	regex.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			regex.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			regex.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			regex.WriteString("[^/]*")
+		case pattern[i] == '?':
+			regex.WriteString("[^/]")
+		default:
+			regex.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	regex.WriteString("$")
+	matched, err := regexp.MatchString(regex.String(), strings.TrimPrefix(path, "/"))
+	return err == nil && matched
+}
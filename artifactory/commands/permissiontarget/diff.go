@@ -0,0 +1,215 @@
+package permissiontarget
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PermissionTargetDiff lists the differences between a permission target template and the permission target on the server.
+type PermissionTargetDiff struct {
+	Name            string
+	MissingOnServer bool
+	Differences     []string
+}
+
+// PermissionTargetDiffCommand compares permission target templates against the permission targets on the server.
+// The template path may be a single template, or a directory of templates, such as the one created by the export command.
+type PermissionTargetDiffCommand struct {
+	PermissionTargetCommand
+	diffs []PermissionTargetDiff
+}
+
+func NewPermissionTargetDiffCommand() *PermissionTargetDiffCommand {
+	return &PermissionTargetDiffCommand{}
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetTemplatePath(path string) *PermissionTargetDiffCommand {
+	ptdc.templatePath = path
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetVars(vars string) *PermissionTargetDiffCommand {
+	ptdc.vars = vars
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetDiffCommand {
+	ptdc.serverDetails = serverDetails
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptdc.serverDetails, nil
+}
+
+func (ptdc *PermissionTargetDiffCommand) CommandName() string {
+	return "rt_permission_target_diff"
+}
+
+// The permission targets which differ from their templates.
+func (ptdc *PermissionTargetDiffCommand) Diffs() []PermissionTargetDiff {
+	return ptdc.diffs
+}
+
+func (ptdc *PermissionTargetDiffCommand) Run() error {
+	templatePaths, err := ptdc.getTemplatePaths()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ptdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	ptdc.diffs = nil
+	for _, templatePath := range templatePaths {
+		templateCmd := PermissionTargetCommand{templatePath: templatePath, vars: ptdc.vars}
+		template, err := convertTemplateToParams(&templateCmd)
+		if err != nil {
+			return err
+		}
+		current, err := servicesManager.GetPermissionTarget(template.Name)
+		if err != nil {
+			return err
+		}
+		diff := PermissionTargetDiff{Name: template.Name, MissingOnServer: current == nil}
+		if current != nil {
+			diff.Differences = diffPermissionTargets(&template, current)
+		}
+		if diff.MissingOnServer || len(diff.Differences) > 0 {
+			ptdc.diffs = append(ptdc.diffs, diff)
+		}
+	}
+	ptdc.printDiffs(len(templatePaths))
+	return nil
+}
+
+func (ptdc *PermissionTargetDiffCommand) getTemplatePaths() ([]string, error) {
+	info, err := os.Stat(ptdc.templatePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if !info.IsDir() {
+		return []string{ptdc.templatePath}, nil
+	}
+	templatePaths, err := filepath.Glob(filepath.Join(ptdc.templatePath, "*.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(templatePaths) == 0 {
+		return nil, errorutils.CheckErrorf("no permission target templates found in %s", ptdc.templatePath)
+	}
+	return templatePaths, nil
+}
+
+func (ptdc *PermissionTargetDiffCommand) printDiffs(templatesCount int) {
+	var lines []string
+	for _, diff := range ptdc.diffs {
+		if diff.MissingOnServer {
+			lines = append(lines, diff.Name+": missing on the server")
+			continue
+		}
+		for _, difference := range diff.Differences {
+			lines = append(lines, diff.Name+": "+difference)
+		}
+	}
+	if len(lines) > 0 {
+		log.Output(strings.Join(lines, "\n"))
+	}
+	log.Info(fmt.Sprintf("%d of %d permission targets differ from their templates.", len(ptdc.diffs), templatesCount))
+}
+
+// Describe the differences between the template and the permission target on the server, in the template's terms.
+func diffPermissionTargets(template, current *services.PermissionTargetParams) (differences []string) {
+	sections := []struct {
+		key               string
+		template, current *services.PermissionTargetSection
+	}{
+		{Repo, template.Repo, current.Repo},
+		{Build, template.Build, current.Build},
+		{ReleaseBundle, template.ReleaseBundle, current.ReleaseBundle},
+	}
+	for _, section := range sections {
+		switch {
+		case section.template == nil && section.current == nil:
+		case section.template == nil:
+			differences = append(differences, section.key+": only on the server")
+		case section.current == nil:
+			differences = append(differences, section.key+": missing on the server")
+		default:
+			differences = append(differences, diffPermissionSections(section.key, section.template, section.current)...)
+		}
+	}
+	return
+}
+
+func diffPermissionSections(key string, template, current *services.PermissionTargetSection) (differences []string) {
+	addDifference := func(field string, templateValue, currentValue []string) {
+		if !reflect.DeepEqual(templateValue, currentValue) {
+			differences = append(differences, fmt.Sprintf("%s.%s: template [%s], server [%s]", key, field, strings.Join(templateValue, ","), strings.Join(currentValue, ",")))
+		}
+	}
+	// The repositories of the build section can't be changed.
+	if key != Build {
+		addDifference("repositories", sortedCopy(template.Repositories), sortedCopy(current.Repositories))
+	}
+	addDifference("include-patterns", getIncludePatterns(template), getIncludePatterns(current))
+	addDifference("exclude-patterns", sortedCopy(template.ExcludePatterns), sortedCopy(current.ExcludePatterns))
+	templateUsers, templateGroups := getSectionActions(template)
+	currentUsers, currentGroups := getSectionActions(current)
+	for _, principal := range getPrincipals(templateUsers, currentUsers) {
+		addDifference("actions-users."+principal, sortedCopy(templateUsers[principal]), sortedCopy(currentUsers[principal]))
+	}
+	for _, principal := range getPrincipals(templateGroups, currentGroups) {
+		addDifference("actions-groups."+principal, sortedCopy(templateGroups[principal]), sortedCopy(currentGroups[principal]))
+	}
+	return
+}
+
+// Artifactory includes all the paths if no include patterns are set.
+func getIncludePatterns(section *services.PermissionTargetSection) []string {
+	if len(section.IncludePatterns) == 0 {
+		return []string{IncludePatternsDefault}
+	}
+	return sortedCopy(section.IncludePatterns)
+}
+
+func getSectionActions(section *services.PermissionTargetSection) (users, groups map[string][]string) {
+	if section.Actions == nil {
+		return nil, nil
+	}
+	return section.Actions.Users, section.Actions.Groups
+}
+
+// The sorted union of the principals in both actions maps.
+func getPrincipals(first, second map[string][]string) []string {
+	var principals []string
+	for principal := range first {
+		principals = append(principals, principal)
+	}
+	for principal := range second {
+		if _, exists := first[principal]; !exists {
+			principals = append(principals, principal)
+		}
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
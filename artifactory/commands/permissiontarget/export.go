@@ -0,0 +1,140 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PermissionTargetExportCommand writes existing permission targets in the format of the permission target templates,
+// so that they can be reviewed, kept in source control and applied with the create and update commands.
+type PermissionTargetExportCommand struct {
+	serverDetails *config.ServerDetails
+	names         []string
+	targetDir     string
+}
+
+func NewPermissionTargetExportCommand() *PermissionTargetExportCommand {
+	return &PermissionTargetExportCommand{}
+}
+
+func (ptec *PermissionTargetExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetExportCommand {
+	ptec.serverDetails = serverDetails
+	return ptec
+}
+
+// The permission targets to export. All the permission targets are exported if empty.
+func (ptec *PermissionTargetExportCommand) SetNames(names []string) *PermissionTargetExportCommand {
+	ptec.names = names
+	return ptec
+}
+
+func (ptec *PermissionTargetExportCommand) SetTargetDir(targetDir string) *PermissionTargetExportCommand {
+	ptec.targetDir = targetDir
+	return ptec
+}
+
+func (ptec *PermissionTargetExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptec.serverDetails, nil
+}
+
+func (ptec *PermissionTargetExportCommand) CommandName() string {
+	return "rt_permission_target_export"
+}
+
+func (ptec *PermissionTargetExportCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(ptec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	permissionTargets, err := getPermissionTargets(servicesManager, ptec.names)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(ptec.targetDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, permissionTarget := range permissionTargets {
+		content, err := json.MarshalIndent(convertParamsToTemplate(permissionTarget), "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		templatePath := filepath.Join(ptec.targetDir, permissionTarget.Name+".json")
+		if err = os.WriteFile(templatePath, content, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Debug("Permission target", permissionTarget.Name, "exported to", templatePath)
+	}
+	log.Info(fmt.Sprintf("%d permission targets exported to %s.", len(permissionTargets), ptec.targetDir))
+	return nil
+}
+
+// Get the requested permission targets, or all of them if no names are provided.
+// Listing the permission targets only returns their names, so each of them is fetched separately.
+func getPermissionTargets(servicesManager artifactory.ArtifactoryServicesManager, names []string) ([]*services.PermissionTargetParams, error) {
+	if len(names) == 0 {
+		allPermissionTargets, err := servicesManager.GetAllPermissionTargets()
+		if err != nil {
+			return nil, err
+		}
+		for _, permissionTarget := range *allPermissionTargets {
+			names = append(names, permissionTarget.Name)
+		}
+	}
+	var permissionTargets []*services.PermissionTargetParams
+	for _, name := range names {
+		permissionTarget, err := servicesManager.GetPermissionTarget(name)
+		if err != nil {
+			return nil, err
+		}
+		if permissionTarget == nil {
+			return nil, errorutils.CheckErrorf("permission target '%s' does not exist", name)
+		}
+		permissionTargets = append(permissionTargets, permissionTarget)
+	}
+	return permissionTargets, nil
+}
+
+// The reverse of convertTemplateToParams.
+func convertParamsToTemplate(params *services.PermissionTargetParams) map[string]interface{} {
+	template := map[string]interface{}{Name: params.Name}
+	for key, section := range map[string]*services.PermissionTargetSection{Repo: params.Repo, Build: params.Build, ReleaseBundle: params.ReleaseBundle} {
+		if section == nil {
+			continue
+		}
+		answer := PermissionSectionAnswer{
+			IncludePatterns: strings.Join(section.IncludePatterns, ","),
+			ExcludePatterns: strings.Join(section.ExcludePatterns, ","),
+		}
+		// The repositories of the build section have a constant value, which the template doesn't include.
+		if key != Build {
+			answer.Repositories = strings.Join(section.Repositories, ",")
+		}
+		if section.Actions != nil {
+			answer.ActionsUsers = convertActionsToTemplate(section.Actions.Users)
+			answer.ActionsGroups = convertActionsToTemplate(section.Actions.Groups)
+		}
+		template[key] = answer
+	}
+	return template
+}
+
+func convertActionsToTemplate(actions map[string][]string) map[string]string {
+	if len(actions) == 0 {
+		return nil
+	}
+	templateActions := make(map[string]string, len(actions))
+	for principal, principalActions := range actions {
+		templateActions[principal] = strings.Join(sortedCopy(principalActions), ",")
+	}
+	return templateActions
+}
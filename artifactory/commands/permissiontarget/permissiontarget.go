@@ -28,17 +28,34 @@ func (ptc *PermissionTargetCommand) TemplatePath() string {
 }
 
 func (ptc *PermissionTargetCommand) PerformPermissionTargetCmd(isUpdate bool) (err error) {
-	permissionTargetConfigMap, err := utils.ConvertTemplateToMap(ptc)
+	params, err := convertTemplateToParams(ptc)
 	if err != nil {
 		return err
 	}
+	servicesManager, err := rtUtils.CreateServiceManager(ptc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if isUpdate {
+		return servicesManager.UpdatePermissionTarget(params)
+	}
+	return servicesManager.CreatePermissionTarget(params)
+}
+
+// Read the template, replace its vars and convert it to the permission target params.
+func convertTemplateToParams(templateCmd utils.TemplateUserCommand) (params services.PermissionTargetParams, err error) {
+	permissionTargetConfigMap, err := utils.ConvertTemplateToMap(templateCmd)
+	if err != nil {
+		return
+	}
 	// Go over the confMap and write the values with the correct types
 	for key, value := range permissionTargetConfigMap {
 		isBuildSection := false
 		switch key {
 		case Name:
 			if _, ok := value.(string); !ok {
-				return errorutils.CheckErrorf("template syntax error: the value for the  key: \"Name\" is not a string type.")
+				err = errorutils.CheckErrorf("template syntax error: the value for the  key: \"Name\" is not a string type.")
+				return
 			}
 		case Build:
 			isBuildSection = true
@@ -46,33 +63,24 @@ func (ptc *PermissionTargetCommand) PerformPermissionTargetCmd(isUpdate bool) (e
 		case Repo:
 			fallthrough
 		case ReleaseBundle:
-			permissionSection, err := covertPermissionSection(value, isBuildSection)
-			if err != nil {
-				return err
+			var permissionSection *services.PermissionTargetSection
+			if permissionSection, err = covertPermissionSection(value, isBuildSection); err != nil {
+				return
 			}
 			permissionTargetConfigMap[key] = permissionSection
 		default:
-			return errorutils.CheckError(errors.New("template syntax error: unknown key: \"" + key + "\"."))
+			err = errorutils.CheckError(errors.New("template syntax error: unknown key: \"" + key + "\"."))
+			return
 		}
 	}
 	// Convert the new JSON with the correct types to params struct
 	content, err := json.Marshal(permissionTargetConfigMap)
 	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	params := services.NewPermissionTargetParams()
-	err = json.Unmarshal(content, &params)
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	servicesManager, err := rtUtils.CreateServiceManager(ptc.serverDetails, -1, 0, false)
-	if err != nil {
-		return err
-	}
-	if isUpdate {
-		return servicesManager.UpdatePermissionTarget(params)
+		return
 	}
-	return servicesManager.CreatePermissionTarget(params)
+	params = services.NewPermissionTargetParams()
+	err = errorutils.CheckError(json.Unmarshal(content, &params))
+	return
 }
 
 // Each section is a map of string->interface{}. We need to convert each value to its correct type
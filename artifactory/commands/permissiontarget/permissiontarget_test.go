@@ -0,0 +1,213 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPermissionTargets = []services.PermissionTargetParams{
+	{
+		Name: "prod-deployers",
+		Repo: &services.PermissionTargetSection{
+			Repositories: []string{"prod-docker-local"},
+			Actions: &services.Actions{
+				Users:  map[string][]string{"ci": {"write"}},
+				Groups: map[string][]string{"release-managers": {"delete", "read"}},
+			},
+		},
+	},
+	{
+		Name: "readers",
+		Repo: &services.PermissionTargetSection{
+			Repositories:    []string{"ANY LOCAL"},
+			IncludePatterns: []string{"**"},
+			ExcludePatterns: []string{"secrets/**"},
+			Actions:         &services.Actions{Groups: map[string][]string{"readers": {"read"}}},
+		},
+		Build: &services.PermissionTargetSection{
+			Repositories: []string{DefaultBuildRepositoriesValue},
+			Actions:      &services.Actions{Groups: map[string][]string{"readers": {"read"}}},
+		},
+	},
+	{
+		Name: "remote-cache",
+		Repo: &services.PermissionTargetSection{
+			Repositories: []string{"ANY REMOTE"},
+			Actions:      &services.Actions{Users: map[string][]string{"alice": {"write"}}},
+		},
+	},
+}
+
+// Start a fake Artifactory, serving the permission targets, users, groups and repositories APIs.
+func startTestArtifactory(t *testing.T) *httptest.Server {
+	users := map[string]services.User{
+		"alice": {Name: "alice", Groups: &[]string{"readers", "release-managers"}},
+		"bob":   {Name: "bob", Groups: &[]string{"readers"}},
+	}
+	groups := map[string]services.Group{
+		"readers":          {Name: "readers", UsersNames: []string{"alice", "bob"}},
+		"release-managers": {Name: "release-managers", UsersNames: []string{"alice"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch path := r.URL.Path; {
+		case path == "/api/v2/security/permissions":
+			var list []map[string]string
+			for _, permissionTarget := range testPermissionTargets {
+				list = append(list, map[string]string{"name": permissionTarget.Name, "uri": "permissions/" + permissionTarget.Name})
+			}
+			response = list
+		case strings.HasPrefix(path, "/api/v2/security/permissions/"):
+			for _, permissionTarget := range testPermissionTargets {
+				if permissionTarget.Name == strings.TrimPrefix(path, "/api/v2/security/permissions/") {
+					response = permissionTarget
+				}
+			}
+		case strings.HasPrefix(path, "/api/security/users/"):
+			if user, ok := users[strings.TrimPrefix(path, "/api/security/users/")]; ok {
+				response = user
+			}
+		case strings.HasPrefix(path, "/api/security/groups/"):
+			if group, ok := groups[strings.TrimPrefix(path, "/api/security/groups/")]; ok {
+				response = group
+			}
+		case path == "/api/repositories":
+			response = []services.RepositoryDetails{{Key: "prod-docker-local", Rclass: "local"}, {Key: "docker-remote", Rclass: "remote"}}
+		}
+		if response == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getTestServerDetails(server *httptest.Server) *config.ServerDetails {
+	return &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+}
+
+func TestExportAndDiff(t *testing.T) {
+	server := startTestArtifactory(t)
+	targetDir := t.TempDir()
+	require.NoError(t, NewPermissionTargetExportCommand().SetServerDetails(getTestServerDetails(server)).SetTargetDir(targetDir).Run())
+
+	content, err := os.ReadFile(filepath.Join(targetDir, "readers.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"readers",
+		"repo":{"repositories":"ANY LOCAL","include-patterns":"**","exclude-patterns":"secrets/**","actions-groups":{"readers":"read"}},
+		"build":{"actions-groups":{"readers":"read"}}}`, string(content))
+
+	// The exported templates are identical to the server.
+	diffCmd := NewPermissionTargetDiffCommand().SetServerDetails(getTestServerDetails(server)).SetTemplatePath(targetDir)
+	require.NoError(t, diffCmd.Run())
+	assert.Empty(t, diffCmd.Diffs())
+
+	// Change the templates and add a new one.
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "prod-deployers.json"), []byte(`{"name":"prod-deployers",
+		"repo":{"repositories":"prod-docker-local,${repo}","actions-users":{"ci":"write,read"},"actions-groups":{"release-managers":"read,delete"}}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "new.json"), []byte(`{"name":"new"}`), 0644))
+	require.NoError(t, diffCmd.SetVars("repo=prod-npm-local").Run())
+	assert.Equal(t, []PermissionTargetDiff{
+		{Name: "new", MissingOnServer: true},
+		{Name: "prod-deployers", Differences: []string{
+			"repo.repositories: template [prod-docker-local,prod-npm-local], server [prod-docker-local]",
+			"repo.actions-users.ci: template [read,write], server [write]",
+		}},
+	}, diffCmd.Diffs())
+}
+
+func TestAccessReport(t *testing.T) {
+	server := startTestArtifactory(t)
+	tests := []struct {
+		name     string
+		user     string
+		group    string
+		repo     string
+		expected []EffectiveAccess
+	}{
+		{
+			name: "user",
+			user: "alice",
+			expected: []EffectiveAccess{
+				{Principal: "user:alice", Repository: "ANY LOCAL", PartialActions: []string{"read"}},
+				{Principal: "user:alice", Repository: "ANY REMOTE", Actions: []string{"read", "annotate", "write"}},
+				{Principal: "user:alice", Repository: DefaultBuildRepositoriesValue, Actions: []string{"read"}},
+				{Principal: "user:alice", Repository: "prod-docker-local", Actions: []string{"read", "annotate", "write", "delete"}},
+			},
+		},
+		{
+			name:  "group on repository",
+			group: "readers",
+			repo:  "prod-docker-local",
+			expected: []EffectiveAccess{
+				{Principal: "group:readers", Repository: "prod-docker-local", PartialActions: []string{"read"}},
+			},
+		},
+		{
+			name: "repository",
+			repo: "prod-docker-local",
+			expected: []EffectiveAccess{
+				{Principal: "group:readers", Repository: "prod-docker-local", PartialActions: []string{"read"}},
+				{Principal: "group:release-managers", Repository: "prod-docker-local", Actions: []string{"read", "annotate", "write", "delete"}},
+				{Principal: "user:alice", Repository: "prod-docker-local", Actions: []string{"read", "annotate", "write", "delete"}},
+				{Principal: "user:bob", Repository: "prod-docker-local", PartialActions: []string{"read"}},
+				{Principal: "user:ci", Repository: "prod-docker-local", Actions: []string{"read", "annotate", "write"}},
+			},
+		},
+		{
+			name: "excluded path",
+			user: "bob",
+			repo: "prod-docker-local/secrets/key.pem",
+		},
+		{
+			name: "included path",
+			user: "bob",
+			repo: "prod-docker-local/app/1.0/app.tgz",
+			expected: []EffectiveAccess{
+				{Principal: "user:bob", Repository: "prod-docker-local", Actions: []string{"read"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessReportCmd := NewAccessReportCommand().SetServerDetails(getTestServerDetails(server)).SetUser(tt.user).SetGroup(tt.group).SetRepo(tt.repo)
+			require.NoError(t, accessReportCmd.Run())
+			assert.Equal(t, tt.expected, accessReportCmd.Report().Effective)
+		})
+	}
+
+	assert.ErrorContains(t, NewAccessReportCommand().SetServerDetails(getTestServerDetails(server)).SetUser("carol").Run(), "user 'carol' does not exist")
+	assert.ErrorContains(t, NewAccessReportCommand().Run(), "a user, a group or a repository must be provided")
+}
+
+func TestMatchAntPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"**", "a/b/c.jar", true},
+		{"secrets/**", "secrets/key.pem", true},
+		{"secrets/**", "app/secrets/key.pem", false},
+		{"**/secrets/**", "app/secrets/key.pem", true},
+		{"**/*.jar", "c.jar", true},
+		{"org/*/1.?/*", "org/acme/1.0/acme.jar", true},
+		{"org/*/1.?/*", "org/acme/1.10/acme.jar", false},
+		{"org/*.jar", "org/acme/acme.jar", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, matchAntPattern(tt.pattern, tt.path), tt.pattern+" "+tt.path)
+	}
+}
@@ -49,13 +49,26 @@ func (rcc *ReplicationCreateCommand) CommandName() string {
 }
 
 func (rcc *ReplicationCreateCommand) Run() (err error) {
-	content, err := fileutils.ReadFile(rcc.templatePath)
+	params, err := convertTemplateToParams(rcc.templatePath, rcc.vars)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(rcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	return servicesManager.CreateReplication(params)
+}
+
+// Read the template, replace its vars and convert it to the replication params.
+func convertTemplateToParams(templatePath, vars string) (params services.CreateReplicationParams, err error) {
+	content, err := fileutils.ReadFile(templatePath)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
 	// Replace vars string-by-string if needed
-	if len(rcc.vars) > 0 {
-		templateVars := coreutils.SpecVarsStringToMap(rcc.vars)
+	if len(vars) > 0 {
+		templateVars := coreutils.SpecVarsStringToMap(vars)
 		content = coreutils.ReplaceVars(content, templateVars)
 	}
 	// Unmarshal template to a map
@@ -74,43 +87,35 @@ func (rcc *ReplicationCreateCommand) Run() (err error) {
 		if key == "serverId" {
 			serverId = fmt.Sprint(value)
 		} else {
-			err := writersMap[key](&replicationConfigMap, key, fmt.Sprint(value))
-			if err != nil {
-				return err
+			if err = writersMap[key](&replicationConfigMap, key, fmt.Sprint(value)); err != nil {
+				return
 			}
 		}
 	}
-	err = fillMissingDefaultValue(replicationConfigMap)
-	if err != nil {
-		return err
+	if err = fillMissingDefaultValue(replicationConfigMap); err != nil {
+		return
 	}
 	// Write a JSON with the correct values
 	content, err = json.Marshal(replicationConfigMap)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	var params services.CreateReplicationParams
 	err = json.Unmarshal(content, &params)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
 
 	setPathPrefixBackwardCompatibility(&params)
-	servicesManager, err := rtUtils.CreateServiceManager(rcc.serverDetails, -1, 0, false)
-	if err != nil {
-		return err
-	}
 	// In case 'serverId' is not found, pull replication will be assumed.
 	if serverId != "" {
-		if targetRepo, ok := replicationConfigMap["targetRepoKey"]; ok {
-			if err = updateArtifactoryInfo(&params, serverId, fmt.Sprint(targetRepo)); err != nil {
-				return err
-			}
-		} else {
-			return errorutils.CheckErrorf("expected 'targetRepoKey' field in the json template file.")
+		targetRepo, ok := replicationConfigMap["targetRepoKey"]
+		if !ok {
+			err = errorutils.CheckErrorf("expected 'targetRepoKey' field in the json template file.")
+			return
 		}
+		err = updateArtifactoryInfo(&params, serverId, fmt.Sprint(targetRepo))
 	}
-	return servicesManager.CreateReplication(params)
+	return
 }
 
 func fillMissingDefaultValue(replicationConfigMap map[string]interface{}) error {
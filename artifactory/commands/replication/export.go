@@ -0,0 +1,202 @@
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	replicationTypePush = "PUSH"
+
+	// Written to the template when the target of a push replication isn't one of the configured servers.
+	serverIdVar = "${" + ServerId + "}"
+)
+
+// ReplicationExportCommand writes the replications of the repositories matching a pattern in the format of the replication templates,
+// so that they can be reviewed, kept in source control and applied with the create and update commands.
+type ReplicationExportCommand struct {
+	serverDetails *config.ServerDetails
+	repoPattern   string
+	targetDir     string
+}
+
+func NewReplicationExportCommand() *ReplicationExportCommand {
+	return &ReplicationExportCommand{repoPattern: "*"}
+}
+
+func (rec *ReplicationExportCommand) SetRepoPattern(repoPattern string) *ReplicationExportCommand {
+	rec.repoPattern = repoPattern
+	return rec
+}
+
+func (rec *ReplicationExportCommand) SetTargetDir(targetDir string) *ReplicationExportCommand {
+	rec.targetDir = targetDir
+	return rec
+}
+
+func (rec *ReplicationExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationExportCommand {
+	rec.serverDetails = serverDetails
+	return rec
+}
+
+func (rec *ReplicationExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rec.serverDetails, nil
+}
+
+func (rec *ReplicationExportCommand) CommandName() string {
+	return "rt_replication_export"
+}
+
+func (rec *ReplicationExportCommand) Run() (err error) {
+	servicesManager, err := rtUtils.CreateServiceManager(rec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	// The targets of the push replications are matched against the configured servers, to write their server IDs in the templates.
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	return rec.export(servicesManager, servers)
+}
+
+func (rec *ReplicationExportCommand) export(servicesManager artifactory.ArtifactoryServicesManager, servers []*config.ServerDetails) error {
+	repoReplications, err := getReplications(servicesManager, rec.repoPattern)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(rec.targetDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	exported := 0
+	for _, replications := range repoReplications {
+		for i, replication := range replications.configs {
+			template := convertParamsToTemplate(replication, replications.replicationType == replicationTypePush, servers)
+			content, err := json.MarshalIndent(template, "", "  ")
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			// A repository may have multiple push replications.
+			fileName := replications.repoKey + ".json"
+			if len(replications.configs) > 1 {
+				fileName = fmt.Sprintf("%s-%d.json", replications.repoKey, i+1)
+			}
+			if err = os.WriteFile(filepath.Join(rec.targetDir, fileName), content, 0644); err != nil {
+				return errorutils.CheckError(err)
+			}
+			exported++
+		}
+	}
+	log.Info(fmt.Sprintf("%d replications exported to %s.", exported, rec.targetDir))
+	return nil
+}
+
+// The reverse of convertTemplateToParams. All the values in the template are strings.
+func convertParamsToTemplate(params utils.ReplicationParams, isPush bool, servers []*config.ServerDetails) map[string]string {
+	template := map[string]string{
+		RepoKey:                params.RepoKey,
+		CronExp:                params.CronExp,
+		EnableEventReplication: strconv.FormatBool(params.EnableEventReplication),
+		Enabled:                strconv.FormatBool(params.Enabled),
+		SyncDeletes:            strconv.FormatBool(params.SyncDeletes),
+		SyncProperties:         strconv.FormatBool(params.SyncProperties),
+		SyncStatistics:         strconv.FormatBool(params.SyncStatistics),
+		SocketTimeoutMillis:    strconv.Itoa(params.SocketTimeoutMillis),
+	}
+	if params.IncludePathPrefixPattern != "" {
+		template[IncludePathPrefixPattern] = params.IncludePathPrefixPattern
+	} else if params.PathPrefix != "" {
+		template[IncludePathPrefixPattern] = params.PathPrefix
+	}
+	if isPush {
+		template[ServerId], template[TargetRepoKey] = getReplicationTarget(params.Url, servers)
+		if template[ServerId] == serverIdVar {
+			log.Warn(fmt.Sprintf("The target of the replication of %s, %s, isn't one of the configured servers. Provide its server ID using the '%s' var.", params.RepoKey, params.Url, ServerId))
+		}
+	}
+	return template
+}
+
+// Split the URL of a push replication to the server ID of the target Artifactory and the target repository key.
+func getReplicationTarget(replicationUrl string, servers []*config.ServerDetails) (serverId, targetRepoKey string) {
+	replicationUrl = strings.TrimSuffix(replicationUrl, "/")
+	for _, server := range servers {
+		artifactoryUrl := strings.TrimSuffix(server.GetArtifactoryUrl(), "/")
+		if artifactoryUrl == "" {
+			continue
+		}
+		if repoKey, found := strings.CutPrefix(replicationUrl, artifactoryUrl+"/"); found && !strings.Contains(repoKey, "/") {
+			return server.ServerId, repoKey
+		}
+	}
+	return serverIdVar, path.Base(replicationUrl)
+}
+
+type repoReplications struct {
+	repoKey         string
+	replicationType string
+	configs         []utils.ReplicationParams
+}
+
+// An entry of the replications list.
+type replicationSummary struct {
+	RepoKey         string `json:"repoKey"`
+	ReplicationType string `json:"replicationType"`
+}
+
+// Get the replications of the repositories matching the pattern, sorted by the repository key.
+func getReplications(servicesManager artifactory.ArtifactoryServicesManager, repoPattern string) ([]repoReplications, error) {
+	var summaries []replicationSummary
+	if err := sendReplicationsGet(servicesManager, "api/replications", &summaries); err != nil {
+		return nil, err
+	}
+	replicationTypes := map[string]string{}
+	for _, summary := range summaries {
+		matched, err := filepath.Match(repoPattern, summary.RepoKey)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if matched {
+			replicationTypes[summary.RepoKey] = strings.ToUpper(summary.ReplicationType)
+		}
+	}
+	var replications []repoReplications
+	for repoKey, replicationType := range replicationTypes {
+		configs, err := servicesManager.GetReplication(repoKey)
+		if err != nil {
+			return nil, err
+		}
+		replications = append(replications, repoReplications{repoKey: repoKey, replicationType: replicationType, configs: configs})
+	}
+	sort.Slice(replications, func(i, j int) bool {
+		return replications[i].repoKey < replications[j].repoKey
+	})
+	return replications, nil
+}
+
+func sendReplicationsGet(servicesManager artifactory.ArtifactoryServicesManager, apiPath string, result any) error {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+apiPath, true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
@@ -0,0 +1,220 @@
+package replication
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type StatusFormat string
+
+const (
+	StatusTable StatusFormat = "table"
+	StatusJson  StatusFormat = "json"
+
+	// The status of a replication which has never completed.
+	neverRunStatus = "never_run"
+)
+
+func GetStatusFormat(format string) (StatusFormat, error) {
+	switch StatusFormat(format) {
+	case "", StatusTable:
+		return StatusTable, nil
+	case StatusJson:
+		return StatusJson, nil
+	}
+	return "", errorutils.CheckErrorf("invalid output format '%s'. The valid values are: table and json", format)
+}
+
+// ReplicationStatus is the last run of a replication. The lag is the time since the last completed run.
+type ReplicationStatus struct {
+	RepoKey       string `json:"repoKey"`
+	Type          string `json:"type"`
+	Target        string `json:"target,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	CronExp       string `json:"cronExp,omitempty"`
+	Status        string `json:"status"`
+	LastCompleted string `json:"lastCompleted,omitempty"`
+	LagSeconds    int64  `json:"lagSeconds,omitempty"`
+	Lagging       bool   `json:"lagging"`
+}
+
+// The response of the replication status API.
+type replicationStatusResponse struct {
+	Status        string `json:"status"`
+	LastCompleted string `json:"lastCompleted"`
+	Targets       []struct {
+		Url           string `json:"url"`
+		Status        string `json:"status"`
+		LastCompleted string `json:"lastCompleted"`
+	} `json:"targets"`
+}
+
+// ReplicationStatusCommand shows the last run of the replications of the repositories matching a pattern.
+// If a lag threshold is set, the command fails if any of the enabled replications hasn't completed within it,
+// so that it can be used as a monitoring probe.
+type ReplicationStatusCommand struct {
+	serverDetails *config.ServerDetails
+	repoPattern   string
+	failIfLagging time.Duration
+	format        StatusFormat
+	statuses      []ReplicationStatus
+}
+
+func NewReplicationStatusCommand() *ReplicationStatusCommand {
+	return &ReplicationStatusCommand{repoPattern: "*", format: StatusTable}
+}
+
+func (rsc *ReplicationStatusCommand) SetRepoPattern(repoPattern string) *ReplicationStatusCommand {
+	rsc.repoPattern = repoPattern
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetFailIfLagging(failIfLagging time.Duration) *ReplicationStatusCommand {
+	rsc.failIfLagging = failIfLagging
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetFormat(format StatusFormat) *ReplicationStatusCommand {
+	rsc.format = format
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationStatusCommand {
+	rsc.serverDetails = serverDetails
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) Statuses() []ReplicationStatus {
+	return rsc.statuses
+}
+
+func (rsc *ReplicationStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rsc.serverDetails, nil
+}
+
+func (rsc *ReplicationStatusCommand) CommandName() string {
+	return "rt_replication_status"
+}
+
+func (rsc *ReplicationStatusCommand) Run() (err error) {
+	servicesManager, err := rtUtils.CreateServiceManager(rsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if rsc.statuses, err = rsc.getStatuses(servicesManager, time.Now()); err != nil {
+		return err
+	}
+	if err = rsc.printStatuses(); err != nil {
+		return err
+	}
+	lagging := 0
+	for _, status := range rsc.statuses {
+		if status.Lagging {
+			lagging++
+		}
+	}
+	if lagging > 0 {
+		return errorutils.CheckErrorf("%d of the replications haven't completed in the last %s", lagging, rsc.failIfLagging)
+	}
+	return nil
+}
+
+func (rsc *ReplicationStatusCommand) getStatuses(servicesManager artifactory.ArtifactoryServicesManager, now time.Time) ([]ReplicationStatus, error) {
+	repoReplications, err := getReplications(servicesManager, rsc.repoPattern)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []ReplicationStatus
+	for _, replications := range repoReplications {
+		var response replicationStatusResponse
+		if err = sendReplicationsGet(servicesManager, "api/replication/"+replications.repoKey, &response); err != nil {
+			return nil, err
+		}
+		for _, replication := range replications.configs {
+			status := ReplicationStatus{
+				RepoKey: replication.RepoKey,
+				Type:    strings.ToLower(replications.replicationType),
+				Enabled: replication.Enabled,
+				CronExp: replication.CronExp,
+				Status:  response.Status,
+			}
+			lastCompleted := response.LastCompleted
+			// The status of each push replication is reported per target.
+			if replications.replicationType == replicationTypePush {
+				status.Target = replication.Url
+				for _, target := range response.Targets {
+					if strings.TrimSuffix(target.Url, "/") == strings.TrimSuffix(replication.Url, "/") {
+						status.Status, lastCompleted = target.Status, target.LastCompleted
+					}
+				}
+			}
+			if err = rsc.setLag(&status, lastCompleted, now); err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+func (rsc *ReplicationStatusCommand) setLag(status *ReplicationStatus, lastCompleted string, now time.Time) error {
+	if status.Status == "" {
+		status.Status = neverRunStatus
+	}
+	var lag time.Duration
+	if lastCompleted != "" {
+		completed, err := parseReplicationTime(lastCompleted)
+		if err != nil {
+			return err
+		}
+		status.LastCompleted = completed.Format(time.RFC3339)
+		lag = now.Sub(completed).Truncate(time.Second)
+		status.LagSeconds = int64(lag.Seconds())
+	}
+	// A replication which never completed is lagging, unless it's disabled.
+	status.Lagging = rsc.failIfLagging > 0 && status.Enabled && (lastCompleted == "" || lag > rsc.failIfLagging)
+	return nil
+}
+
+// Artifactory returns the times in the ISO 8601 format, with milliseconds.
+func parseReplicationTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05.000Z0700", time.RFC3339Nano} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, errorutils.CheckErrorf("unexpected replication time format '%s'", value)
+}
+
+func (rsc *ReplicationStatusCommand) printStatuses() error {
+	if rsc.format == StatusJson {
+		content, err := json.MarshalIndent(rsc.statuses, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	statusTable := table.NewWriter()
+	statusTable.AppendHeader(table.Row{"Repository", "Type", "Target", "Enabled", "Cron", "Status", "Last completed", "Lag"})
+	for _, status := range rsc.statuses {
+		lag := ""
+		if status.LastCompleted != "" {
+			lag = (time.Duration(status.LagSeconds) * time.Second).String()
+		}
+		if status.Lagging {
+			lag += " (lagging)"
+		}
+		statusTable.AppendRow(table.Row{status.RepoKey, status.Type, status.Target, status.Enabled, status.CronExp, status.Status, status.LastCompleted, strings.TrimSpace(lag)})
+	}
+	log.Output(statusTable.Render())
+	return nil
+}
@@ -0,0 +1,186 @@
+package replication
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A fake Artifactory with a push replication of libs-local to two targets, and a pull replication of npm-remote.
+type testReplicationServer struct {
+	*httptest.Server
+	mutex            sync.Mutex
+	updates          []utils.UpdateReplicationBody
+	multiPushUpdates map[string]multiPushReplicationBody
+}
+
+func newTestReplicationServer(t *testing.T) *testReplicationServer {
+	responses := map[string]string{
+		"/api/replications": `[{"repoKey":"libs-local","replicationType":"PUSH"},{"repoKey":"libs-local","replicationType":"PUSH"},
+			{"repoKey":"npm-remote","replicationType":"PULL"}]`,
+		"/api/replications/libs-local": `[
+			{"url":"https://dr.acme.com/artifactory/libs-dr","repoKey":"libs-local","cronExp":"0 0 * * * ?","enabled":true,"syncDeletes":true,"socketTimeoutMillis":15000},
+			{"url":"https://other.acme.com/artifactory/libs-other","repoKey":"libs-local","cronExp":"0 0 * * * ?","enabled":false,"socketTimeoutMillis":15000}]`,
+		"/api/replications/npm-remote": `[{"url":"","repoKey":"npm-remote","cronExp":"0 0 12 * * ?","enabled":true,"syncProperties":true,"socketTimeoutMillis":15000,"pathPrefix":"@acme"}]`,
+		"/api/replication/libs-local": `{"status":"ok","lastCompleted":"2026-10-18T11:00:00.000+0000","targets":[
+			{"url":"https://dr.acme.com/artifactory/libs-dr","status":"ok","lastCompleted":"2026-10-18T11:00:00.000+0000"},
+			{"url":"https://other.acme.com/artifactory/libs-other","status":"never_run"}]}`,
+		"/api/replication/npm-remote": `{"status":"error","lastCompleted":"2026-10-17T12:00:00.000+0000"}`,
+	}
+	server := &testReplicationServer{multiPushUpdates: map[string]multiPushReplicationBody{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			if repoKey, found := strings.CutPrefix(r.URL.Path, "/api/replications/multiple/"); found {
+				var body multiPushReplicationBody
+				assert.NoError(t, json.Unmarshal(content, &body))
+				server.multiPushUpdates[repoKey] = body
+				return
+			}
+			server.updates = append(server.updates, unmarshalReplicationBody(t, content))
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReplicationExportAndUpdate(t *testing.T) {
+	server := newTestReplicationServer(t)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	servicesManager, err := rtUtils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	targetDir := t.TempDir()
+	servers := []*config.ServerDetails{{ServerId: "dr", ArtifactoryUrl: "https://dr.acme.com/artifactory/"}}
+	require.NoError(t, NewReplicationExportCommand().SetTargetDir(targetDir).export(servicesManager, servers))
+
+	expected := map[string]map[string]string{
+		"libs-local-1.json": {"repoKey": "libs-local", "serverId": "dr", "targetRepoKey": "libs-dr", "cronExp": "0 0 * * * ?", "enabled": "true", "syncDeletes": "true"},
+		// The target isn't one of the configured servers.
+		"libs-local-2.json": {"repoKey": "libs-local", "serverId": "${serverId}", "targetRepoKey": "libs-other", "cronExp": "0 0 * * * ?", "enabled": "false", "syncDeletes": "false"},
+		"npm-remote.json":   {"repoKey": "npm-remote", "cronExp": "0 0 12 * * ?", "enabled": "true", "syncDeletes": "false", "syncProperties": "true", "includePathPrefixPattern": "@acme"},
+	}
+	files, err := os.ReadDir(targetDir)
+	require.NoError(t, err)
+	assert.Len(t, files, len(expected))
+	for fileName, expectedTemplate := range expected {
+		content, err := os.ReadFile(filepath.Join(targetDir, fileName))
+		require.NoError(t, err)
+		var template map[string]string
+		require.NoError(t, json.Unmarshal(content, &template))
+		for key, value := range expectedTemplate {
+			assert.Equal(t, value, template[key], fileName+" "+key)
+		}
+		assert.Equal(t, "15000", template["socketTimeoutMillis"])
+	}
+
+	// Change the cron expression of the pull replication in place.
+	templatePath := filepath.Join(targetDir, "npm-remote.json")
+	content, err := os.ReadFile(templatePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(templatePath, []byte(strings.Replace(string(content), "0 0 12 * * ?", "${cron}", 1)), 0644))
+	updateCmd := NewReplicationUpdateCommand().SetServerDetails(serverDetails).SetTemplatePath(templatePath).SetVars("cron=0 0 6 * * ?")
+	require.NoError(t, updateCmd.Run())
+	require.Len(t, server.updates, 1)
+	assert.Equal(t, *utils.CreateUpdateReplicationBody(utils.ReplicationParams{
+		RepoKey:                  "npm-remote",
+		CronExp:                  "0 0 6 * * ?",
+		Enabled:                  true,
+		SyncProperties:           true,
+		SocketTimeoutMillis:      15000,
+		PathPrefix:               "@acme",
+		IncludePathPrefixPattern: "@acme",
+	}), server.updates[0])
+}
+
+func TestReplicationUpdateMultiPush(t *testing.T) {
+	// The targets of the push replications are resolved from the configured servers.
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "dr", ArtifactoryUrl: "https://dr.acme.com/artifactory/"}}))
+	server := newTestReplicationServer(t)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	servicesManager, err := rtUtils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	targetDir := t.TempDir()
+	require.NoError(t, NewReplicationExportCommand().SetTargetDir(targetDir).export(servicesManager, nil))
+
+	// The two push replications of libs-local are updated together, and the pull replication of npm-remote alone.
+	require.NoError(t, NewReplicationUpdateCommand().SetServerDetails(serverDetails).SetTemplatePath(targetDir).SetVars("serverId=dr").Run())
+	require.Len(t, server.updates, 1)
+	assert.Equal(t, "npm-remote", server.updates[0].RepoKey)
+	require.Contains(t, server.multiPushUpdates, "libs-local")
+	multiPushUpdate := server.multiPushUpdates["libs-local"]
+	assert.Equal(t, "0 0 * * * ?", multiPushUpdate.CronExp)
+	require.Len(t, multiPushUpdate.Replications, 2)
+	assert.Equal(t, "https://dr.acme.com/artifactory/libs-dr", multiPushUpdate.Replications[0].URL)
+	assert.True(t, multiPushUpdate.Replications[0].Enabled)
+	assert.Equal(t, "https://dr.acme.com/artifactory/libs-other", multiPushUpdate.Replications[1].URL)
+	assert.False(t, multiPushUpdate.Replications[1].Enabled)
+
+	// The push replications of a repository share their schedule.
+	templatePath := filepath.Join(targetDir, "libs-local-2.json")
+	content, err := os.ReadFile(templatePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(templatePath, []byte(strings.Replace(string(content), "0 0 * * * ?", "0 0 6 * * ?", 1)), 0644))
+	assert.ErrorContains(t, NewReplicationUpdateCommand().SetServerDetails(serverDetails).SetTemplatePath(targetDir).SetVars("serverId=dr").Run(),
+		"the push replications of libs-local must have the same cronExp and enableEventReplication values")
+}
+
+func TestReplicationStatus(t *testing.T) {
+	server := newTestReplicationServer(t)
+	servicesManager, err := rtUtils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, -1, 0, false)
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	statusCmd := NewReplicationStatusCommand().SetRepoPattern("*-*").SetFailIfLagging(2 * time.Hour)
+	statuses, err := statusCmd.getStatuses(servicesManager, now)
+	require.NoError(t, err)
+	assert.Equal(t, []ReplicationStatus{
+		{RepoKey: "libs-local", Type: "push", Target: "https://dr.acme.com/artifactory/libs-dr", Enabled: true, CronExp: "0 0 * * * ?",
+			Status: "ok", LastCompleted: "2026-10-18T11:00:00Z", LagSeconds: 3600},
+		// Disabled replications aren't lagging.
+		{RepoKey: "libs-local", Type: "push", Target: "https://other.acme.com/artifactory/libs-other", CronExp: "0 0 * * * ?", Status: "never_run"},
+		{RepoKey: "npm-remote", Type: "pull", Enabled: true, CronExp: "0 0 12 * * ?",
+			Status: "error", LastCompleted: "2026-10-17T12:00:00Z", LagSeconds: 24 * 3600, Lagging: true},
+	}, statuses)
+
+	statuses, err = statusCmd.SetRepoPattern("libs-*").SetFailIfLagging(0).getStatuses(servicesManager, now)
+	require.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.False(t, status.Lagging)
+	}
+}
+
+func TestParseReplicationTime(t *testing.T) {
+	for _, value := range []string{"2026-10-18T14:00:00.000+0200", "2026-10-18T12:00:00Z", "2026-10-18T12:00:00.000Z"} {
+		parsed, err := parseReplicationTime(value)
+		require.NoError(t, err, value)
+		assert.True(t, parsed.Equal(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)), value)
+	}
+	_, err := parseReplicationTime("18/10/2026")
+	assert.ErrorContains(t, err, "unexpected replication time format")
+}
@@ -0,0 +1,164 @@
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ReplicationUpdateCommand updates existing replications in place from replication templates.
+// The template path may be a single template, or a directory of templates, such as the one created by the export command.
+// The templates of a repository with several push replications are updated together, using the multi-push replication API.
+type ReplicationUpdateCommand struct {
+	serverDetails *config.ServerDetails
+	templatePath  string
+	vars          string
+}
+
+func NewReplicationUpdateCommand() *ReplicationUpdateCommand {
+	return &ReplicationUpdateCommand{}
+}
+
+func (ruc *ReplicationUpdateCommand) SetTemplatePath(path string) *ReplicationUpdateCommand {
+	ruc.templatePath = path
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) SetVars(vars string) *ReplicationUpdateCommand {
+	ruc.vars = vars
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationUpdateCommand {
+	ruc.serverDetails = serverDetails
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ruc.serverDetails, nil
+}
+
+func (ruc *ReplicationUpdateCommand) CommandName() string {
+	return "rt_replication_update"
+}
+
+func (ruc *ReplicationUpdateCommand) Run() (err error) {
+	templatePaths, err := getTemplatePaths(ruc.templatePath)
+	if err != nil {
+		return err
+	}
+	repoReplications, err := ruc.groupByRepo(templatePaths)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ruc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, replications := range repoReplications {
+		if len(replications.configs) == 1 {
+			err = servicesManager.UpdateReplication(services.UpdateReplicationParams{ReplicationParams: replications.configs[0]})
+		} else {
+			err = updateMultiPushReplication(servicesManager, replications)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("%d replications updated.", len(templatePaths)))
+	return nil
+}
+
+// Convert the templates to replications, grouped by their repository and sorted by the repository key.
+func (ruc *ReplicationUpdateCommand) groupByRepo(templatePaths []string) ([]repoReplications, error) {
+	configs := make(map[string][]utils.ReplicationParams)
+	for _, templatePath := range templatePaths {
+		params, err := convertTemplateToParams(templatePath, ruc.vars)
+		if err != nil {
+			return nil, err
+		}
+		configs[params.RepoKey] = append(configs[params.RepoKey], params.ReplicationParams)
+	}
+	replications := make([]repoReplications, 0, len(configs))
+	for repoKey, repoConfigs := range configs {
+		if len(repoConfigs) > 1 {
+			if err := validateMultiPushReplication(repoKey, repoConfigs); err != nil {
+				return nil, err
+			}
+		}
+		replications = append(replications, repoReplications{repoKey: repoKey, configs: repoConfigs})
+	}
+	sort.Slice(replications, func(i, j int) bool {
+		return replications[i].repoKey < replications[j].repoKey
+	})
+	return replications, nil
+}
+
+// Only push replications may be combined, and the schedule of a multi-push replication is shared by its targets.
+func validateMultiPushReplication(repoKey string, configs []utils.ReplicationParams) error {
+	for _, replication := range configs {
+		if replication.Url == "" {
+			return errorutils.CheckErrorf("found %d templates of %s, but only push replications may be combined", len(configs), repoKey)
+		}
+		if replication.CronExp != configs[0].CronExp || replication.EnableEventReplication != configs[0].EnableEventReplication {
+			return errorutils.CheckErrorf("the push replications of %s must have the same cronExp and enableEventReplication values", repoKey)
+		}
+	}
+	return nil
+}
+
+// The body of the multi-push replication API.
+type multiPushReplicationBody struct {
+	CronExp                string                        `json:"cronExp"`
+	EnableEventReplication bool                          `json:"enableEventReplication"`
+	Replications           []utils.UpdateReplicationBody `json:"replications"`
+}
+
+func updateMultiPushReplication(servicesManager artifactory.ArtifactoryServicesManager, replications repoReplications) error {
+	body := multiPushReplicationBody{CronExp: replications.configs[0].CronExp, EnableEventReplication: replications.configs[0].EnableEventReplication}
+	for _, replication := range replications.configs {
+		body.Replications = append(body.Replications, *utils.CreateUpdateReplicationBody(replication))
+	}
+	content, err := json.Marshal(body)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	utils.SetContentType("application/json", &httpClientDetails.Headers)
+	log.Info(fmt.Sprintf("Updating the %d push replications of %s...", len(replications.configs), replications.repoKey))
+	resp, respBody, err := servicesManager.Client().SendPost(serviceDetails.GetUrl()+"api/replications/multiple/"+replications.repoKey, content, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, respBody, http.StatusOK, http.StatusCreated)
+}
+
+func getTemplatePaths(templatePath string) ([]string, error) {
+	info, err := os.Stat(templatePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if !info.IsDir() {
+		return []string{templatePath}, nil
+	}
+	templatePaths, err := filepath.Glob(filepath.Join(templatePath, "*.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(templatePaths) == 0 {
+		return nil, errorutils.CheckErrorf("no replication templates found in %s", templatePath)
+	}
+	return templatePaths, nil
+}
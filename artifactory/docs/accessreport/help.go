@@ -0,0 +1,13 @@
+package accessreport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt access-report [command options]"}
+
+func GetDescription() string {
+	return "Report the effective actions granted across all the permission targets to a user, to a group or on a repository, including the include and exclude patterns and the group memberships."
+}
+
+func GetArguments() []components.Argument {
+	return nil
+}
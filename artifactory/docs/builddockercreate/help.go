@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rt build-docker-create <target repo> --image-file=<Image file path>"}
 
 func GetDescription() string {
-	return "Add a published docker image to the build-info."
+	return "Add a published docker image to the build-info. Supports images built without a Docker daemon, such as by Kaniko, Buildah or BuildKit."
 }
 
 func GetArguments() []components.Argument {
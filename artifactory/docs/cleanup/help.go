@@ -0,0 +1,18 @@
+package cleanup
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt cleanup [command options] <policies file>"}
+
+func GetDescription() string {
+	return "Delete files from Artifactory according to cleanup policies, such as age since last download, number of versions to keep and repository size budget."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "policies file",
+			Description: "Path to a YAML file with a list of cleanup policies. Each policy selects files by 'repos' and 'path' patterns, and deletes them according to 'olderThan', 'notDownloadedSince', 'keepLast', 'excludeProps' and 'maxRepoSize'.",
+		},
+	}
+}
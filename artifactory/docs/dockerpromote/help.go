@@ -5,14 +5,14 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rt docker-promote <source docker image> <source repo> <target repo>"}
 
 func GetDescription() string {
-	return "Promotes a Docker image from one repository to another. Supported by local repositories only."
+	return "Promotes a Docker image from one repository to another. Multi-platform images and images referenced by digest are promoted with all their platform manifests, layers and attestations. Supported by local repositories only."
 }
 
 func GetArguments() []components.Argument {
 	return []components.Argument{
 		{
 			Name:        "source docker image",
-			Description: "The docker image name to promote.",
+			Description: "The docker image name to promote. Use <image>@sha256:<digest> to promote an exact manifest digest, together with --target-tag.",
 		},
 		{
 			Name:        "source repo",
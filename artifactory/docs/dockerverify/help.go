@@ -0,0 +1,22 @@
+package dockerverify
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt docker-verify [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Verify that every Docker image in a published build is signed with cosign or Notation. Run before promoting the build."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "build name",
+			Description: "Build name.",
+		},
+		{
+			Name:        "build number",
+			Description: "Build number.",
+		},
+	}
+}
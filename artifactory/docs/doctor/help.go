@@ -0,0 +1,13 @@
+package doctor
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt doctor [command options]"}
+
+func GetDescription() string {
+	return "Diagnose the connection to Artifactory. Checks the DNS resolution, proxy, TLS, client certificate, latency, authentication and version, and optionally the permissions on a repository and the transfer throughput."
+}
+
+func GetArguments() []components.Argument {
+	return nil
+}
@@ -0,0 +1,18 @@
+package gitlfsmigrate
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt glm [command options] [path to .git]"}
+
+func GetDescription() string {
+	return "Migrate Git LFS objects to an Artifactory LFS repository. This command finds the LFS pointers in the history of the Git references, and uploads the objects which are missing in the repository."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "path to .git",
+			Description: "Path to a directory containing the .git directory, or to a bare Git repository. If not specified, the .git directory is assumed to be in the current directory.",
+		},
+	}
+}
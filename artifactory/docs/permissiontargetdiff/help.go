@@ -0,0 +1,18 @@
+package permissiontargetdiff
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt ptdiff <template path>"}
+
+func GetDescription() string {
+	return "Compare permission target templates against the permission targets in Artifactory."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "template path",
+			Description: "Specifies the local file system path of a permission target template, or of a directory of templates, such as the one created by the “jfrog rt ptexp” command.",
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package permissiontargetexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt ptexp <target path>"}
+
+func GetDescription() string {
+	return "Export permission targets from Artifactory as permission target templates."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "target path",
+			Description: "Specifies the local file system directory to which the templates are written, a <permission target name>.json file per permission target. The templates can be used by the “jfrog rt ptc”, “jfrog rt ptu” and “jfrog rt ptdiff” commands.",
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package replicationexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt rplexp <repository pattern> <target path>"}
+
+func GetDescription() string {
+	return "Export the replications of repositories from Artifactory as replication templates."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository pattern",
+			Description: "Specifies the repositories whose replications should be exported. You can use wildcards to specify multiple repositories.",
+		},
+		{
+			Name:        "target path",
+			Description: "Specifies the local file system directory to which the templates are written, a <repository key>.json file per replication. The templates can be used by the “jfrog rt rplc” and “jfrog rt rplu” commands.",
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package replicationstatus
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt rpls [command options] [repository pattern]"}
+
+func GetDescription() string {
+	return "Show the status, last completed run and lag of the replications in Artifactory."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "repository pattern",
+			Description: "[Default: *] Specifies the repositories whose replications should be shown. You can use wildcards to specify multiple repositories.",
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package replicationupdate
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt rplu <template path>"}
+
+func GetDescription() string {
+	return "Update existing replications in Artifactory."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "template path",
+			Description: "Specifies the local file system path of a replication template, or of a directory of templates, such as the one created by the “jfrog rt rplexp” command. The templates of a repository with several push replications are updated together.",
+		},
+	}
+}
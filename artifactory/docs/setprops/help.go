@@ -8,6 +8,7 @@ import (
 var Usage = []string{
 	"rt sp [command options] <files pattern> <file properties>",
 	"rt sp <file properties> --spec=<File Spec path> [command options]",
+	"rt sp --manifest=<CSV or JSON manifest path> [command options]",
 }
 
 const EnvVar string = common.JfrogCliFailNoOp
@@ -0,0 +1,22 @@
+package sync
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt sync [command options] <local directory> <repository path>"}
+
+func GetDescription() string {
+	return "Sync a local directory with a repository path in Artifactory, based on the SHA-256 checksums of the files, or their SHA-1 checksums if the SHA-256 checksums are missing in Artifactory."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "local directory",
+			Description: "Path to the local directory to sync.",
+		},
+		{
+			Name:        "repository path",
+			Description: "The repository path to sync, in the form of repo/path.",
+		},
+	}
+}
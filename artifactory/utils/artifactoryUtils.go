@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -142,3 +143,23 @@ func ExecuteAqlQuery(serviceManager artifactory.ArtifactoryServicesManager, aqlQ
 	}
 	return parsedResult.Results, nil
 }
+
+// DownloadRemoteFile downloads a single file from Artifactory to the given local path.
+func DownloadRemoteFile(serviceManager artifactory.ArtifactoryServicesManager, remotePath, localPath string) (err error) {
+	reader, err := serviceManager.ReadRemoteFile(remotePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	file, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
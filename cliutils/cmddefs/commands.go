@@ -19,4 +19,5 @@ const (
 	ReleaseBundleExport       = "release-bundle-export"
 	ReleaseBundleImport       = "release-bundle-import"
 	ReleaseBundleAnnotate     = "release-bundle-annotate"
+	ReleaseBundleMigrate      = "release-bundle-migrate"
 )
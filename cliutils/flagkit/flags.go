@@ -34,6 +34,7 @@ const (
 	Copy                   = "copy"
 	Delete                 = "delete"
 	Properties             = "properties"
+	SetProperties          = "set-properties"
 	Search                 = "search"
 	BuildPublish           = "build-publish"
 	BuildAppend            = "build-append"
@@ -51,6 +52,7 @@ const (
 	Gradle                 = "gradle"
 	GradleConfig           = "gradle-config"
 	DockerPromote          = "docker-promote"
+	DockerVerify           = "docker-verify"
 	Docker                 = "docker"
 	DockerPush             = "docker-push"
 	DockerPull             = "docker-pull"
@@ -82,10 +84,18 @@ const (
 	Poetry                 = "poetry"
 	Ping                   = "ping"
 	RtCurl                 = "rt-curl"
+	RtSync                 = "rt-sync"
+	RtCleanup              = "rt-cleanup"
+	GitLfsMigrate          = "git-lfs-migrate"
+	RtDoctor               = "rt-doctor"
 	TemplateConsumer       = "template-consumer"
 	RepoDelete             = "repo-delete"
 	ReplicationDelete      = "replication-delete"
+	ReplicationExport      = "replication-export"
+	ReplicationStatus      = "replication-status"
 	PermissionTargetDelete = "permission-target-delete"
+	PermissionTargetExport = "permission-target-export"
+	AccessReport           = "access-report"
 	// #nosec G101 -- False positive - no hardcoded credentials.
 	ArtifactoryAccessTokenCreate = "artifactory-access-token-create"
 	UserCreate                   = "user-create"
@@ -208,6 +218,11 @@ const (
 	symlinks          = "symlinks"
 	uploadAnt         = uploadPrefix + antFlag
 
+	// Upload watch mode flags
+	watch         = "watch"
+	watchDebounce = "watch-debounce"
+	watchStopFile = "watch-stop-file"
+
 	// Unique download flags
 	downloadPrefix       = "download-"
 	downloadRecursive    = downloadPrefix + Recursive
@@ -316,8 +331,64 @@ const (
 	glcDryRun = glcPrefix + dryRun
 	glcQuiet  = glcPrefix + quiet
 	glcRepo   = glcPrefix + repo
+	glcReport = glcPrefix + "report"
 	refs      = "refs"
 
+	// Unique git-lfs-migrate flags
+	glmPrefix    = "glm-"
+	glmDryRun    = glmPrefix + dryRun
+	glmRepo      = glmPrefix + repo
+	glmRefs      = glmPrefix + refs
+	glmSourceUrl = "source-url"
+
+	// Unique sync flags
+	syncPrefix         = "sync-"
+	syncMode           = "mode"
+	syncConflictPolicy = "conflict-policy"
+	syncStateDir       = "state-dir"
+	syncDryRun         = syncPrefix + dryRun
+	syncQuiet          = syncPrefix + quiet
+	syncDelete         = syncPrefix + "delete"
+
+	// Unique replication-status flags
+	replicationStatusPrefix        = "replication-status-"
+	replicationStatusFailIfLagging = replicationStatusPrefix + "fail-if-lagging"
+	replicationStatusFormat        = replicationStatusPrefix + Format
+
+	// Unique permission-target-export flags
+	permissionTargetExportNames = "permission-target-export-names"
+
+	// Unique access-report flags
+	accessReportPrefix   = "access-report-"
+	accessReportForUser  = accessReportPrefix + "for-user"
+	accessReportForGroup = accessReportPrefix + "for-group"
+	accessReportForRepo  = accessReportPrefix + "for-repo"
+	accessReportFormat   = accessReportPrefix + Format
+
+	// Unique doctor flags
+	doctorPrefix = "doctor-"
+	doctorRepo   = doctorPrefix + repo
+	doctorFormat = doctorPrefix + Format
+
+	// Unique direct-download flags
+	resume   = "resume"
+	cacheDir = "cache-dir"
+
+	// Unique cleanup flags
+	cleanupPrefix = "cleanup-"
+	cleanupDryRun = cleanupPrefix + dryRun
+	cleanupQuiet  = cleanupPrefix + quiet
+
+	// Search output flags
+	searchFormat  = "search-" + Format
+	searchFields  = "fields"
+	searchGroupBy = "group-by"
+	searchSumSize = "sum-size"
+
+	// Unique set-props manifest flags
+	propsManifest = "manifest"
+	propsReport   = "report"
+
 	// Build tool config flags
 	global          = "global"
 	serverIdResolve = "server-id-resolve"
@@ -345,6 +416,8 @@ const (
 	deploymentThreads = "deployment-threads"
 	skipLogin         = "skip-login"
 	validateSha       = "validate-sha"
+	imagePath         = "image-path"
+	verifyPull        = "verify"
 
 	// Unique docker promote flags
 	dockerPromotePrefix = "docker-promote-"
@@ -354,7 +427,11 @@ const (
 	dockerPromoteCopy   = dockerPromotePrefix + Copy
 
 	// Unique build docker create
-	imageFile = "image-file"
+	imageFile           = "image-file"
+	extractAttestations = "extract-attestations"
+	includeSignatures   = "include-signatures"
+	imageName           = "image-name"
+	dockerfile          = "dockerfile"
 
 	// Unique oc start-build flags
 	ocStartBuildPrefix = "oc-start-build-"
@@ -486,6 +563,7 @@ const (
 	PathMappingTarget        = "mapping-target"
 	lcPathMappingTarget      = lifecyclePrefix + PathMappingTarget
 	lcDryRun                 = lifecyclePrefix + dryRun
+	lcMigrateDryRun          = lifecyclePrefix + "migrate-" + dryRun
 	lcIncludeRepos           = lifecyclePrefix + IncludeRepos
 	lcExcludeRepos           = lifecyclePrefix + ExcludeRepos
 	PromotionType            = "promotion-type"
@@ -496,7 +574,23 @@ const (
 	SourceTypeReleaseBundles = "source-type-release-bundles"
 	SourceTypeBuilds         = "source-type-builds"
 	Draft                    = "draft"
+	SourceTypeImages         = "source-type-images"
+	SourceTypeCharts         = "source-type-charts"
+	IncludeChartImages       = "include-chart-images"
+	BaseArchives             = "base-archives"
+	KnownChecksums           = "known-checksums"
 	AddSources               = "add"
+	PromoteEnvironment       = "promote-env"
+	Distribute               = "distribute"
+	lcFormat                 = lifecyclePrefix + Format
+	SiteServers              = "site-servers"
+	lcSiteServers            = lifecyclePrefix + SiteServers
+	QueryName                = "query-name"
+	QueryCreatedFrom         = "query-created-from"
+	QueryCreatedTo           = "query-created-to"
+	QueryEnvironment         = "query-env"
+	QuerySha256              = "query-sha256"
+	lcQuiet                  = lifecyclePrefix + quiet
 
 	// Skills commands keys
 	SkillsPublish = "skills-publish"
@@ -537,6 +631,7 @@ var commandFlags = map[string][]string{
 	cmddefs.ReleaseBundleCreate: {
 		platformUrl, user, password, accessToken, serverId, lcSigningKey, lcSync, lcProject, lcBuilds, lcReleaseBundles,
 		specFlag, specVars, BuildName, BuildNumber, SourceTypeReleaseBundles, SourceTypeBuilds, Draft,
+		SourceTypeImages, SourceTypeCharts, IncludeChartImages,
 	},
 	cmddefs.ReleaseBundleUpdate: {
 		platformUrl, user, password, accessToken, serverId, lcSync, lcProject,
@@ -551,7 +646,7 @@ var commandFlags = map[string][]string{
 	},
 	cmddefs.ReleaseBundleDistribute: {
 		platformUrl, user, password, accessToken, serverId, lcProject, DistRules, site, city, countryCodes,
-		lcDryRun, CreateRepo, lcPathMappingPattern, lcPathMappingTarget, lcSync, maxWaitMinutes,
+		lcDryRun, CreateRepo, lcPathMappingPattern, lcPathMappingTarget, lcSync, maxWaitMinutes, lcFormat, lcSiteServers,
 	},
 	cmddefs.ReleaseBundleDeleteLocal: {
 		platformUrl, user, password, accessToken, serverId, deleteQuiet, lcSync, lcProject,
@@ -562,13 +657,18 @@ var commandFlags = map[string][]string{
 	},
 	cmddefs.ReleaseBundleExport: {
 		platformUrl, user, password, accessToken, serverId, lcPathMappingTarget, lcPathMappingPattern, Project,
-		downloadMinSplit, downloadSplitCount,
+		downloadMinSplit, downloadSplitCount, BaseArchives, KnownChecksums,
 	},
 	cmddefs.ReleaseBundleImport: {
-		user, password, accessToken, serverId, platformUrl,
+		user, password, accessToken, serverId, platformUrl, BaseArchives,
 	},
 	cmddefs.ReleaseBundleAnnotate: {
 		platformUrl, user, password, accessToken, serverId, lcProject, lcTag, lcProperties, lcDeleteProperties, propsRecursive,
+		QueryName, QueryCreatedFrom, QueryCreatedTo, QueryEnvironment, QuerySha256, lcQuiet,
+	},
+	cmddefs.ReleaseBundleMigrate: {
+		platformUrl, user, password, accessToken, serverId, lcSigningKey, lcSync, lcProject, lcMigrateDryRun, PromoteEnvironment,
+		Distribute, CreateRepo, maxWaitMinutes, lcFormat,
 	},
 	AddConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
@@ -586,7 +686,7 @@ var commandFlags = map[string][]string{
 		ClientCertKeyPath, specFlag, specVars, BuildName, BuildNumber, module, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, retryWaitTime, dryRun, uploadExplode, symlinks, includeDirs,
 		failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, Project,
-		uploadAnt, uploadArchive, uploadMinSplit, uploadSplitCount, chunkSize,
+		uploadAnt, uploadArchive, uploadMinSplit, uploadSplitCount, chunkSize, watch, watchDebounce, watchStopFile,
 	},
 	Download: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -601,7 +701,7 @@ var commandFlags = map[string][]string{
 		ClientCertKeyPath, specFlag, specVars, BuildName, BuildNumber, module, exclusions,
 		downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, downloadMinSplit, downloadSplitCount,
 		retries, retryWaitTime, dryRun, downloadExplode, threads, downloadSyncDeletes, syncDeletesQuiet, skipChecksum, failNoOp, detailedSummary, Project,
-		bypassArchiveInspection, validateSymlinks, InsecureTls, bundle,
+		bypassArchiveInspection, validateSymlinks, InsecureTls, bundle, resume, cacheDir,
 	},
 	Move: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -625,7 +725,8 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		InsecureTls, searchTransitive, retries, retryWaitTime, Project, searchInclude,
+		InsecureTls, searchTransitive, retries, retryWaitTime, Project, searchInclude, searchFormat, searchFields,
+		searchGroupBy, searchSumSize,
 	},
 	Properties: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -633,6 +734,12 @@ var commandFlags = map[string][]string{
 		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
 		InsecureTls, retries, retryWaitTime, Project, repoOnly,
 	},
+	SetProperties: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
+		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
+		InsecureTls, retries, retryWaitTime, Project, repoOnly, propsManifest, propsReport, dryRun,
+	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, bpOverwrite, collectEnv, collectGitInfo, gitConfigFilePath, dotGitPath, depExclude,
@@ -652,7 +759,7 @@ var commandFlags = map[string][]string{
 	},
 	BuildDockerCreate: {
 		BuildName, BuildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, imageFile, Project,
+		serverId, imageFile, Project, extractAttestations, includeSignatures, imageName, dockerfile,
 	},
 	OcStartBuild: {
 		BuildName, BuildNumber, module, Project, serverId, ocStartBuildRepo,
@@ -671,7 +778,20 @@ var commandFlags = map[string][]string{
 	},
 	GitLfsClean: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
-		glcQuiet, InsecureTls, retries, retryWaitTime,
+		glcQuiet, glcReport, InsecureTls, retries, retryWaitTime,
+	},
+	GitLfsMigrate: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, glmRepo, glmRefs, glmSourceUrl, glmDryRun, threads, InsecureTls, retries, retryWaitTime,
+	},
+	RtSync: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, syncMode, syncConflictPolicy, syncStateDir, syncDelete, syncDryRun, syncQuiet, threads,
+		InsecureTls, retries, retryWaitTime,
+	},
+	RtCleanup: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, cleanupDryRun, cleanupQuiet, threads, InsecureTls, retries, retryWaitTime,
 	},
 	CocoapodsConfig: {
 		global, serverIdResolve, repoResolve,
@@ -708,13 +828,16 @@ var commandFlags = map[string][]string{
 		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, url, user, password, accessToken, sshPassphrase, sshKeyPath,
 		serverId,
 	},
+	DockerVerify: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, Project,
+	},
 	ContainerPush: {
 		BuildName, BuildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, skipLogin, threads, Project, detailedSummary, validateSha,
+		serverId, skipLogin, threads, Project, detailedSummary, validateSha, imagePath,
 	},
 	ContainerPull: {
 		BuildName, BuildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, skipLogin, Project,
+		serverId, skipLogin, Project, verifyPull,
 	},
 	NpmConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
@@ -769,6 +892,10 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls,
 	},
+	RtDoctor: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, doctorRepo, doctorFormat,
+	},
 	RtCurl: {
 		serverId,
 	},
@@ -802,10 +929,26 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, deleteQuiet,
 	},
+	ReplicationExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath,
+	},
+	ReplicationStatus: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, replicationStatusFailIfLagging, replicationStatusFormat,
+	},
 	PermissionTargetDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, deleteQuiet,
 	},
+	PermissionTargetExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, permissionTargetExportNames,
+	},
+	AccessReport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, accessReportForUser, accessReportForGroup, accessReportForRepo, accessReportFormat,
+	},
 	ArtifactoryAccessTokenCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, rtAtcGroups, rtAtcGrantAdmin, rtAtcExpiry, rtAtcRefreshable, rtAtcAudience,
@@ -878,11 +1021,20 @@ var flagsMap = map[string]components.Flag{
 	OrderBy:           components.NewStringFlag(OrderBy, "Defines the criterion by which to order the list of promotions: created (standard timestamp or milliseconds), createdBy", components.SetMandatoryFalse()),
 	Includes:          components.NewStringFlag(Includes, "Either messages: Returns any error messages generated when creating the Release Bundle version.or permissions: Returns the permission settings for promoting, distributing, and deleting these Release Bundle versions.", components.SetMandatoryFalse()),
 	bundle:            components.NewStringFlag(bundle, "If specified, only artifacts of the specified bundle are matched. The value format is bundle-name/bundle-version.", components.SetMandatoryFalse()),
-	imageFile:         components.NewStringFlag(imageFile, "[Mandatory] Path to a file which includes one line in the following format: <IMAGE-TAG>@sha256:<MANIFEST-SHA256>.", components.SetMandatoryTrue()),
+	imageFile:         components.NewStringFlag(imageFile, "[Mandatory] Path to the file written by the image builder. Supported formats: a line in the format <IMAGE-TAG>@sha256:<MANIFEST-SHA256> (Kaniko '--image-name-with-digest-file'), the manifest digest only (Kaniko '--digest-file', Buildah '--digestfile'), or a BuildKit/buildx '--metadata-file'.", components.SetMandatoryTrue()),
 	ocStartBuildRepo:  components.NewStringFlag(repo, "[Mandatory] The name of the repository to which the image was pushed.", components.SetMandatoryTrue()),
 	runNative:         components.NewBoolFlag(runNative, "Set to true if you'd like to use the native client configurations. Note: This flag would invoke native client behind the scenes, has performance implications and does not support deployment view and detailed summary.", components.WithBoolDefaultValueFalse()),
 	npmWorkspaces:     components.NewBoolFlag(npmWorkspaces, "Set to true if you'd like to use npm workspaces.", components.WithBoolDefaultValueFalse()),
 
+	imageName:           components.NewStringFlag(imageName, "[Optional] The image name and tag, for image files which include the image digest only, such as the files written by Kaniko '--digest-file' or Buildah '--digestfile'.", components.SetMandatoryFalse()),
+	dockerfile:          components.NewStringFlag(dockerfile, "[Optional] Path to the Dockerfile the image was built from. Its base images are recorded as dependencies of the image.", components.SetMandatoryFalse()),
+	extractAttestations: components.NewBoolFlag(extractAttestations, "[Default: false] Set to true to extract the SBOM and provenance predicates from the image's attestation manifests, and deploy them next to the attestation manifests as readable JSON files.", components.WithBoolDefaultValueFalse()),
+	includeSignatures:   components.NewBoolFlag(includeSignatures, "[Default: false] Set to true to record the cosign and Notation signatures of the image as artifacts of the build, so they are promoted along with the image.", components.WithBoolDefaultValueFalse()),
+
+	imagePath: components.NewStringFlag(imagePath, "[Optional] Path to an OCI image layout directory, or to an OCI or 'docker save' tarball, to push the image from without a container daemon.", components.SetMandatoryFalse()),
+
+	verifyPull: components.NewBoolFlag(verifyPull, "[Default: false] Set to true to verify that the pulled image matches the image in Artifactory. The verified digest is recorded in the build-info, if collected.", components.WithBoolDefaultValueFalse()),
+
 	// Config specific commands flags
 	interactive:       components.NewBoolFlag(interactive, "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.", components.WithBoolDefaultValueFalse()),
 	EncPassword:       components.NewBoolFlag(EncPassword, "[Default: true] If set to false then the configured password will not be encrypted using Artifactory's encryption API.", components.WithBoolDefaultValueFalse()),
@@ -940,6 +1092,11 @@ var flagsMap = map[string]components.Flag{
 	uploadSplitCount:  components.NewStringFlag(SplitCount, "[Default: "+strconv.Itoa(UploadSplitCount)+"] The maximum number of parts that can be concurrently uploaded per file during a multi-part upload. Set to 0 to disable multi-part upload. This option, as well as the functionality of multi-part upload, requires Artifactory with S3 or GCP storage.", components.SetMandatoryFalse()),
 	chunkSize:         components.NewStringFlag(chunkSize, "[Default: "+strconv.Itoa(UploadChunkSizeMb)+"] The upload chunk size in MiB that can be concurrently uploaded during a multi-part upload. This option, as well as the functionality of multi-part upload, requires Artifactory with S3 or GCP storage.", components.SetMandatoryFalse()),
 
+	// Upload watch mode flags
+	watch:         components.NewBoolFlag(watch, "Set to true to keep watching the source files after the initial upload, and upload new and modified files until the command is interrupted or the stop file is created.", components.WithBoolDefaultValueFalse()),
+	watchDebounce: components.NewStringFlag(watchDebounce, "[Default: 2s] Only relevant with --watch. The time a file should remain unmodified before it is uploaded, to avoid uploading partially written files. For example: 500ms, 5s.", components.SetMandatoryFalse()),
+	watchStopFile: components.NewStringFlag(watchStopFile, "Only relevant with --watch. Path to a file whose creation stops the watch.", components.SetMandatoryFalse()),
+
 	// Move specific commands flags
 	moveRecursive:    components.NewBoolFlag(Recursive, "[Default: true] Set to false if you do not wish to move artifacts inside sub-folders in Artifactory.", components.WithBoolDefaultValueFalse()),
 	moveFlat:         components.NewBoolFlag(flat, "If set to false, files are moved according to their file system hierarchy.", components.WithBoolDefaultValueFalse()),
@@ -1020,6 +1177,56 @@ var flagsMap = map[string]components.Flag{
 	glcRepo:   components.NewStringFlag(repo, "Local Git LFS repository which should be cleaned. If omitted, this is detected from the Git repository.", components.SetMandatoryFalse()),
 	glcDryRun: components.NewBoolFlag(dryRun, "If true, cleanup is only simulated. No files are actually deleted.", components.WithBoolDefaultValueFalse()),
 	glcQuiet:  components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the delete confirmation message.", components.WithBoolDefaultValueFalse()),
+	glcReport: components.NewBoolFlag("report", "Set to true to only print the number and total size of the unreferenced files, for each of the comma-separated references and for all of them together. No files are deleted.", components.WithBoolDefaultValueFalse()),
+
+	// GitLfsMigrate specific commands flags
+	glmRepo:      components.NewStringFlag(repo, "[Mandatory] Git LFS repository in Artifactory, to which the LFS objects should be uploaded.", components.SetMandatoryTrue()),
+	glmRefs:      components.NewStringFlag(refs, "[Default: refs/*] List of comma-separated(,) Git references in the form of \"ref1,ref2,...\", whose history should be scanned for LFS pointers.", components.SetMandatoryFalse()),
+	glmSourceUrl: components.NewStringFlag(glmSourceUrl, "URL of the source LFS server, from which objects which are missing in the local LFS storage should be downloaded. Credentials may be included in the URL.", components.SetMandatoryFalse()),
+	glmDryRun:    components.NewBoolFlag(dryRun, "Set to true to only list the LFS objects which would be uploaded.", components.WithBoolDefaultValueFalse()),
+
+	// Sync specific commands flags
+	syncMode:           components.NewStringFlag(syncMode, "[Default: both] The sync direction. 'push' makes the repository path a mirror of the local directory, 'pull' makes the local directory a mirror of the repository path, and 'both' propagates the changes of each side to the other.", components.SetMandatoryFalse()),
+	syncConflictPolicy: components.NewStringFlag(syncConflictPolicy, "[Default: skip] How to handle files which changed both locally and in Artifactory since the last sync. Can be one of 'skip', 'fail', 'local', 'remote' or 'newer'.", components.SetMandatoryFalse()),
+	syncStateDir:       components.NewStringFlag(syncStateDir, "[Default: $JFROG_CLI_HOME_DIR/sync] Directory in which the state of the last sync is cached.", components.SetMandatoryFalse()),
+	syncDelete:         components.NewBoolFlag("delete", "Set to true to delete the files which were added to Artifactory in push mode, or locally in pull mode, so that the target becomes an exact mirror. Otherwise, these files are kept.", components.WithBoolDefaultValueFalse()),
+	syncDryRun:         components.NewBoolFlag(dryRun, "Set to true to only print the sync plan, without changing any files.", components.WithBoolDefaultValueFalse()),
+	syncQuiet:          components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the delete confirmation message.", components.WithBoolDefaultValueFalse()),
+
+	// Cleanup specific commands flags
+	cleanupDryRun: components.NewBoolFlag(dryRun, "Set to true to only print the files which would be deleted and the space which would be reclaimed.", components.WithBoolDefaultValueFalse()),
+	cleanupQuiet:  components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the delete confirmation message.", components.WithBoolDefaultValueFalse()),
+
+	// Replication status flags
+	replicationStatusFailIfLagging: components.NewStringFlag("fail-if-lagging", "[Optional] Fail if any of the enabled replications hasn't completed within this duration, for example '30m' or '2h'. Replications which have never completed are lagging.", components.SetMandatoryFalse()),
+	replicationStatusFormat:        components.NewStringFlag(Format, "[Default: table] The output format. Can be one of 'table' or 'json'.", components.SetMandatoryFalse()),
+
+	// Permission target export flags
+	permissionTargetExportNames: components.NewStringFlag("names", "[Optional] Comma-separated list of the permission targets to export. If not provided, all the permission targets are exported.", components.SetMandatoryFalse()),
+
+	// Access report flags
+	accessReportForUser:  components.NewStringFlag("for-user", "[Optional] Report the actions granted to this user, directly or through the groups the user belongs to.", components.SetMandatoryFalse()),
+	accessReportForGroup: components.NewStringFlag("for-group", "[Optional] Report the actions granted to this group.", components.SetMandatoryFalse()),
+	accessReportForRepo:  components.NewStringFlag("for-repo", "[Optional] Report the actions granted on this repository, optionally followed by a path in the repository to evaluate the include and exclude patterns, for example 'libs-local/org/acme/'.", components.SetMandatoryFalse()),
+	accessReportFormat:   components.NewStringFlag(Format, "[Default: table] The output format. Can be one of 'table' or 'json'.", components.SetMandatoryFalse()),
+
+	// Doctor flags
+	doctorRepo:   components.NewStringFlag(repo, "Repository on which to check the read, deploy and delete permissions, and to sample the throughput. A small probe file is deployed to the repository and deleted.", components.SetMandatoryFalse()),
+	doctorFormat: components.NewStringFlag(Format, "[Default: text] The output format. Can be one of 'text' or 'json'.", components.SetMandatoryFalse()),
+
+	// Direct download resume and cache flags
+	resume:   components.NewBoolFlag(resume, "Set to true to resume interrupted downloads with range requests, instead of downloading them from the start.", components.WithBoolDefaultValueFalse()),
+	cacheDir: components.NewStringFlag(cacheDir, "Path to a local content-addressable cache directory, which may be shared by several workspaces on one machine. Files are copied from the cache, and their checksums are verified.", components.SetMandatoryFalse()),
+
+	// Search output flags
+	searchFormat:  components.NewStringFlag(Format, "[Default: json] The output format. Can be one of 'json', 'csv', 'table' or 'ndjson'. The ndjson format prints a JSON object per line, and is suitable for streaming very large results.", components.SetMandatoryFalse()),
+	searchFields:  components.NewStringFlag(searchFields, "List of comma-separated(,) fields to output, such as 'path,size,sha256'. Use 'props' for all the properties, or 'props.<key>' for the values of a single property.", components.SetMandatoryFalse()),
+	searchGroupBy: components.NewStringFlag(searchGroupBy, "Only relevant with --count or --sum-size. Group the totals by 'repo', by the first segments of the path in the form of 'path:<depth>', or by a property value in the form of 'props.<key>'.", components.SetMandatoryFalse()),
+	searchSumSize: components.NewBoolFlag(searchSumSize, "Set to true to display only the total size of the files found.", components.WithBoolDefaultValueFalse()),
+
+	// Set-props manifest flags
+	propsManifest: components.NewStringFlag(propsManifest, "Path to a CSV or JSON manifest of per-artifact properties changes, which is used instead of the files pattern and the properties arguments. A CSV manifest starts with a 'path' column, followed by a column per property. A column named '+key' adds values to the property, a column named '-key' removes the property when set to true, and any other column replaces the property values.", components.SetMandatoryFalse()),
+	propsReport:   components.NewStringFlag(propsReport, "Only relevant with --manifest. Path to a CSV or JSON file to which the status of each manifest row is written. The report can be used as a manifest to retry only the failed rows.", components.SetMandatoryFalse()),
 
 	// Config commands flags
 	global:          components.NewBoolFlag(global, "Set to true if you'd like the configuration to be global (for all projects). Specific projects can override the global configuration.", components.WithBoolDefaultValueFalse()),
@@ -1131,7 +1338,8 @@ var flagsMap = map[string]components.Flag{
 	lcPathMappingPattern: components.NewStringFlag(PathMappingPattern, "Specify along with "+PathMappingTarget+" to distribute artifacts to a different path on the edge node. You can use wildcards to specify multiple artifacts.", components.SetMandatoryFalse()),
 	lcPathMappingTarget: components.NewStringFlag(PathMappingTarget, "The target path for distributed artifacts on the edge node. If not specified, the artifacts will have the same path and name on the edge node, as on the source Artifactory server. "+
 		"For flexibility in specifying the distribution path, you can include placeholders in the form of {1}, {2} which are replaced by corresponding tokens in the pattern path that are enclosed in parenthesis.` `", components.SetMandatoryFalse()),
-	lcDryRun: components.NewBoolFlag(dryRun, "Set to true to only simulate the distribution of the release bundle.", components.WithBoolDefaultValueFalse()),
+	lcDryRun:        components.NewBoolFlag(dryRun, "Set to true to only simulate the distribution of the release bundle.", components.WithBoolDefaultValueFalse()),
+	lcMigrateDryRun: components.NewBoolFlag(dryRun, "Set to true to only report the release bundles that would be migrated, without migrating them.", components.WithBoolDefaultValueFalse()),
 	lcIncludeRepos: components.NewStringFlag(IncludeRepos, "List of semicolon-separated(;) repositories to include in the promotion. If this property is left undefined, all repositories (except those specifically excluded) are included in the promotion. "+
 		"If one or more repositories are specifically included, all other repositories are excluded.` `", components.SetMandatoryFalse()),
 	lcExcludeRepos:           components.NewStringFlag(ExcludeRepos, "List of semicolon-separated(;) repositories to exclude from the promotion.` `", components.SetMandatoryFalse()),
@@ -1143,7 +1351,22 @@ var flagsMap = map[string]components.Flag{
 	SourceTypeReleaseBundles: components.NewStringFlag(SourceTypeReleaseBundles, "List of semicolon-seperated(;) release bundles in the form of 'name=releaseBundleName1, version=version1; name=releaseBundleName2, version=version2' to be included in the new bundle.", components.SetMandatoryFalse()),
 	SourceTypeBuilds:         components.NewStringFlag(SourceTypeBuilds, "List of semicolon-separated(;) builds in the form of 'name=buildName1, id=runID1, include-deps=true; name=buildName2, id=runID2' to be included in the new bundle.", components.SetMandatoryFalse()),
 	Draft:                    components.NewBoolFlag(Draft, "Set to true to create the release bundle as a draft. A draft release bundle can be updated and finalized later.", components.WithBoolDefaultValueFalse()),
+	SourceTypeImages:         components.NewStringFlag(SourceTypeImages, "List of semicolon-separated(;) container images in the form of 'registry/repo/image:tag' or 'registry/repo/image@sha256:digest' to be included in the new bundle. All the platforms of multi-arch images are included.", components.SetMandatoryFalse()),
+	SourceTypeCharts:         components.NewStringFlag(SourceTypeCharts, "List of semicolon-separated(;) Helm charts in the form of 'repo/chart:version' to be included in the new bundle.", components.SetMandatoryFalse()),
+	IncludeChartImages:       components.NewBoolFlag(IncludeChartImages, "Set to true to also include the container images referenced by the rendered templates of the charts provided by --"+SourceTypeCharts+". Requires the helm client.", components.WithBoolDefaultValueFalse()),
+	BaseArchives:             components.NewStringFlag(BaseArchives, "List of semicolon-separated(;) paths to previously exported release bundle archives. When exporting, the content found in these archives is left out of the created delta archive. When importing a delta archive, the content it references is restored from these archives.", components.SetMandatoryFalse()),
+	KnownChecksums:           components.NewStringFlag(KnownChecksums, "Path to a file listing the SHA-256 checksums of content which already exists on the import side, one per line. This content is left out of the created delta archive.", components.SetMandatoryFalse()),
 	AddSources:               components.NewBoolFlag(AddSources, "Add sources to an existing draft release bundle.", components.WithBoolDefaultValueFalse()),
+	PromoteEnvironment:       components.NewStringFlag(PromoteEnvironment, "Environment to promote the migrated release bundles to. If not provided, the migrated release bundles are not promoted.", components.SetMandatoryFalse()),
+	Distribute:               components.NewBoolFlag(Distribute, "Set to true to distribute the migrated release bundles to the sites the Distribution v1 release bundles were distributed to.", components.WithBoolDefaultValueFalse()),
+	lcFormat:                 components.NewStringFlag(Format, "[Default: table] Defines the output format of the command. Acceptable values are: table and json.", components.SetMandatoryFalse()),
+	lcSiteServers:            components.NewStringFlag(SiteServers, "List of semicolon-separated(;) site=server-id pairs, mapping distribution sites to configured server IDs. Used by --dry-run to verify the target paths on the sites. Sites which are not mapped are matched with the server ID equal to the site name.` `", components.SetMandatoryFalse()),
+	QueryName:                components.NewStringFlag(QueryName, "Annotate all the Release Bundle versions whose name matches this pattern. Wildcards are supported.", components.SetMandatoryFalse()),
+	QueryCreatedFrom:         components.NewStringFlag(QueryCreatedFrom, "Annotate only Release Bundle versions created at or after this date, in the YYYY-MM-DD or RFC3339 format.", components.SetMandatoryFalse()),
+	QueryCreatedTo:           components.NewStringFlag(QueryCreatedTo, "Annotate only Release Bundle versions created at or before this date, in the YYYY-MM-DD or RFC3339 format.", components.SetMandatoryFalse()),
+	QueryEnvironment:         components.NewStringFlag(QueryEnvironment, "Annotate only Release Bundle versions promoted to this environment.", components.SetMandatoryFalse()),
+	QuerySha256:              components.NewStringFlag(QuerySha256, "Annotate only Release Bundle versions containing an artifact with this SHA-256 checksum.", components.SetMandatoryFalse()),
+	lcQuiet:                  components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the confirmation message.", components.WithBoolDefaultValueFalse()),
 
 	// Skills-specific flags
 	repo:         components.NewStringFlag(repo, "Skills repository key in Artifactory.", components.SetMandatoryFalse()),
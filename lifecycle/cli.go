@@ -22,6 +22,7 @@ import (
 	rbExport "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/export"
 	rbFinalize "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/finalize"
 	rbImport "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/importbundle"
+	rbMigrate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/migrate"
 	rbPromote "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/promote"
 	rbUpdate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/update"
 	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -144,6 +145,15 @@ func GetCommands() []components.Command {
 			Category:    lcCategory,
 			Action:      releaseBundleSearch,
 		},
+		{
+			Name:        cmddefs.ReleaseBundleMigrate,
+			Aliases:     []string{"rbm"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleMigrate),
+			Description: rbMigrate.GetDescription(),
+			Arguments:   rbMigrate.GetArguments(),
+			Category:    lcCategory,
+			Action:      migrate,
+		},
 	}
 }
 
@@ -175,9 +185,23 @@ func assertValidCreationMethod(c *components.Context) error {
 
 	multiReleaseBundleSourcesCount := coreutils.SumTrueValues(multiReleaseBundleSources)
 
+	if c.IsFlagSet(flagkit.SourceTypeImages) || c.IsFlagSet(flagkit.SourceTypeCharts) {
+		return validateContainerCreationMethod(c, methodCount+multiReleaseBundleSourcesCount)
+	}
 	return validateCreationMethods(c, methodCount, multiReleaseBundleSourcesCount)
 }
 
+func validateContainerCreationMethod(c *components.Context, otherMethodsCount int) error {
+	if otherMethodsCount > 0 {
+		return errorutils.CheckErrorf("--%s and --%s cannot be combined with other creation sources",
+			flagkit.SourceTypeImages, flagkit.SourceTypeCharts)
+	}
+	if c.GetBoolFlagValue(flagkit.IncludeChartImages) && !c.IsFlagSet(flagkit.SourceTypeCharts) {
+		return errorutils.CheckErrorf("--%s can only be used together with --%s", flagkit.IncludeChartImages, flagkit.SourceTypeCharts)
+	}
+	return nil
+}
+
 func validateRegularMethods(c *components.Context, methodCount int) error {
 	if err := validateSingleCreationMethod(methodCount); err != nil {
 		return err
@@ -255,6 +279,10 @@ func create(c *components.Context) (err error) {
 		SetReleaseBundleProject(pluginsCommon.GetProject(c)).SetSpec(creationSpec).
 		SetBuildsSpecPath(c.GetStringFlagValue(flagkit.Builds)).SetReleaseBundlesSpecPath(c.GetStringFlagValue(flagkit.ReleaseBundles))
 
+	createCmd.SetImages(splitSourceReferences(c.GetStringFlagValue(flagkit.SourceTypeImages))).
+		SetCharts(splitSourceReferences(c.GetStringFlagValue(flagkit.SourceTypeCharts))).
+		SetIncludeChartImages(c.GetBoolFlagValue(flagkit.IncludeChartImages))
+
 	err = lifecycle.ValidateFeatureSupportedVersion(lcDetails, minArtifactoryVersionForMultiSourceSupport)
 	// err == nil means new flags are supported and may be added to createCmd
 	if err == nil {
@@ -265,6 +293,16 @@ func create(c *components.Context) (err error) {
 	return commands.Exec(createCmd)
 }
 
+// splitSourceReferences splits a semicolon-separated list of references, such as images, charts or archive paths.
+func splitSourceReferences(value string) (references []string) {
+	for _, reference := range strings.Split(value, ";") {
+		if reference = strings.TrimSpace(reference); reference != "" {
+			references = append(references, reference)
+		}
+	}
+	return
+}
+
 func validateUpdateReleaseBundleContext(c *components.Context) error {
 	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
 		return err
@@ -371,6 +409,10 @@ func getReleaseBundleCreationSpec(c *components.Context) (*speccore.SpecFiles, e
 		return nil, nil
 	}
 
+	if c.IsFlagSet(flagkit.SourceTypeImages) || c.IsFlagSet(flagkit.SourceTypeCharts) {
+		return nil, nil
+	}
+
 	if c.IsFlagSet(flagkit.SourceTypeReleaseBundles) || c.IsFlagSet(flagkit.SourceTypeBuilds) {
 		return nil, nil
 	}
@@ -435,6 +477,10 @@ func distribute(c *components.Context) error {
 	if err != nil {
 		return err
 	}
+	siteServers, err := parseSiteServers(c.GetStringFlagValue(flagkit.SiteServers))
+	if err != nil {
+		return err
+	}
 
 	distributeCmd := lifecycle.NewReleaseBundleDistributeCommand()
 	distributeCmd.SetServerDetails(lcDetails).
@@ -447,10 +493,29 @@ func distribute(c *components.Context) error {
 		SetPathMappingPattern(c.GetStringFlagValue(flagkit.PathMappingPattern)).
 		SetPathMappingTarget(c.GetStringFlagValue(flagkit.PathMappingTarget)).
 		SetSync(c.GetBoolFlagValue(flagkit.Sync)).
-		SetMaxWaitMinutes(maxWaitMinutes)
+		SetMaxWaitMinutes(maxWaitMinutes).
+		SetFormat(c.GetStringFlagValue(flagkit.Format)).
+		SetSiteServers(siteServers)
 	return commands.Exec(distributeCmd)
 }
 
+// parseSiteServers parses the semicolon-separated site=server-id pairs of the --site-servers option.
+func parseSiteServers(value string) (map[string]string, error) {
+	siteServers := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		site, serverId, found := strings.Cut(pair, "=")
+		site, serverId = strings.TrimSpace(site), strings.TrimSpace(serverId)
+		if !found || site == "" || serverId == "" {
+			return nil, errorutils.CheckErrorf("invalid --%s value '%s'. Expected semicolon-separated site=server-id pairs", flagkit.SiteServers, value)
+		}
+		siteServers[site] = serverId
+	}
+	return siteServers, nil
+}
+
 func deleteLocal(c *components.Context) error {
 	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
 		return err
@@ -533,7 +598,9 @@ func export(c *components.Context) error {
 	exportCmd.
 		SetServerDetails(lcDetails).
 		SetReleaseBundleExportModifications(modifications).
-		SetDownloadConfiguration(*downloadConfig)
+		SetDownloadConfiguration(*downloadConfig).
+		SetBaseArchives(splitSourceReferences(c.GetStringFlagValue(flagkit.BaseArchives))).
+		SetKnownChecksumsFile(c.GetStringFlagValue(flagkit.KnownChecksums))
 
 	return commands.Exec(exportCmd)
 }
@@ -557,7 +624,8 @@ func releaseBundleImport(c *components.Context) error {
 	}
 	importCmd.
 		SetServerDetails(rtDetails).
-		SetFilepath(c.GetArgumentAt(0))
+		SetFilepath(c.GetArgumentAt(0)).
+		SetBaseArchives(splitSourceReferences(c.GetStringFlagValue(flagkit.BaseArchives)))
 
 	return commands.Exec(importCmd)
 }
@@ -567,7 +635,11 @@ func annotate(c *components.Context) error {
 		return err
 	}
 
-	if c.GetNumberOfArgs() < 2 {
+	query, err := getReleaseBundleQuery(c)
+	if err != nil {
+		return err
+	}
+	if query != nil && c.GetNumberOfArgs() != 0 || query == nil && c.GetNumberOfArgs() < 2 {
 		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
 	}
 
@@ -598,10 +670,85 @@ func annotate(c *components.Context) error {
 		SetTag(c.GetStringFlagValue(flagkit.Tag), tagExist).
 		SetProps(c.GetStringFlagValue(flagkit.Properties)).
 		DeleteProps(c.GetStringFlagValue(flagkit.DeleteProperty)).
-		SetRecursive(c.GetBoolFlagValue(flagkit.Recursive), c.IsFlagSet(flagkit.Recursive))
+		SetRecursive(c.GetBoolFlagValue(flagkit.Recursive), c.IsFlagSet(flagkit.Recursive)).
+		SetQuery(query).
+		SetQuiet(pluginsCommon.GetQuietValue(c))
 	return commands.Exec(annotateCmd)
 }
 
+// getReleaseBundleQuery returns the query to select the release bundle versions to annotate, or nil if no query flag is set.
+func getReleaseBundleQuery(c *components.Context) (*lifecycle.ReleaseBundleQuery, error) {
+	queryFlags := []bool{
+		c.IsFlagSet(flagkit.QueryName),
+		c.IsFlagSet(flagkit.QueryCreatedFrom),
+		c.IsFlagSet(flagkit.QueryCreatedTo),
+		c.IsFlagSet(flagkit.QueryEnvironment),
+		c.IsFlagSet(flagkit.QuerySha256),
+	}
+	if coreutils.SumTrueValues(queryFlags) == 0 {
+		return nil, nil
+	}
+	createdFrom, err := lifecycle.ParseQueryDate(c.GetStringFlagValue(flagkit.QueryCreatedFrom), false)
+	if err != nil {
+		return nil, err
+	}
+	createdTo, err := lifecycle.ParseQueryDate(c.GetStringFlagValue(flagkit.QueryCreatedTo), true)
+	if err != nil {
+		return nil, err
+	}
+	return &lifecycle.ReleaseBundleQuery{
+		NamePattern: c.GetStringFlagValue(flagkit.QueryName),
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Environment: c.GetStringFlagValue(flagkit.QueryEnvironment),
+		Sha256:      c.GetStringFlagValue(flagkit.QuerySha256),
+	}, nil
+}
+
+func migrate(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if len(c.Arguments) != 1 && len(c.Arguments) != 2 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	// The Distribution v1 bundles are read from the Distribution service of the same platform, unless another one is configured.
+	if lcDetails.DistributionUrl == "" {
+		lcDetails.DistributionUrl = strings.TrimSuffix(lcDetails.LifecycleUrl, "lifecycle/") + "distribution/"
+	}
+
+	maxWaitMinutes, err := c.GetDefaultIntFlagValueIfNotSet("max-wait-minutes", 60)
+	if err != nil {
+		return err
+	}
+
+	version := ""
+	if len(c.Arguments) == 2 {
+		version = c.GetArgumentAt(1)
+	}
+
+	migrateCmd := lifecycle.NewReleaseBundleMigrateCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleVersion(version).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetSigningKeyName(c.GetStringFlagValue(flagkit.SigningKey)).
+		SetSync(c.GetBoolFlagValue(flagkit.Sync)).
+		SetPromoteEnvironment(c.GetStringFlagValue(flagkit.PromoteEnvironment)).
+		SetDistribute(c.GetBoolFlagValue(flagkit.Distribute)).
+		SetAutoCreateRepo(c.GetBoolFlagValue(flagkit.CreateRepo)).
+		SetMaxWaitMinutes(maxWaitMinutes).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).
+		SetFormat(c.GetStringFlagValue(flagkit.Format))
+	return commands.Exec(migrateCmd)
+}
+
 func validateDistributeCommand(c *components.Context) error {
 	if err := distribution.ValidateReleaseBundleDistributeCmd(c); err != nil {
 		return err
@@ -421,3 +421,16 @@ func TestValidateFinalizeReleaseBundleContext(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSiteServers(t *testing.T) {
+	siteServers, err := parseSiteServers("edge-eu=eu; edge-us=us;")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"edge-eu": "eu", "edge-us": "us"}, siteServers)
+
+	siteServers, err = parseSiteServers("")
+	assert.NoError(t, err)
+	assert.Empty(t, siteServers)
+
+	_, err = parseSiteServers("edge-eu")
+	assert.ErrorContains(t, err, "invalid --site-servers value")
+}
@@ -20,6 +20,8 @@ type ReleaseBundleAnnotateCommand struct {
 	deleteProps               string
 	deletePropsExist          bool
 	recursive                 bool
+	query                     *ReleaseBundleQuery
+	quiet                     bool
 	validateVersionFunc       func(*config.ServerDetails, string) error
 	getPrerequisitesFunc      func() (*lifecycle.LifecycleServicesManager, services.ReleaseBundleDetails, services.CommonOptionalQueryParams, error)
 	annotateReleaseBundleFunc func(*ReleaseBundleAnnotateCommand, *lifecycle.LifecycleServicesManager,
@@ -88,6 +90,17 @@ func (rba *ReleaseBundleAnnotateCommand) DeleteProps(deleteProps string) *Releas
 	return rba
 }
 
+// SetQuery makes the command annotate every release bundle version matching the query, instead of a single name and version.
+func (rba *ReleaseBundleAnnotateCommand) SetQuery(query *ReleaseBundleQuery) *ReleaseBundleAnnotateCommand {
+	rba.query = query
+	return rba
+}
+
+func (rba *ReleaseBundleAnnotateCommand) SetQuiet(quiet bool) *ReleaseBundleAnnotateCommand {
+	rba.quiet = quiet
+	return rba
+}
+
 func (rba *ReleaseBundleAnnotateCommand) ServerDetails() (*config.ServerDetails, error) {
 	return rba.serverDetails, nil
 }
@@ -106,6 +119,10 @@ func (rba *ReleaseBundleAnnotateCommand) Run() error {
 		return err
 	}
 
+	if rba.query != nil {
+		return rba.runQuery(servicesManager, queryParams)
+	}
+
 	err = rba.annotateReleaseBundleFunc(rba, servicesManager, rbDetails, queryParams)
 	if err != nil {
 		return err
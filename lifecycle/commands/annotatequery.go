@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	searchPageSize       = 100
+	queryDateLayout      = "2006-01-02"
+	promotionCompleted   = "COMPLETED"
+	annotationSucceeded  = "annotated"
+	annotationFailedMsg  = "failed"
+	wildcardCharacters   = "*?["
+	maxAnnotationPreview = 50
+)
+
+// ReleaseBundleQuery selects release bundle versions by the same filters offered by release-bundle-search.
+// Empty fields are ignored.
+type ReleaseBundleQuery struct {
+	NamePattern string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Environment string
+	Sha256      string
+}
+
+// ParseQueryDate accepts either a date (YYYY-MM-DD) or an RFC3339 timestamp.
+// When endOfDay is true, a date-only value covers the whole day.
+func ParseQueryDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.Parse(queryDateLayout, value)
+	if err != nil {
+		return time.Time{}, errorutils.CheckErrorf("invalid date '%s'. Expected the YYYY-MM-DD or RFC3339 format", value)
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return parsed, nil
+}
+
+func (q *ReleaseBundleQuery) matchesName(name string) (bool, error) {
+	if q.NamePattern == "" {
+		return true, nil
+	}
+	matched, err := filepath.Match(q.NamePattern, name)
+	return matched, errorutils.CheckError(err)
+}
+
+func (q *ReleaseBundleQuery) matchesCreated(created time.Time) bool {
+	if !q.CreatedFrom.IsZero() && created.Before(q.CreatedFrom) {
+		return false
+	}
+	if !q.CreatedTo.IsZero() && created.After(q.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// namePrefix returns the literal prefix of the name pattern, which is used to narrow down the server side search.
+func (q *ReleaseBundleQuery) namePrefix() string {
+	if i := strings.IndexAny(q.NamePattern, wildcardCharacters); i >= 0 {
+		return q.NamePattern[:i]
+	}
+	return q.NamePattern
+}
+
+func promotedTo(promotions []services.RbPromotion, environment string) bool {
+	for _, promotion := range promotions {
+		if promotion.Environment == environment && strings.EqualFold(string(promotion.Status), promotionCompleted) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsChecksum(spec services.ReleaseBundleSpecResponse, sha256 string) bool {
+	for _, artifact := range spec.Artifacts {
+		if strings.EqualFold(artifact.Checksum, sha256) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMatchingVersions runs the query against the lifecycle search API.
+func (q *ReleaseBundleQuery) findMatchingVersions(manager *lifecycle.LifecycleServicesManager, project string) ([]services.ReleaseBundleVersion, error) {
+	names, err := q.findMatchingNames(manager, project)
+	if err != nil {
+		return nil, err
+	}
+	var matches []services.ReleaseBundleVersion
+	for _, name := range names {
+		versions, err := searchAllVersions(manager, name, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range versions {
+			isMatch, err := q.matchesVersion(manager, version, project)
+			if err != nil {
+				return nil, err
+			}
+			if isMatch {
+				matches = append(matches, version)
+			}
+		}
+	}
+	return matches, nil
+}
+
+func (q *ReleaseBundleQuery) findMatchingNames(manager *lifecycle.LifecycleServicesManager, project string) ([]string, error) {
+	var names []string
+	for offset := 0; ; offset += searchPageSize {
+		response, err := manager.ReleaseBundlesSearchGroup(services.GetSearchOptionalQueryParams{
+			Offset: offset, Limit: searchPageSize, FilterBy: q.namePrefix(), Project: project,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range response.ReleaseBundleSearchGroup {
+			isMatch, err := q.matchesName(group.ReleaseBundleName)
+			if err != nil {
+				return nil, err
+			}
+			if isMatch {
+				names = append(names, group.ReleaseBundleName)
+			}
+		}
+		if len(response.ReleaseBundleSearchGroup) < searchPageSize || offset+searchPageSize >= response.Total {
+			return names, nil
+		}
+	}
+}
+
+func searchAllVersions(manager *lifecycle.LifecycleServicesManager, name, project string) ([]services.ReleaseBundleVersion, error) {
+	var versions []services.ReleaseBundleVersion
+	for offset := 0; ; offset += searchPageSize {
+		response, err := manager.ReleaseBundlesSearchVersions(name, services.GetSearchOptionalQueryParams{
+			Offset: offset, Limit: searchPageSize, Project: project,
+		})
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, response.ReleaseBundles...)
+		if len(response.ReleaseBundles) < searchPageSize || offset+searchPageSize >= response.Total {
+			return versions, nil
+		}
+	}
+}
+
+// matchesVersion applies the filters that are not supported by the search API.
+// The cheap created-date filter runs first, to avoid fetching promotions and contents of filtered-out versions.
+func (q *ReleaseBundleQuery) matchesVersion(manager *lifecycle.LifecycleServicesManager, version services.ReleaseBundleVersion, project string) (bool, error) {
+	if !q.matchesCreated(version.Created) {
+		return false, nil
+	}
+	rbDetails := services.ReleaseBundleDetails{
+		ReleaseBundleName:    version.ReleaseBundleName,
+		ReleaseBundleVersion: version.ReleaseBundleVersion,
+	}
+	if q.Environment != "" {
+		promotions, err := manager.GetReleaseBundleVersionPromotions(rbDetails, services.GetPromotionsOptionalQueryParams{ProjectKey: project})
+		if err != nil {
+			return false, err
+		}
+		if !promotedTo(promotions.Promotions, q.Environment) {
+			return false, nil
+		}
+	}
+	if q.Sha256 != "" {
+		spec, err := manager.GetReleaseBundleSpecification(rbDetails)
+		if err != nil {
+			return false, err
+		}
+		if !containsChecksum(spec, q.Sha256) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type annotationResult struct {
+	Name    string `col-name:"Name"`
+	Version string `col-name:"Version"`
+	Created string `col-name:"Created"`
+	Status  string `col-name:"Status" omitempty:"true"`
+	Error   string `col-name:"Error" omitempty:"true"`
+}
+
+func (rba *ReleaseBundleAnnotateCommand) runQuery(manager *lifecycle.LifecycleServicesManager, queryParams services.CommonOptionalQueryParams) error {
+	matches, err := rba.query.findMatchingVersions(manager, rba.rbProjectKey)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		log.Info("No release bundle versions match the query.")
+		return nil
+	}
+
+	results := make([]annotationResult, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, annotationResult{
+			Name:    match.ReleaseBundleName,
+			Version: match.ReleaseBundleVersion,
+			Created: match.Created.Format(time.RFC3339),
+		})
+	}
+	if err = printAnnotationPreview(results); err != nil {
+		return err
+	}
+	if !rba.quiet && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to annotate %d release bundle versions?", len(results)), false) {
+		return nil
+	}
+
+	var failed int
+	for i := range results {
+		rbDetails := services.ReleaseBundleDetails{
+			ReleaseBundleName:    results[i].Name,
+			ReleaseBundleVersion: results[i].Version,
+		}
+		if err = rba.annotateReleaseBundleFunc(rba, manager, rbDetails, queryParams); err != nil {
+			failed++
+			results[i].Status = annotationFailedMsg
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Status = annotationSucceeded
+	}
+	if err = coreutils.PrintTable(results, "Release Bundles Annotation", "", false); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed annotating %d out of %d release bundle versions", failed, len(results))
+	}
+	return nil
+}
+
+func printAnnotationPreview(results []annotationResult) error {
+	preview := results
+	footer := ""
+	if len(preview) > maxAnnotationPreview {
+		preview = preview[:maxAnnotationPreview]
+		footer = fmt.Sprintf("...and %d more release bundle versions.", len(results)-maxAnnotationPreview)
+	}
+	return coreutils.PrintTableWithBorderless(preview, "Release bundle versions matching the query", footer, "", false)
+}
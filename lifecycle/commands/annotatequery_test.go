@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryDate(t *testing.T) {
+	from, err := ParseQueryDate("2024-03-01", false)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), from)
+
+	to, err := ParseQueryDate("2024-03-01", true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 23, 59, 59, int(time.Second-time.Nanosecond), time.UTC), to)
+
+	exact, err := ParseQueryDate("2024-03-01T10:00:00Z", true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), exact)
+
+	empty, err := ParseQueryDate("", false)
+	assert.NoError(t, err)
+	assert.True(t, empty.IsZero())
+
+	_, err = ParseQueryDate("01/03/2024", false)
+	assert.Error(t, err)
+}
+
+func TestReleaseBundleQueryMatchesCreated(t *testing.T) {
+	query := ReleaseBundleQuery{
+		CreatedFrom: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		CreatedTo:   time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	assert.True(t, query.matchesCreated(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, query.matchesCreated(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, query.matchesCreated(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, (&ReleaseBundleQuery{}).matchesCreated(time.Now()))
+}
+
+func TestReleaseBundleQueryNamePrefix(t *testing.T) {
+	assert.Equal(t, "payments-", (&ReleaseBundleQuery{NamePattern: "payments-*"}).namePrefix())
+	assert.Equal(t, "billing", (&ReleaseBundleQuery{NamePattern: "billing"}).namePrefix())
+	assert.Equal(t, "", (&ReleaseBundleQuery{NamePattern: "*"}).namePrefix())
+}
+
+func TestPromotedTo(t *testing.T) {
+	promotions := []services.RbPromotion{
+		{Environment: "QA", Status: "COMPLETED"},
+		{Environment: "PROD", Status: "FAILED"},
+	}
+	assert.True(t, promotedTo(promotions, "QA"))
+	assert.False(t, promotedTo(promotions, "PROD"))
+	assert.False(t, promotedTo(promotions, "DEV"))
+}
+
+func TestContainsChecksum(t *testing.T) {
+	var spec services.ReleaseBundleSpecResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"artifacts":[{"path":"generic-local/a.zip","checksum":"ABC123"}]}`), &spec))
+	assert.True(t, containsChecksum(spec, "abc123"))
+	assert.False(t, containsChecksum(spec, "def456"))
+}
+
+// Start a fake lifecycle service, serving the release bundles search API.
+func startTestSearchServer(t *testing.T, versions map[string][]services.ReleaseBundleVersion) *httptest.Server {
+	groupApi := "/lifecycle/" + services.GetReleaseBundleSearchGroupApi()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		if r.URL.Path == groupApi {
+			groups := services.ReleaseBundlesGroupResponse{}
+			for name := range versions {
+				if strings.HasPrefix(name, r.URL.Query().Get("filter_by")) {
+					groups.ReleaseBundleSearchGroup = append(groups.ReleaseBundleSearchGroup, services.ReleaseBundleSearchGroup{ReleaseBundleName: name})
+				}
+			}
+			groups.Total = len(groups.ReleaseBundleSearchGroup)
+			response = groups
+		} else {
+			for name, nameVersions := range versions {
+				if r.URL.Path == "/lifecycle/"+services.GetReleaseBundleSearchVersionsApi(name) {
+					response = services.ReleaseBundleVersionsResponse{ReleaseBundles: nameVersions, Total: len(nameVersions)}
+				}
+			}
+		}
+		if response == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunQuery(t *testing.T) {
+	created := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	server := startTestSearchServer(t, map[string][]services.ReleaseBundleVersion{
+		"payments-api": {
+			{ReleaseBundleName: "payments-api", ReleaseBundleVersion: "1.0.0", Created: created},
+			{ReleaseBundleName: "payments-api", ReleaseBundleVersion: "0.9.0", Created: created.AddDate(0, -2, 0)},
+		},
+		"payments-ui": {{ReleaseBundleName: "payments-ui", ReleaseBundleVersion: "2.0.0", Created: created}},
+		"billing":     {{ReleaseBundleName: "billing", ReleaseBundleVersion: "1.0.0", Created: created}},
+	})
+	manager, err := utils.CreateLifecycleServiceManager(&config.ServerDetails{LifecycleUrl: server.URL + "/lifecycle/"}, false)
+	require.NoError(t, err)
+
+	var annotated []string
+	cmd := NewReleaseBundleAnnotateCommand().SetQuiet(true).SetQuery(&ReleaseBundleQuery{
+		NamePattern: "payments-*",
+		CreatedFrom: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	cmd.annotateReleaseBundleFunc = func(rba *ReleaseBundleAnnotateCommand, manager *lifecycle.LifecycleServicesManager,
+		details services.ReleaseBundleDetails, params services.CommonOptionalQueryParams) error {
+		annotated = append(annotated, details.ReleaseBundleName+"/"+details.ReleaseBundleVersion)
+		assert.Equal(t, "example-project", params.ProjectKey)
+		if details.ReleaseBundleName == "payments-ui" {
+			return errors.New("forbidden")
+		}
+		return nil
+	}
+
+	err = cmd.runQuery(manager, services.CommonOptionalQueryParams{ProjectKey: "example-project"})
+	assert.ErrorContains(t, err, "failed annotating 1 out of 2 release bundle versions")
+	assert.ElementsMatch(t, []string{"payments-api/1.0.0", "payments-ui/2.0.0"}, annotated)
+
+	// No matches isn't an error, and nothing is annotated.
+	annotated = nil
+	cmd.SetQuery(&ReleaseBundleQuery{NamePattern: "orders-*"})
+	assert.NoError(t, cmd.runQuery(manager, services.CommonOptionalQueryParams{}))
+	assert.Empty(t, annotated)
+}
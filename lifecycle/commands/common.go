@@ -20,9 +20,10 @@ import (
 const (
 	rbV2manifestName                                      = "release-bundle.json.evd"
 	releaseBundlesV2                                      = "release-bundles-v2"
-	minimalLifecycleArtifactoryVersion                    = "7.63.2"
-	minArtifactoryVersionForMultiSourceAndPackagesSupport = "7.114.0"
-	minArtifactoryVersionForDraftBundleSupport            = "7.136.0"
+	MinimalLifecycleArtifactoryVersion                    = "7.63.2"
+	MinArtifactoryVersionForMultiSourceAndPackagesSupport = "7.114.0"
+	MinArtifactoryVersionForDraftBundleSupport            = "7.136.0"
+	jsonOutputFormat                                      = "json"
 )
 
 type releaseBundleCmd struct {
@@ -78,7 +79,7 @@ func validateArtifactoryVersion(serverDetails *config.ServerDetails, minVersion
 }
 
 func validateArtifactoryVersionSupported(serverDetails *config.ServerDetails) error {
-	return validateArtifactoryVersion(serverDetails, minimalLifecycleArtifactoryVersion)
+	return validateArtifactoryVersion(serverDetails, MinimalLifecycleArtifactoryVersion)
 }
 
 func ValidateFeatureSupportedVersion(serverDetails *config.ServerDetails, minCommandVersion string) error {
@@ -41,6 +41,11 @@ type ReleaseBundleCreateCommand struct {
 
 	// Multi-bundles and multi-builds sources from command-line
 	ReleaseBundleSources
+
+	// Container image and Helm chart references from command-line
+	images             []string
+	charts             []string
+	includeChartImages bool
 }
 
 func NewReleaseBundleCreateCommand() *ReleaseBundleCreateCommand {
@@ -109,6 +114,21 @@ func (rbc *ReleaseBundleCreateCommand) SetReleaseBundlesSources(sourcesReleaseBu
 	return rbc
 }
 
+func (rbc *ReleaseBundleCreateCommand) SetImages(images []string) *ReleaseBundleCreateCommand {
+	rbc.images = images
+	return rbc
+}
+
+func (rbc *ReleaseBundleCreateCommand) SetCharts(charts []string) *ReleaseBundleCreateCommand {
+	rbc.charts = charts
+	return rbc
+}
+
+func (rbc *ReleaseBundleCreateCommand) SetIncludeChartImages(includeChartImages bool) *ReleaseBundleCreateCommand {
+	rbc.includeChartImages = includeChartImages
+	return rbc
+}
+
 func (rbc *ReleaseBundleCreateCommand) CommandName() string {
 	return "rb_create"
 }
@@ -138,8 +158,8 @@ func (rbc *ReleaseBundleCreateCommand) Run() error {
 
 	// Validate Artifactory version supports draft bundle creation
 	if rbc.draft {
-		if err := ValidateFeatureSupportedVersion(rbc.serverDetails, minArtifactoryVersionForDraftBundleSupport); err != nil {
-			return errorutils.CheckErrorf("draft bundle creation requires Artifactory version %s or higher", minArtifactoryVersionForDraftBundleSupport)
+		if err := ValidateFeatureSupportedVersion(rbc.serverDetails, MinArtifactoryVersionForDraftBundleSupport); err != nil {
+			return errorutils.CheckErrorf("draft bundle creation requires Artifactory version %s or higher", MinArtifactoryVersionForDraftBundleSupport)
 		}
 	}
 
@@ -148,8 +168,12 @@ func (rbc *ReleaseBundleCreateCommand) Run() error {
 		return err
 	}
 
+	if len(rbc.images) > 0 || len(rbc.charts) > 0 {
+		return rbc.createFromContainers(servicesManager, rbDetails, queryParams)
+	}
+
 	var isReleaseBundleCreationWithMultiSourcesSupported bool
-	if err = ValidateFeatureSupportedVersion(rbc.serverDetails, minArtifactoryVersionForMultiSourceAndPackagesSupport); err != nil {
+	if err = ValidateFeatureSupportedVersion(rbc.serverDetails, MinArtifactoryVersionForMultiSourceAndPackagesSupport); err != nil {
 		isReleaseBundleCreationWithMultiSourcesSupported = false
 	} else {
 		isReleaseBundleCreationWithMultiSourcesSupported = true
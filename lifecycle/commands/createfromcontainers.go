@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/helm"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/ocicontainer"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	helmChartContentMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	ociManifestFileName       = "manifest.json"
+	ociBlobPrefix             = "sha256__"
+)
+
+// chartReference identifies a Helm chart stored in Artifactory, in the <repository>/<chart name>:<chart version> format.
+type chartReference struct {
+	repo    string
+	name    string
+	version string
+}
+
+func parseChartReference(reference string) (chartReference, error) {
+	nameWithRepo, version, found := cutLast(reference, ":")
+	repo, name, hasRepo := strings.Cut(nameWithRepo, "/")
+	if !found || !hasRepo || repo == "" || name == "" || version == "" {
+		return chartReference{}, errorutils.CheckErrorf("invalid chart reference '%s'. Expected the <repository>/<chart name>:<chart version> format", reference)
+	}
+	return chartReference{repo: repo, name: name, version: version}, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func (ref chartReference) String() string {
+	return fmt.Sprintf("%s/%s:%s", ref.repo, ref.name, ref.version)
+}
+
+// archiveName returns the file name of a chart packaged in a classic Helm repository.
+func (ref chartReference) archiveName() string {
+	return fmt.Sprintf("%s-%s.tgz", path.Base(ref.name), ref.version)
+}
+
+type ociManifest struct {
+	Layers []struct {
+		Digest    string `json:"digest,omitempty"`
+		MediaType string `json:"mediaType,omitempty"`
+	} `json:"layers,omitempty"`
+}
+
+func (rbc *ReleaseBundleCreateCommand) createFromContainers(servicesManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, queryParams services.CommonOptionalQueryParams) error {
+	rtServicesManager, err := utils.CreateServiceManager(rbc.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	artifactsSource, err := rbc.createArtifactSourceFromContainers(rtServicesManager)
+	if err != nil {
+		return err
+	}
+	return servicesManager.CreateReleaseBundleFromArtifactsDraft(rbDetails, queryParams, rbc.signingKeyName, artifactsSource, rbc.draft)
+}
+
+// createArtifactSourceFromContainers resolves the image and chart references to the files stored in Artifactory.
+func (rbc *ReleaseBundleCreateCommand) createArtifactSourceFromContainers(rtServicesManager artifactory.ArtifactoryServicesManager) (artifactsSource services.CreateFromArtifacts, err error) {
+	var files []servicesUtils.ResultItem
+	for _, image := range rbc.images {
+		imageFiles, err := resolveImage(image, rtServicesManager)
+		if err != nil {
+			return artifactsSource, err
+		}
+		files = append(files, imageFiles...)
+	}
+	for _, chart := range rbc.charts {
+		chartFiles, err := rbc.resolveChart(chart, rtServicesManager)
+		if err != nil {
+			return artifactsSource, err
+		}
+		files = append(files, chartFiles...)
+	}
+	artifactsSource.Artifacts = resultItemsToArtifactSources(files)
+	if len(artifactsSource.Artifacts) == 0 {
+		return artifactsSource, errorutils.CheckErrorf("no artifacts were found for the provided images and charts")
+	}
+	return artifactsSource, nil
+}
+
+func resolveImage(image string, rtServicesManager artifactory.ArtifactoryServicesManager) ([]servicesUtils.ResultItem, error) {
+	log.Info(fmt.Sprintf("Resolving image %s...", image))
+	files, err := ocicontainer.FetchImageFiles(image, rtServicesManager)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed resolving image '%s': %s", image, err.Error())
+	}
+	log.Debug(fmt.Sprintf("Found %d files for image %s", len(files), image))
+	return files, nil
+}
+
+func (rbc *ReleaseBundleCreateCommand) resolveChart(reference string, rtServicesManager artifactory.ArtifactoryServicesManager) ([]servicesUtils.ResultItem, error) {
+	chart, err := parseChartReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Resolving chart %s...", chart))
+	files, chartArchive, err := searchChartFiles(chart, rtServicesManager)
+	if err != nil {
+		return nil, err
+	}
+	if !rbc.includeChartImages {
+		return files, nil
+	}
+	images, err := getChartImages(chart, chartArchive, rtServicesManager)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		imageFiles, err := resolveImage(image, rtServicesManager)
+		if err != nil {
+			// Charts commonly reference public images which are not stored in Artifactory.
+			log.Warn(fmt.Sprintf("Skipping image %s referenced by chart %s: %s", image, chart, err.Error()))
+			continue
+		}
+		files = append(files, imageFiles...)
+	}
+	return files, nil
+}
+
+// searchChartFiles returns the files of the chart, looking for an OCI chart first and then for a classic chart archive.
+// The returned archive item holds the packaged chart content.
+func searchChartFiles(chart chartReference, rtServicesManager artifactory.ArtifactoryServicesManager) ([]servicesUtils.ResultItem, *servicesUtils.ResultItem, error) {
+	ociFiles, err := artUtils.ExecuteAqlQuery(rtServicesManager, fmt.Sprintf(
+		`items.find({"repo": "%s", "path": "%s/%s", "type": "file"}).include("name", "repo", "path", "sha256")`,
+		chart.repo, chart.name, chart.version))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range ociFiles {
+		if ociFiles[i].Name == ociManifestFileName {
+			return ociFiles, &ociFiles[i], nil
+		}
+	}
+
+	classicFiles, err := artUtils.ExecuteAqlQuery(rtServicesManager, fmt.Sprintf(
+		`items.find({"repo": "%s", "name": "%s", "type": "file"}).include("name", "repo", "path", "sha256")`,
+		chart.repo, chart.archiveName()))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(classicFiles) == 0 {
+		return nil, nil, errorutils.CheckErrorf("could not find chart '%s' in Artifactory", chart)
+	}
+	if len(classicFiles) > 1 {
+		log.Warn(fmt.Sprintf("Found %d archives of chart %s. Using %s", len(classicFiles), chart, classicFiles[0].GetItemRelativePath()))
+	}
+	return classicFiles[:1], &classicFiles[0], nil
+}
+
+// getChartImages downloads the chart and returns the images referenced by its rendered templates.
+func getChartImages(chart chartReference, chartItem *servicesUtils.ResultItem, rtServicesManager artifactory.ArtifactoryServicesManager) (images []string, err error) {
+	archivePath := chartItem.GetItemRelativePath()
+	if chartItem.Name == ociManifestFileName {
+		if archivePath, err = getOciChartContentPath(chartItem, rtServicesManager); err != nil {
+			return nil, err
+		}
+	}
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	localArchive := filepath.Join(tempDirPath, chart.archiveName())
+	if err = artUtils.DownloadRemoteFile(rtServicesManager, archivePath, localArchive); err != nil {
+		return nil, err
+	}
+	return helm.RenderChartImages(localArchive, nil)
+}
+
+// getOciChartContentPath returns the path of the chart content layer listed in the given OCI manifest.
+func getOciChartContentPath(manifestItem *servicesUtils.ResultItem, rtServicesManager artifactory.ArtifactoryServicesManager) (string, error) {
+	var manifest ociManifest
+	if err := utils.RemoteUnmarshal(rtServicesManager, manifestItem.GetItemRelativePath(), &manifest); err != nil {
+		return "", err
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == helmChartContentMediaType {
+			return path.Join(manifestItem.Repo, manifestItem.Path, ociBlobPrefix+strings.TrimPrefix(layer.Digest, "sha256:")), nil
+		}
+	}
+	return "", errorutils.CheckErrorf("could not find the chart content layer in '%s'", manifestItem.GetItemRelativePath())
+}
+
+// resultItemsToArtifactSources converts the resolved files to release bundle artifacts, skipping files which are shared between images.
+func resultItemsToArtifactSources(files []servicesUtils.ResultItem) []services.ArtifactSource {
+	encountered := make(map[string]bool)
+	artifacts := make([]services.ArtifactSource, 0, len(files))
+	for _, file := range files {
+		artifactPath := file.GetItemRelativePath()
+		if encountered[artifactPath] {
+			continue
+		}
+		encountered[artifactPath] = true
+		artifacts = append(artifacts, services.ArtifactSource{Path: artifactPath, Sha256: file.Sha256})
+	}
+	return artifacts
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChartReference(t *testing.T) {
+	tests := []struct {
+		reference   string
+		expected    chartReference
+		archiveName string
+		expectError bool
+	}{
+		{"helm-local/nginx:1.2.3", chartReference{repo: "helm-local", name: "nginx", version: "1.2.3"}, "nginx-1.2.3.tgz", false},
+		{"helm-oci/team/backend:0.1.0", chartReference{repo: "helm-oci", name: "team/backend", version: "0.1.0"}, "backend-0.1.0.tgz", false},
+		{"nginx:1.2.3", chartReference{}, "", true},
+		{"helm-local/nginx", chartReference{}, "", true},
+		{"helm-local/nginx:", chartReference{}, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			chart, err := parseChartReference(test.reference)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, chart)
+			assert.Equal(t, test.archiveName, chart.archiveName())
+		})
+	}
+}
+
+func TestResultItemsToArtifactSources(t *testing.T) {
+	files := []servicesUtils.ResultItem{
+		{Repo: "docker-local", Path: "app/1.0", Name: "list.manifest.json", Sha256: "aaa"},
+		{Repo: "docker-local", Path: "app/sha256:111", Name: "manifest.json", Sha256: "bbb"},
+		{Repo: "docker-local", Path: "app/sha256:111", Name: "sha256__ccc", Sha256: "ccc"},
+		{Repo: "docker-local", Path: "app/sha256:111", Name: "sha256__ccc", Sha256: "ccc"},
+		{Repo: "helm-local", Path: ".", Name: "app-1.0.tgz", Sha256: "ddd"},
+	}
+	assert.Equal(t, []services.ArtifactSource{
+		{Path: "docker-local/app/1.0/list.manifest.json", Sha256: "aaa"},
+		{Path: "docker-local/app/sha256:111/manifest.json", Sha256: "bbb"},
+		{Path: "docker-local/app/sha256:111/sha256__ccc", Sha256: "ccc"},
+		{Path: "helm-local/app-1.0.tgz", Sha256: "ddd"},
+	}, resultItemsToArtifactSources(files))
+}
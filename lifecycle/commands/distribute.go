@@ -14,6 +14,9 @@ type ReleaseBundleDistributeCommand struct {
 	pathMappingPattern string
 	pathMappingTarget  string
 	maxWaitMinutes     int
+	format             string
+	// Maps site names to the IDs of the servers used to verify their target paths in a dry run.
+	siteServers map[string]string
 }
 
 func NewReleaseBundleDistributeCommand() *ReleaseBundleDistributeCommand {
@@ -75,6 +78,16 @@ func (rbd *ReleaseBundleDistributeCommand) SetMaxWaitMinutes(maxWaitMinutes int)
 	return rbd
 }
 
+func (rbd *ReleaseBundleDistributeCommand) SetFormat(format string) *ReleaseBundleDistributeCommand {
+	rbd.format = format
+	return rbd
+}
+
+func (rbd *ReleaseBundleDistributeCommand) SetSiteServers(siteServers map[string]string) *ReleaseBundleDistributeCommand {
+	rbd.siteServers = siteServers
+	return rbd
+}
+
 func (rbd *ReleaseBundleDistributeCommand) Run() error {
 	if err := validateArtifactoryVersionSupported(rbd.serverDetails); err != nil {
 		return err
@@ -99,6 +112,9 @@ func (rbd *ReleaseBundleDistributeCommand) Run() error {
 		ProjectKey:        rbd.rbProjectKey,
 	}
 
+	if rbd.dryRun {
+		return rbd.runDryRun(rbDetails, distributeParams)
+	}
 	return servicesManager.DistributeReleaseBundle(rbDetails, distributeParams)
 }
 
@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/stringutils"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientArtUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Number of target paths checked by a single AQL query against a site.
+	existingFilesQueryChunkSize = 50
+
+	plannedStatusNew        = "new"
+	plannedStatusIdentical  = "identical"
+	plannedStatusConflict   = "conflict"
+	plannedStatusUnverified = "unverified"
+)
+
+// DistributionPlan describes the outcome of a distribution, as resolved by a dry run.
+type DistributionPlan struct {
+	ReleaseBundleName    string                 `json:"release_bundle_name"`
+	ReleaseBundleVersion string                 `json:"release_bundle_version"`
+	Sites                []SiteDistributionPlan `json:"sites"`
+}
+
+// SiteDistributionPlan lists the artifacts which would be distributed to a single site.
+// The target state is verified only for sites which are configured as servers in the JFrog CLI, either mapped explicitly to a server ID,
+// or with a server ID matching the site name.
+type SiteDistributionPlan struct {
+	Name                 string            `json:"name"`
+	Verified             bool              `json:"verified"`
+	RepositoriesToCreate []string          `json:"repositories_to_create,omitempty"`
+	MissingRepositories  []string          `json:"missing_repositories,omitempty"`
+	Artifacts            []PlannedArtifact `json:"artifacts"`
+	conflicts            int
+}
+
+type PlannedArtifact struct {
+	SourcePath     string `json:"source_path"`
+	TargetPath     string `json:"target_path"`
+	Sha256         string `json:"sha256"`
+	Status         string `json:"status"`
+	ExistingSha256 string `json:"existing_sha256,omitempty"`
+}
+
+type plannedArtifactRow struct {
+	SourcePath     string `col-name:"Source Path"`
+	TargetPath     string `col-name:"Target Path"`
+	Status         string `col-name:"Status"`
+	ExistingSha256 string `col-name:"Existing SHA-256" omitempty:"true"`
+}
+
+// runDryRun validates the distribution request against the server and prints the resolved distribution plan.
+func (rbd *ReleaseBundleDistributeCommand) runDryRun(rbDetails services.ReleaseBundleDetails, distributeParams services.DistributeReleaseBundleParams) error {
+	servicesManager, err := utils.CreateLifecycleServiceManager(rbd.serverDetails, true)
+	if err != nil {
+		return err
+	}
+	sites, err := rbd.getDistributionTargets(servicesManager, rbDetails, distributeParams)
+	if err != nil {
+		return err
+	}
+	spec, err := servicesManager.GetReleaseBundleSpecification(rbDetails)
+	if err != nil {
+		return err
+	}
+	artifacts, err := rbd.mapArtifacts(spec)
+	if err != nil {
+		return err
+	}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+
+	plan := DistributionPlan{ReleaseBundleName: rbDetails.ReleaseBundleName, ReleaseBundleVersion: rbDetails.ReleaseBundleVersion}
+	var unverified []string
+	for _, site := range sites {
+		sitePlan, err := rbd.planSite(site, artifacts, servers)
+		if err != nil {
+			return err
+		}
+		if !sitePlan.Verified {
+			unverified = append(unverified, site)
+		}
+		plan.Sites = append(plan.Sites, sitePlan)
+	}
+	if len(unverified) > 0 {
+		log.Warn(fmt.Sprintf("The target paths on %s were not verified, since no server is configured for them. Map the sites to server IDs using the --%s option.",
+			strings.Join(unverified, ", "), flagkit.SiteServers))
+	}
+	if err = printDistributionPlan(plan, rbd.format); err != nil {
+		return err
+	}
+	return distributionPlanError(plan)
+}
+
+// distributionTargetsResponse holds the sites resolved by the server for a dry-run distribution.
+type distributionTargetsResponse struct {
+	Sites []distribution.TargetArtifactory `json:"sites"`
+}
+
+// getDistributionTargets sends the distribution request as a dry run and returns the names of the sites the server resolved from the distribution rules.
+// The request is sent directly, since the services manager discards the dry-run response.
+func (rbd *ReleaseBundleDistributeCommand) getDistributionTargets(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails, distributeParams services.DistributeReleaseBundleParams) ([]string, error) {
+	distributeService := services.NewDistributeReleaseBundleService(servicesManager.Client())
+	distributeService.DryRun = true
+	distributeService.AutoCreateRepo = distributeParams.AutoCreateRepo
+	distributeService.DistributeParams = distribution.DistributionParams{DistributionRules: distributeParams.DistributionRules}
+	distributeService.PathMappings = []clientArtUtils.PathMapping{}
+	for _, pathMapping := range distributeParams.PathMappings {
+		distributeService.PathMappings = append(distributeService.PathMappings,
+			distribution.CreatePathMappingsFromPatternAndTarget(pathMapping.Pattern, pathMapping.Target)...)
+	}
+	content, err := json.Marshal(distributeService.GetDistributeBody())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	log.Info("[Dry run] Distributing: " + rbDetails.ReleaseBundleName + "/" + rbDetails.ReleaseBundleVersion)
+	serviceDetails, err := rbd.serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	requestFullUrl, err := clientUtils.BuildUrl(serviceDetails.GetUrl(),
+		distributeService.GetRestApi(rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion), distribution.GetProjectQueryParam(distributeParams.ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	httpClientDetails.SetContentTypeApplicationJson()
+	resp, body, err := servicesManager.Client().SendPost(requestFullUrl, content, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+	log.Debug(clientUtils.IndentJson(body))
+	return parseDistributionTargets(body)
+}
+
+func parseDistributionTargets(body []byte) ([]string, error) {
+	var response distributionTargetsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	seen := make(map[string]bool)
+	var sites []string
+	for _, site := range response.Sites {
+		if site.Name != "" && !seen[site.Name] {
+			seen[site.Name] = true
+			sites = append(sites, site.Name)
+		}
+	}
+	return sites, nil
+}
+
+// mapArtifacts returns the artifacts of the release bundle with their target paths after applying the path mapping.
+func (rbd *ReleaseBundleDistributeCommand) mapArtifacts(spec services.ReleaseBundleSpecResponse) ([]PlannedArtifact, error) {
+	artifacts := make([]PlannedArtifact, 0, len(spec.Artifacts))
+	for _, artifact := range spec.Artifacts {
+		sourcePath := path.Join(artifact.SourceRepositoryKey, artifact.Path)
+		targetPath, err := mapTargetPath(sourcePath, rbd.pathMappingPattern, rbd.pathMappingTarget)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, PlannedArtifact{SourcePath: sourcePath, TargetPath: targetPath, Sha256: artifact.Checksum})
+	}
+	return artifacts, nil
+}
+
+// mapTargetPath applies the path mapping to the source path. Paths which do not match the pattern are distributed as is.
+func mapTargetPath(sourcePath, pattern, target string) (string, error) {
+	if pattern == "" || target == "" {
+		return sourcePath, nil
+	}
+	regExp, err := clientUtils.GetRegExp(stringutils.WildcardPatternToRegExp(pattern))
+	if err != nil {
+		return "", err
+	}
+	if !regExp.MatchString(sourcePath) {
+		return sourcePath, nil
+	}
+	mapped, _, err := clientUtils.BuildTargetPath(pattern, sourcePath, target, false)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(mapped, "/") {
+		mapped += path.Base(sourcePath)
+	}
+	return mapped, nil
+}
+
+// planSite resolves the state of the target paths on the site, when a server is configured for the site.
+func (rbd *ReleaseBundleDistributeCommand) planSite(site string, artifacts []PlannedArtifact, servers []*config.ServerDetails) (SiteDistributionPlan, error) {
+	sitePlan := SiteDistributionPlan{Name: site}
+	siteServicesManager, err := rbd.getSiteServicesManager(site, servers)
+	if err != nil {
+		return sitePlan, err
+	}
+	if siteServicesManager == nil {
+		log.Debug(fmt.Sprintf("No server is configured for the '%s' site. Skipping the verification of the site's target paths.", site))
+		for _, artifact := range artifacts {
+			artifact.Status = plannedStatusUnverified
+			sitePlan.Artifacts = append(sitePlan.Artifacts, artifact)
+		}
+		return sitePlan, nil
+	}
+
+	sitePlan.Verified = true
+	artifactsByRepo := groupArtifactsByTargetRepo(artifacts)
+	for _, repo := range sortedKeys(artifactsByRepo) {
+		repoArtifacts := artifactsByRepo[repo]
+		exists, err := siteServicesManager.IsRepoExists(repo)
+		if err != nil {
+			return sitePlan, err
+		}
+		existingChecksums := map[string]string{}
+		if !exists {
+			if rbd.autoCreateRepo {
+				sitePlan.RepositoriesToCreate = append(sitePlan.RepositoriesToCreate, repo)
+			} else {
+				sitePlan.MissingRepositories = append(sitePlan.MissingRepositories, repo)
+			}
+		} else if existingChecksums, err = getExistingChecksums(siteServicesManager, repo, repoArtifacts); err != nil {
+			return sitePlan, err
+		}
+		for _, artifact := range repoArtifacts {
+			artifact.Status = plannedStatusNew
+			if existing, found := existingChecksums[artifact.TargetPath]; found {
+				if strings.EqualFold(existing, artifact.Sha256) {
+					artifact.Status = plannedStatusIdentical
+				} else {
+					artifact.Status = plannedStatusConflict
+					artifact.ExistingSha256 = existing
+					sitePlan.conflicts++
+				}
+			}
+			sitePlan.Artifacts = append(sitePlan.Artifacts, artifact)
+		}
+	}
+	return sitePlan, nil
+}
+
+// getSiteServicesManager returns a services manager for the server mapped to the site, or for the server configured with the site name as its ID.
+// Returns nil if there is no such server.
+func (rbd *ReleaseBundleDistributeCommand) getSiteServicesManager(site string, servers []*config.ServerDetails) (artifactory.ArtifactoryServicesManager, error) {
+	serverId, mapped := rbd.siteServers[site]
+	if !mapped {
+		serverId = site
+	}
+	for _, server := range servers {
+		if server.ServerId == serverId {
+			return utils.CreateServiceManager(server, 3, 0, false)
+		}
+	}
+	if mapped {
+		return nil, errorutils.CheckErrorf("the '%s' server ID, mapped to the '%s' site, is not configured", serverId, site)
+	}
+	return nil, nil
+}
+
+func groupArtifactsByTargetRepo(artifacts []PlannedArtifact) map[string][]PlannedArtifact {
+	grouped := make(map[string][]PlannedArtifact)
+	for _, artifact := range artifacts {
+		repo, _, _ := strings.Cut(artifact.TargetPath, "/")
+		grouped[repo] = append(grouped[repo], artifact)
+	}
+	return grouped
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getExistingChecksums returns the SHA-256 checksums of the files that already exist at the target paths, keyed by target path.
+func getExistingChecksums(siteServicesManager artifactory.ArtifactoryServicesManager, repo string, artifacts []PlannedArtifact) (map[string]string, error) {
+	checksums := make(map[string]string)
+	for start := 0; start < len(artifacts); start += existingFilesQueryChunkSize {
+		end := min(start+existingFilesQueryChunkSize, len(artifacts))
+		results, err := artUtils.ExecuteAqlQuery(siteServicesManager, buildExistingFilesQuery(repo, artifacts[start:end]))
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			checksums[result.GetItemRelativePath()] = result.Sha256
+		}
+	}
+	return checksums, nil
+}
+
+func buildExistingFilesQuery(repo string, artifacts []PlannedArtifact) string {
+	conditions := make([]string, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		_, relativePath, _ := strings.Cut(artifact.TargetPath, "/")
+		dir, name := path.Split(relativePath)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" {
+			dir = "."
+		}
+		conditions = append(conditions, fmt.Sprintf(`{"$and":[{"path":"%s"},{"name":"%s"}]}`, dir, name))
+	}
+	return fmt.Sprintf(`items.find({"repo":"%s","$or":[%s]}).include("repo","path","name","sha256")`, repo, strings.Join(conditions, ","))
+}
+
+func printDistributionPlan(plan DistributionPlan, format string) error {
+	if format == jsonOutputFormat {
+		content, err := json.Marshal(plan)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	}
+	if len(plan.Sites) == 0 {
+		log.Info("The distribution does not resolve to any site.")
+		return nil
+	}
+	for _, site := range plan.Sites {
+		if len(site.RepositoriesToCreate) > 0 {
+			log.Info(fmt.Sprintf("Repositories to be created on %s: %s", site.Name, strings.Join(site.RepositoriesToCreate, ", ")))
+		}
+		if len(site.MissingRepositories) > 0 {
+			log.Warn(fmt.Sprintf("Repositories missing on %s: %s", site.Name, strings.Join(site.MissingRepositories, ", ")))
+		}
+		rows := make([]plannedArtifactRow, 0, len(site.Artifacts))
+		for _, artifact := range site.Artifacts {
+			rows = append(rows, plannedArtifactRow{SourcePath: artifact.SourcePath, TargetPath: artifact.TargetPath, Status: artifact.Status, ExistingSha256: artifact.ExistingSha256})
+		}
+		if err := coreutils.PrintTable(rows, "Distribution to "+site.Name, "No artifacts to distribute", false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func distributionPlanError(plan DistributionPlan) error {
+	var problems []string
+	for _, site := range plan.Sites {
+		if site.conflicts > 0 {
+			problems = append(problems, fmt.Sprintf("%d conflicting artifacts on %s", site.conflicts, site.Name))
+		}
+		if len(site.MissingRepositories) > 0 {
+			problems = append(problems, fmt.Sprintf("%d missing repositories on %s", len(site.MissingRepositories), site.Name))
+		}
+	}
+	if len(problems) > 0 {
+		return errorutils.CheckErrorf("the distribution plan has problems: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
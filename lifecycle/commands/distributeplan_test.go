@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapTargetPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourcePath string
+		pattern    string
+		target     string
+		expected   string
+	}{
+		{"no mapping", "generic-local/app/app.zip", "", "", "generic-local/app/app.zip"},
+		{"repo mapping", "generic-local/app/app.zip", "generic-local/(*)", "generic-edge/{1}", "generic-edge/app/app.zip"},
+		{"target directory", "generic-local/app/app.zip", "generic-local/app/*", "generic-edge/releases/", "generic-edge/releases/app.zip"},
+		{"not matching", "docker-local/app/manifest.json", "generic-local/(*)", "generic-edge/{1}", "docker-local/app/manifest.json"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targetPath, err := mapTargetPath(test.sourcePath, test.pattern, test.target)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, targetPath)
+		})
+	}
+}
+
+func TestGetDistributionTargets(t *testing.T) {
+	var request map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lifecycle/api/v2/distribution/distribute/payments/1.0.0", r.URL.Path)
+		assert.Equal(t, "proj", r.URL.Query().Get("project"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, err := w.Write([]byte(`{"sites":[{"service_id":"jfed1","name":"edge-eu","type":"edge"},{"service_id":"jfed2","name":"edge-us","type":"edge"},{"service_id":"jfed1","name":"edge-eu","type":"edge"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverDetails := &config.ServerDetails{LifecycleUrl: server.URL + "/lifecycle/"}
+	manager, err := utils.CreateLifecycleServiceManager(serverDetails, true)
+	require.NoError(t, err)
+
+	// Rules by wildcards, city or country code are resolved by the server, regardless of the mapped sites.
+	rbd := NewReleaseBundleDistributeCommand().SetServerDetails(serverDetails).SetSiteServers(map[string]string{"edge-eu": "eu"})
+	params := services.DistributeReleaseBundleParams{
+		DistributionRules: []*distribution.DistributionCommonParams{{SiteName: "edge-*"}, {CountryCodes: []string{"US"}}},
+		PathMappings:      []services.PathMapping{{Pattern: "(.*)", Target: "mirror/$1"}},
+		ProjectKey:        "proj",
+	}
+	sites, err := rbd.getDistributionTargets(manager, services.ReleaseBundleDetails{ReleaseBundleName: "payments", ReleaseBundleVersion: "1.0.0"}, params)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edge-eu", "edge-us"}, sites)
+	assert.Equal(t, true, request["dry_run"])
+	assert.Len(t, request["distribution_rules"], 2)
+	assert.NotEmpty(t, request["modifications"].(map[string]any)["mappings"])
+}
+
+func TestGetSiteServicesManager(t *testing.T) {
+	servers := []*config.ServerDetails{{ServerId: "edge-eu", ArtifactoryUrl: "https://eu.example.com/artifactory/"}, {ServerId: "us", ArtifactoryUrl: "https://us.example.com/artifactory/"}}
+	rbd := NewReleaseBundleDistributeCommand().SetSiteServers(map[string]string{"edge-us": "us", "edge-ap": "ap"})
+
+	manager, err := rbd.getSiteServicesManager("edge-eu", servers)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eu.example.com/artifactory/", manager.GetConfig().GetServiceDetails().GetUrl())
+	manager, err = rbd.getSiteServicesManager("edge-us", servers)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://us.example.com/artifactory/", manager.GetConfig().GetServiceDetails().GetUrl())
+
+	// An unmapped site without a matching server is not verified, while a mapping to a missing server is an error.
+	manager, err = rbd.getSiteServicesManager("edge-sa", servers)
+	assert.NoError(t, err)
+	assert.Nil(t, manager)
+	_, err = rbd.getSiteServicesManager("edge-ap", servers)
+	assert.ErrorContains(t, err, "the 'ap' server ID, mapped to the 'edge-ap' site, is not configured")
+}
+
+func TestGroupArtifactsByTargetRepo(t *testing.T) {
+	artifacts := []PlannedArtifact{
+		{TargetPath: "generic-edge/a.zip"},
+		{TargetPath: "docker-edge/app/1.0/manifest.json"},
+		{TargetPath: "generic-edge/b/b.zip"},
+	}
+	grouped := groupArtifactsByTargetRepo(artifacts)
+	assert.Equal(t, []string{"docker-edge", "generic-edge"}, sortedKeys(grouped))
+	assert.Len(t, grouped["generic-edge"], 2)
+}
+
+func TestBuildExistingFilesQuery(t *testing.T) {
+	query := buildExistingFilesQuery("generic-edge", []PlannedArtifact{
+		{TargetPath: "generic-edge/a.zip"},
+		{TargetPath: "generic-edge/b/c/d.zip"},
+	})
+	assert.Equal(t, `items.find({"repo":"generic-edge","$or":[{"$and":[{"path":"."},{"name":"a.zip"}]},{"$and":[{"path":"b/c"},{"name":"d.zip"}]}]}).include("repo","path","name","sha256")`, query)
+}
+
+func TestDistributionPlanError(t *testing.T) {
+	assert.NoError(t, distributionPlanError(DistributionPlan{Sites: []SiteDistributionPlan{{Name: "edge-eu"}}}))
+	err := distributionPlanError(DistributionPlan{Sites: []SiteDistributionPlan{
+		{Name: "edge-eu", conflicts: 2},
+		{Name: "edge-us", MissingRepositories: []string{"generic-edge"}},
+	}})
+	assert.ErrorContains(t, err, "2 conflicting artifacts on edge-eu")
+	assert.ErrorContains(t, err, "1 missing repositories on edge-us")
+}
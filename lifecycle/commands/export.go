@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
 	artUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -9,6 +11,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"strings"
@@ -19,6 +22,9 @@ type ReleaseBundleExportCommand struct {
 	modifications          services.Modifications
 	downloadConfigurations artUtils.DownloadConfiguration
 	targetPath             string
+	// Incremental export: content found in these archives or listed in this file is left out of the exported archive.
+	baseArchives       []string
+	knownChecksumsFile string
 }
 
 func (rbe *ReleaseBundleExportCommand) Run() (err error) {
@@ -35,6 +41,9 @@ func (rbe *ReleaseBundleExportCommand) Run() (err error) {
 	if err != nil {
 		return errorutils.CheckErrorf("Failed exporting release bundle, error: '%s'", err.Error())
 	}
+	if rbe.isIncremental() {
+		return rbe.exportIncremental(exportResponse)
+	}
 	// Download the exported bundle
 	log.Debug("Downloading the exported bundle...")
 	downloaded, failed, err := rbe.downloadReleaseBundle(exportResponse, rbe.downloadConfigurations)
@@ -45,22 +54,64 @@ func (rbe *ReleaseBundleExportCommand) Run() (err error) {
 	return
 }
 
+func (rbe *ReleaseBundleExportCommand) isIncremental() bool {
+	return len(rbe.baseArchives) > 0 || rbe.knownChecksumsFile != ""
+}
+
 // Download the exported release bundle using artifactory service manager
 func (rbe *ReleaseBundleExportCommand) downloadReleaseBundle(exportResponse services.ReleaseBundleExportedStatusResponse, downloadConfiguration artUtils.DownloadConfiguration) (downloaded int, failed int, err error) {
-	downloadParams := artServices.DownloadParams{
+	artifactoryServiceManager, err := createArtifactoryServiceManager(rbe.serverDetails)
+	if err != nil {
+		return
+	}
+	return artifactoryServiceManager.DownloadFiles(createExportDownloadParams(exportResponse, rbe.targetPath, downloadConfiguration))
+
+}
+
+func createExportDownloadParams(exportResponse services.ReleaseBundleExportedStatusResponse, targetPath string, downloadConfiguration artUtils.DownloadConfiguration) artServices.DownloadParams {
+	return artServices.DownloadParams{
 		CommonParams: &utils.CommonParams{
 			Pattern: strings.TrimPrefix(exportResponse.RelativeUrl, "/"),
-			Target:  rbe.targetPath,
+			Target:  targetPath,
 		},
 		MinSplitSize: downloadConfiguration.MinSplitSize,
 		SplitCount:   downloadConfiguration.SplitCount,
 	}
+}
+
+// exportIncremental downloads the exported archive and creates a delta archive next to it,
+// which leaves out the content that already exists on the import side.
+func (rbe *ReleaseBundleExportCommand) exportIncremental(exportResponse services.ReleaseBundleExportedStatusResponse) (err error) {
+	known, err := collectKnownChecksums(rbe.baseArchives, rbe.knownChecksumsFile)
+	if err != nil {
+		return
+	}
 	artifactoryServiceManager, err := createArtifactoryServiceManager(rbe.serverDetails)
 	if err != nil {
 		return
 	}
-	return artifactoryServiceManager.DownloadFiles(downloadParams)
-
+	log.Debug("Downloading the exported bundle...")
+	summary, err := artifactoryServiceManager.DownloadFilesWithSummary(createExportDownloadParams(exportResponse, rbe.targetPath, rbe.downloadConfigurations))
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, summary.TransferDetailsReader.Close())
+	}()
+	if summary.TotalFailed > 0 || summary.TotalSucceeded < 1 {
+		return errorutils.CheckErrorf("failed downloading the exported release bundle archive")
+	}
+	transferDetails := new(clientUtils.FileTransferDetails)
+	if err = summary.TransferDetailsReader.NextRecord(transferDetails); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Creating the delta archive...")
+	deltaArchivePath, err := createDeltaArchive(transferDetails.TargetPath, known)
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Successfully created the delta archive %s from the release bundle archive %s", deltaArchivePath, transferDetails.TargetPath))
+	return
 }
 func (rbe *ReleaseBundleExportCommand) ServerDetails() (*config.ServerDetails, error) {
 	return rbe.serverDetails, nil
@@ -102,6 +153,16 @@ func (rbe *ReleaseBundleExportCommand) SetDownloadConfiguration(downloadConfig a
 	return rbe
 }
 
+func (rbe *ReleaseBundleExportCommand) SetBaseArchives(baseArchives []string) *ReleaseBundleExportCommand {
+	rbe.baseArchives = baseArchives
+	return rbe
+}
+
+func (rbe *ReleaseBundleExportCommand) SetKnownChecksumsFile(knownChecksumsFile string) *ReleaseBundleExportCommand {
+	rbe.knownChecksumsFile = knownChecksumsFile
+	return rbe
+}
+
 func (rbe *ReleaseBundleExportCommand) SetTargetPath(target string) *ReleaseBundleExportCommand {
 	if target == "" {
 		// Default value as current dir
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// deltaManifestName is the name of the manifest entry which marks an archive as an incremental export.
+	deltaManifestName  = "delta-manifest.json"
+	deltaArchiveSuffix = "-delta.zip"
+)
+
+// DeltaManifest lists every entry of the full export archive, in the original order.
+// Entries which are not included in the delta archive are restored by their checksum when importing.
+type DeltaManifest struct {
+	Archive string       `json:"archive"`
+	Entries []DeltaEntry `json:"entries"`
+}
+
+type DeltaEntry struct {
+	Name     string `json:"name"`
+	Sha256   string `json:"sha256,omitempty"`
+	Size     uint64 `json:"size"`
+	Included bool   `json:"included"`
+}
+
+// collectKnownChecksums returns the SHA-256 checksums of the content which already exists on the import side,
+// taken from previously exported archives and from a file listing one checksum per line.
+func collectKnownChecksums(baseArchives []string, knownChecksumsFile string) (known map[string]bool, err error) {
+	known = make(map[string]bool)
+	for _, baseArchive := range baseArchives {
+		checksums, err := archiveChecksums(baseArchive)
+		if err != nil {
+			return nil, err
+		}
+		for _, checksum := range checksums {
+			known[checksum] = true
+		}
+	}
+	if knownChecksumsFile == "" {
+		return known, nil
+	}
+	file, err := os.Open(knownChecksumsFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		known[strings.ToLower(line)] = true
+	}
+	return known, errorutils.CheckError(scanner.Err())
+}
+
+// archiveChecksums returns the SHA-256 checksums of the file entries in the archive, keyed by entry name.
+func archiveChecksums(archivePath string) (checksums map[string]string, err error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed opening archive '%s': %s", archivePath, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	checksums = make(map[string]string)
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if checksums[file.Name], err = entryChecksum(file); err != nil {
+			return nil, err
+		}
+	}
+	return checksums, nil
+}
+
+func entryChecksum(file *zip.File) (checksum string, err error) {
+	entry, err := file.Open()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(entry.Close()))
+	}()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, entry); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// createDeltaArchive writes an archive next to the full archive, containing only the entries whose content is not known,
+// along with a manifest of all the entries. Returns the path of the delta archive.
+func createDeltaArchive(fullArchivePath string, known map[string]bool) (deltaArchivePath string, err error) {
+	reader, err := zip.OpenReader(fullArchivePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+
+	deltaArchivePath = strings.TrimSuffix(fullArchivePath, filepath.Ext(fullArchivePath)) + deltaArchiveSuffix
+	output, err := os.Create(deltaArchivePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(output.Close()))
+	}()
+	writer := zip.NewWriter(output)
+
+	manifest := DeltaManifest{Archive: filepath.Base(fullArchivePath)}
+	var includedBytes, skippedBytes uint64
+	for _, file := range reader.File {
+		entry := DeltaEntry{Name: file.Name, Size: file.UncompressedSize64, Included: true}
+		if !file.FileInfo().IsDir() {
+			if entry.Sha256, err = entryChecksum(file); err != nil {
+				return "", err
+			}
+			// Empty files are always included, as there's no content to restore them from.
+			entry.Included = entry.Size == 0 || !known[entry.Sha256]
+		}
+		if entry.Included {
+			if err = writer.Copy(file); err != nil {
+				return "", errorutils.CheckError(err)
+			}
+			includedBytes += entry.Size
+		} else {
+			skippedBytes += entry.Size
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+	if err = writeDeltaManifest(writer, manifest); err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckError(writer.Close()); err != nil {
+		return "", err
+	}
+	log.Info(fmt.Sprintf("The delta archive includes %d bytes and references %d bytes of existing content.", includedBytes, skippedBytes))
+	return deltaArchivePath, nil
+}
+
+func writeDeltaManifest(writer *zip.Writer, manifest DeltaManifest) error {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	entry, err := writer.Create(deltaManifestName)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = entry.Write(content)
+	return errorutils.CheckError(err)
+}
+
+// readDeltaManifest returns the delta manifest of the archive, or nil if the archive is not a delta archive.
+func readDeltaManifest(reader *zip.Reader) (*DeltaManifest, error) {
+	for _, file := range reader.File {
+		if file.Name != deltaManifestName {
+			continue
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		manifest := new(DeltaManifest)
+		err = json.NewDecoder(entry).Decode(manifest)
+		return manifest, errors.Join(errorutils.CheckError(err), errorutils.CheckError(entry.Close()))
+	}
+	return nil, nil
+}
+
+// restoreDeltaArchive stitches the full archive from the delta archive, restoring the referenced content from the base archives
+// or, if not found there, from Artifactory. Returns the path of the restored archive, created in targetDir.
+func restoreDeltaArchive(deltaReader *zip.Reader, manifest *DeltaManifest, baseArchives []string, targetDir string,
+	rtServicesManager artifactory.ArtifactoryServicesManager) (restoredArchivePath string, err error) {
+	blobs, closeBaseArchives, err := indexBaseArchives(baseArchives)
+	defer func() {
+		err = errors.Join(err, closeBaseArchives())
+	}()
+	if err != nil {
+		return "", err
+	}
+	includedFiles := make(map[string]*zip.File)
+	for _, file := range deltaReader.File {
+		includedFiles[file.Name] = file
+	}
+
+	restoredArchivePath = filepath.Join(targetDir, manifest.Archive)
+	output, err := os.Create(restoredArchivePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(output.Close()))
+	}()
+	writer := zip.NewWriter(output)
+	for _, entry := range manifest.Entries {
+		if entry.Included {
+			file, found := includedFiles[entry.Name]
+			if !found {
+				return "", errorutils.CheckErrorf("the delta archive is missing the '%s' entry", entry.Name)
+			}
+			err = errorutils.CheckError(writer.Copy(file))
+		} else if blob, found := blobs[entry.Sha256]; found {
+			err = copyBlob(writer, blob, entry.Name)
+		} else {
+			err = restoreFromArtifactory(writer, entry, rtServicesManager)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return restoredArchivePath, errorutils.CheckError(writer.Close())
+}
+
+// indexBaseArchives maps the checksums of the base archives' entries to their location.
+// The returned function closes the base archives.
+func indexBaseArchives(baseArchives []string) (map[string]*zip.File, func() error, error) {
+	blobs := make(map[string]*zip.File)
+	var readers []*zip.ReadCloser
+	closeAll := func() (err error) {
+		for _, reader := range readers {
+			err = errors.Join(err, errorutils.CheckError(reader.Close()))
+		}
+		return
+	}
+	for _, baseArchive := range baseArchives {
+		reader, err := zip.OpenReader(baseArchive)
+		if err != nil {
+			return nil, closeAll, errorutils.CheckErrorf("failed opening base archive '%s': %s", baseArchive, err.Error())
+		}
+		readers = append(readers, reader)
+		for _, file := range reader.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			checksum, err := entryChecksum(file)
+			if err != nil {
+				return nil, closeAll, err
+			}
+			blobs[checksum] = file
+		}
+	}
+	return blobs, closeAll, nil
+}
+
+// copyBlob copies the compressed content of a base archive entry under the name of the restored entry.
+func copyBlob(writer *zip.Writer, file *zip.File, name string) (err error) {
+	header := file.FileHeader
+	header.Name = name
+	raw, err := file.OpenRaw()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	entry, err := writer.CreateRaw(&header)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = io.Copy(entry, raw)
+	return errorutils.CheckError(err)
+}
+
+// restoreFromArtifactory writes the entry's content, downloaded from an artifact with the same checksum.
+func restoreFromArtifactory(writer *zip.Writer, entry DeltaEntry, rtServicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	results, err := artUtils.ExecuteAqlQuery(rtServicesManager,
+		fmt.Sprintf(`items.find({"sha256":"%s"}).include("repo","path","name","sha256").limit(1)`, entry.Sha256))
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return errorutils.CheckErrorf("cannot restore '%s': no base archive or artifact with checksum %s was found", entry.Name, entry.Sha256)
+	}
+	log.Debug(fmt.Sprintf("Restoring %s from %s", entry.Name, results[0].GetItemRelativePath()))
+	content, err := rtServicesManager.ReadRemoteFile(results[0].GetItemRelativePath())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(content.Close()))
+	}()
+	output, err := writer.CreateHeader(&zip.FileHeader{Name: entry.Name, Method: zip.Deflate})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(output, hash), content); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != entry.Sha256 {
+		return errorutils.CheckErrorf("checksum mismatch while restoring '%s': expected %s, got %s", entry.Name, entry.Sha256, checksum)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArchive(t *testing.T, archivePath string, entries [][2]string) {
+	output, err := os.Create(archivePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(output)
+	for _, entry := range entries {
+		file, err := writer.Create(entry[0])
+		require.NoError(t, err)
+		_, err = file.Write([]byte(entry[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, output.Close())
+}
+
+func readTestArchive(t *testing.T, archivePath string) (names []string, contents map[string]string) {
+	reader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	contents = make(map[string]string)
+	for _, file := range reader.File {
+		entry, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(entry)
+		require.NoError(t, err)
+		require.NoError(t, entry.Close())
+		names = append(names, file.Name)
+		contents[file.Name] = string(content)
+	}
+	return
+}
+
+func testChecksum(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestCollectKnownChecksums(t *testing.T) {
+	tempDir := t.TempDir()
+	baseArchive := filepath.Join(tempDir, "base.zip")
+	createTestArchive(t, baseArchive, [][2]string{{"repo/a.bin", "a"}, {"repo/b.bin", "b"}})
+	checksumsFile := filepath.Join(tempDir, "checksums.txt")
+	require.NoError(t, os.WriteFile(checksumsFile, []byte("# known content\n"+testChecksum("c")+"\n\n"), 0600))
+
+	known, err := collectKnownChecksums([]string{baseArchive}, checksumsFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{testChecksum("a"): true, testChecksum("b"): true, testChecksum("c"): true}, known)
+
+	_, err = collectKnownChecksums([]string{filepath.Join(tempDir, "missing.zip")}, "")
+	assert.Error(t, err)
+}
+
+func TestDeltaArchiveRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	baseArchive := filepath.Join(tempDir, "bundle-1.0.0.zip")
+	createTestArchive(t, baseArchive, [][2]string{{"repo/lib/v1/lib.jar", "shared library"}, {"repo/app/v1/app.jar", "app v1"}})
+	fullArchive := filepath.Join(tempDir, "bundle-1.1.0.zip")
+	fullEntries := [][2]string{
+		{"manifest.json", `{"version":"1.1.0"}`},
+		{"repo/lib/v2/lib.jar", "shared library"},
+		{"repo/app/v2/app.jar", "app v2"},
+		{"repo/empty.txt", ""},
+	}
+	createTestArchive(t, fullArchive, fullEntries)
+
+	known, err := collectKnownChecksums([]string{baseArchive}, "")
+	require.NoError(t, err)
+	deltaArchive, err := createDeltaArchive(fullArchive, known)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, "bundle-1.1.0-delta.zip"), deltaArchive)
+
+	deltaNames, _ := readTestArchive(t, deltaArchive)
+	assert.Equal(t, []string{"manifest.json", "repo/app/v2/app.jar", "repo/empty.txt", deltaManifestName}, deltaNames)
+
+	reader, err := zip.OpenReader(deltaArchive)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	manifest, err := readDeltaManifest(&reader.Reader)
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+	assert.Equal(t, "bundle-1.1.0.zip", manifest.Archive)
+	assert.Len(t, manifest.Entries, len(fullEntries))
+	assert.False(t, manifest.Entries[1].Included)
+	assert.Equal(t, testChecksum("shared library"), manifest.Entries[1].Sha256)
+
+	restoreDir := t.TempDir()
+	restoredArchive, err := restoreDeltaArchive(&reader.Reader, manifest, []string{baseArchive}, restoreDir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(restoreDir, "bundle-1.1.0.zip"), restoredArchive)
+	restoredNames, restoredContents := readTestArchive(t, restoredArchive)
+	for i, entry := range fullEntries {
+		assert.Equal(t, entry[0], restoredNames[i])
+		assert.Equal(t, entry[1], restoredContents[entry[0]])
+	}
+}
+
+func TestReadDeltaManifestOfFullArchive(t *testing.T) {
+	fullArchive := filepath.Join(t.TempDir(), "bundle.zip")
+	createTestArchive(t, fullArchive, [][2]string{{"repo/a.bin", "a"}})
+	reader, err := zip.OpenReader(fullArchive)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	manifest, err := readDeltaManifest(&reader.Reader)
+	assert.NoError(t, err)
+	assert.Nil(t, manifest)
+}
+
+func TestRestoreIfDeltaOfNonZipFile(t *testing.T) {
+	tempDir := t.TempDir()
+	tarArchive := filepath.Join(tempDir, "bundle.tar.gz")
+	require.NoError(t, os.WriteFile(tarArchive, []byte{0x1f, 0x8b, 0x08, 0x00, 0x00}, 0644))
+	emptyFile := filepath.Join(tempDir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0644))
+	fullArchive := filepath.Join(tempDir, "bundle.zip")
+	createTestArchive(t, fullArchive, [][2]string{{"repo/a.bin", "a"}})
+
+	// Files which aren't delta archives are imported as is.
+	for _, filePath := range []string{tarArchive, emptyFile, fullArchive} {
+		archivePath, cleanup, err := NewReleaseBundleImportCommand().SetFilepath(filePath).restoreIfDelta(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, filePath, archivePath)
+		assert.NoError(t, cleanup())
+	}
+}
@@ -58,8 +58,8 @@ func (rbf *ReleaseBundleFinalizeCommand) Run() error {
 	}
 
 	// Validate Artifactory version supports draft bundle operations (finalize only works on draft bundles)
-	if err := ValidateFeatureSupportedVersion(rbf.serverDetails, minArtifactoryVersionForDraftBundleSupport); err != nil {
-		return errorutils.CheckErrorf("release bundle finalize requires Artifactory version %s or higher", minArtifactoryVersionForDraftBundleSupport)
+	if err := ValidateFeatureSupportedVersion(rbf.serverDetails, MinArtifactoryVersionForDraftBundleSupport); err != nil {
+		return errorutils.CheckErrorf("release bundle finalize requires Artifactory version %s or higher", MinArtifactoryVersionForDraftBundleSupport)
 	}
 
 	servicesManager, rbDetails, queryParams, err := rbf.getPrerequisites()
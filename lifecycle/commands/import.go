@@ -1,16 +1,32 @@
 package commands
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+// The signatures zip archives start with.
+var (
+	zipLocalFileHeader    = []byte("PK\x03\x04")
+	zipEmptyArchiveHeader = []byte("PK\x05\x06")
+)
+
 type ReleaseBundleImportCommand struct {
 	releaseBundleCmd
 	filePath string
+	// Archives holding the content which is referenced, but not included, by a delta archive.
+	baseArchives []string
 }
 
 func (rbi *ReleaseBundleImportCommand) ServerDetails() (*config.ServerDetails, error) {
@@ -34,6 +50,11 @@ func (rbi *ReleaseBundleImportCommand) SetFilepath(filePath string) *ReleaseBund
 	return rbi
 }
 
+func (rbi *ReleaseBundleImportCommand) SetBaseArchives(baseArchives []string) *ReleaseBundleImportCommand {
+	rbi.baseArchives = baseArchives
+	return rbi
+}
+
 func (rbi *ReleaseBundleImportCommand) Run() (err error) {
 	if err = validateArtifactoryVersionSupported(rbi.serverDetails); err != nil {
 		return
@@ -51,10 +72,68 @@ func (rbi *ReleaseBundleImportCommand) Run() (err error) {
 		return fmt.Errorf("file not found: %s", rbi.filePath)
 	}
 
+	archivePath, cleanup, err := rbi.restoreIfDelta(artService)
+	defer func() {
+		err = errors.Join(err, cleanup())
+	}()
+	if err != nil {
+		return
+	}
+
 	log.Info("Importing the release bundle archive...")
-	if err = artService.ImportReleaseBundle(rbi.filePath); err != nil {
+	if err = artService.ImportReleaseBundle(archivePath); err != nil {
 		return
 	}
 	log.Info("Successfully imported the release bundle archive")
 	return
 }
+
+// restoreIfDelta stitches the full archive back together when the imported file is a delta archive, and returns the path of the archive to import.
+// The returned function removes the restored archive.
+func (rbi *ReleaseBundleImportCommand) restoreIfDelta(artService artifactory.ArtifactoryServicesManager) (archivePath string, cleanup func() error, err error) {
+	cleanup = func() error { return nil }
+	// Only zip archives may be delta archives. Any other file is imported as is.
+	isZip, err := isZipArchive(rbi.filePath)
+	if err != nil || !isZip {
+		return rbi.filePath, cleanup, err
+	}
+	reader, err := zip.OpenReader(rbi.filePath)
+	if err != nil {
+		return "", cleanup, errorutils.CheckErrorf("failed opening the release bundle archive '%s': %s", rbi.filePath, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	manifest, err := readDeltaManifest(&reader.Reader)
+	if err != nil || manifest == nil {
+		return rbi.filePath, cleanup, err
+	}
+
+	log.Info("Restoring the release bundle archive from the delta archive...")
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", cleanup, err
+	}
+	cleanup = func() error { return fileutils.RemoveTempDir(tempDirPath) }
+	archivePath, err = restoreDeltaArchive(&reader.Reader, manifest, rbi.baseArchives, tempDirPath, artService)
+	return
+}
+
+func isZipArchive(filePath string) (isZip bool, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	header := make([]byte, len(zipLocalFileHeader))
+	if _, err = io.ReadFull(file, header); err != nil {
+		// Files shorter than the header aren't zip archives.
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, errorutils.CheckError(err)
+	}
+	return bytes.Equal(header, zipLocalFileHeader) || bytes.Equal(header, zipEmptyArchiveHeader), nil
+}
@@ -0,0 +1,483 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	distributionClient "github.com/jfrog/jfrog-client-go/distribution"
+	distributionServices "github.com/jfrog/jfrog-client-go/distribution/services"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	v1ReleaseBundleApi = "api/v1/release_bundle"
+	// Unsigned v1 bundles are still editable, so they are migrated as v2 drafts.
+	v1StateOpen = "OPEN"
+
+	migrationStatusPlanned  = "planned"
+	migrationStatusSkipped  = "skipped"
+	migrationStatusMigrated = "migrated"
+	migrationStatusFailed   = "failed"
+	// The v2 bundle was created by a previous run, and the steps following its creation were completed by this run.
+	migrationStatusResumed = "resumed"
+
+	migratedFromProperty = "migrated-from"
+	migratedFromValue    = "distribution-v1"
+	descriptionProperty  = "description"
+)
+
+// v1ReleaseBundle holds the parts of a Distribution v1 release bundle version that are needed to recreate it as a v2 bundle.
+type v1ReleaseBundle struct {
+	Name        string       `json:"name"`
+	Version     string       `json:"version"`
+	Description string       `json:"description,omitempty"`
+	State       string       `json:"state,omitempty"`
+	Artifacts   []v1Artifact `json:"artifacts,omitempty"`
+}
+
+type v1Artifact struct {
+	Checksum       string `json:"checksum"`
+	SourceRepoPath string `json:"sourceRepoPath"`
+}
+
+// ReleaseBundleMigration is a single line of the migration report.
+type ReleaseBundleMigration struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	State       string   `json:"v1_state"`
+	Artifacts   int      `json:"artifacts"`
+	Draft       bool     `json:"draft"`
+	Sites       []string `json:"sites,omitempty"`
+	Status      string   `json:"status"`
+	Error       string   `json:"error,omitempty"`
+	description string
+	artifacts   []services.ArtifactSource
+	// The v2 bundle already exists, so only the steps following its creation are run.
+	exists bool
+}
+
+type migrationTableRow struct {
+	Name      string `col-name:"Name"`
+	Version   string `col-name:"Version"`
+	State     string `col-name:"V1 State"`
+	Artifacts string `col-name:"Artifacts"`
+	Draft     string `col-name:"Draft"`
+	Sites     string `col-name:"Sites"`
+	Status    string `col-name:"Status"`
+	Error     string `col-name:"Error" omitempty:"true"`
+}
+
+type ReleaseBundleMigrateCommand struct {
+	releaseBundleCmd
+	signingKeyName     string
+	promoteEnvironment string
+	distribute         bool
+	autoCreateRepo     bool
+	maxWaitMinutes     int
+	dryRun             bool
+	format             string
+}
+
+func NewReleaseBundleMigrateCommand() *ReleaseBundleMigrateCommand {
+	return &ReleaseBundleMigrateCommand{}
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleMigrateCommand {
+	rbm.serverDetails = serverDetails
+	return rbm
+}
+
+// SetReleaseBundleName sets the v1 release bundle name. Wildcards are allowed to migrate several bundles at once.
+func (rbm *ReleaseBundleMigrateCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleMigrateCommand {
+	rbm.releaseBundleName = releaseBundleName
+	return rbm
+}
+
+// SetReleaseBundleVersion sets the v1 release bundle version. If empty, all versions are migrated.
+func (rbm *ReleaseBundleMigrateCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleMigrateCommand {
+	rbm.releaseBundleVersion = releaseBundleVersion
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleMigrateCommand {
+	rbm.rbProjectKey = rbProjectKey
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetSync(sync bool) *ReleaseBundleMigrateCommand {
+	rbm.sync = sync
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetSigningKeyName(signingKeyName string) *ReleaseBundleMigrateCommand {
+	rbm.signingKeyName = signingKeyName
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetPromoteEnvironment(promoteEnvironment string) *ReleaseBundleMigrateCommand {
+	rbm.promoteEnvironment = promoteEnvironment
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetDistribute(distribute bool) *ReleaseBundleMigrateCommand {
+	rbm.distribute = distribute
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetAutoCreateRepo(autoCreateRepo bool) *ReleaseBundleMigrateCommand {
+	rbm.autoCreateRepo = autoCreateRepo
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetMaxWaitMinutes(maxWaitMinutes int) *ReleaseBundleMigrateCommand {
+	rbm.maxWaitMinutes = maxWaitMinutes
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetDryRun(dryRun bool) *ReleaseBundleMigrateCommand {
+	rbm.dryRun = dryRun
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) SetFormat(format string) *ReleaseBundleMigrateCommand {
+	rbm.format = format
+	return rbm
+}
+
+func (rbm *ReleaseBundleMigrateCommand) CommandName() string {
+	return "rb_migrate"
+}
+
+func (rbm *ReleaseBundleMigrateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbm.serverDetails, nil
+}
+
+func (rbm *ReleaseBundleMigrateCommand) Run() error {
+	if err := validateArtifactoryVersionSupported(rbm.serverDetails); err != nil {
+		return err
+	}
+	dsManager, err := utils.CreateDistributionServiceManager(rbm.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	bundles, err := rbm.listMatchingV1Bundles(dsManager)
+	if err != nil {
+		return err
+	}
+	if len(bundles) == 0 {
+		log.Info(fmt.Sprintf("No Distribution v1 release bundles match '%s'.", rbm.releaseBundleName))
+		return nil
+	}
+
+	migrations := make([]ReleaseBundleMigration, 0, len(bundles))
+	for _, bundle := range bundles {
+		migrations = append(migrations, rbm.planMigration(dsManager, bundle))
+	}
+
+	lcManager, err := utils.CreateLifecycleServiceManager(rbm.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	markMigratedBundles(migrations, func(name, version string) (bool, error) {
+		return lcManager.IsReleaseBundleExist(name, version, rbm.rbProjectKey)
+	})
+
+	if !rbm.dryRun {
+		for i := range migrations {
+			if migrations[i].Status != migrationStatusPlanned {
+				continue
+			}
+			if err = rbm.migrate(lcManager, &migrations[i]); err != nil {
+				migrations[i].Status = migrationStatusFailed
+				migrations[i].Error = err.Error()
+				continue
+			}
+			migrations[i].Status = migrationStatusMigrated
+			if migrations[i].exists {
+				migrations[i].Status = migrationStatusResumed
+			}
+		}
+	}
+
+	if err = printMigrationReport(migrations, rbm.format); err != nil {
+		return err
+	}
+	return migrationsError(migrations)
+}
+
+// listMatchingV1Bundles returns the name and version of every v1 bundle matching the requested name pattern and version.
+func (rbm *ReleaseBundleMigrateCommand) listMatchingV1Bundles(dsManager *distributionClient.DistributionServicesManager) ([]v1ReleaseBundle, error) {
+	if !strings.Contains(rbm.releaseBundleName, "*") && rbm.releaseBundleVersion != "" {
+		return []v1ReleaseBundle{{Name: rbm.releaseBundleName, Version: rbm.releaseBundleVersion}}, nil
+	}
+	var all []v1ReleaseBundle
+	if err := getFromDistribution(dsManager, v1ReleaseBundleApi, &all); err != nil {
+		return nil, err
+	}
+	return filterV1Bundles(all, rbm.releaseBundleName, rbm.releaseBundleVersion)
+}
+
+func filterV1Bundles(bundles []v1ReleaseBundle, namePattern, version string) ([]v1ReleaseBundle, error) {
+	var matched []v1ReleaseBundle
+	for _, bundle := range bundles {
+		isMatch, err := filepath.Match(namePattern, bundle.Name)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if isMatch && (version == "" || version == bundle.Version) {
+			matched = append(matched, bundle)
+		}
+	}
+	return matched, nil
+}
+
+// planMigration reads the v1 bundle contents, signing state and distributed sites.
+func (rbm *ReleaseBundleMigrateCommand) planMigration(dsManager *distributionClient.DistributionServicesManager, bundle v1ReleaseBundle) ReleaseBundleMigration {
+	migration := ReleaseBundleMigration{Name: bundle.Name, Version: bundle.Version, Status: migrationStatusPlanned}
+	details := v1ReleaseBundle{}
+	bundlePath := fmt.Sprintf("%s/%s/%s?format=json", v1ReleaseBundleApi, url.PathEscape(bundle.Name), url.PathEscape(bundle.Version))
+	if err := getFromDistribution(dsManager, bundlePath, &details); err != nil {
+		migration.Status = migrationStatusFailed
+		migration.Error = err.Error()
+		return migration
+	}
+	migration.State = details.State
+	migration.Draft = details.State == v1StateOpen
+	migration.description = details.Description
+	migration.artifacts = toArtifactSources(details.Artifacts)
+	migration.Artifacts = len(migration.artifacts)
+	if migration.Artifacts == 0 {
+		migration.Status = migrationStatusSkipped
+		migration.Error = "the release bundle has no artifacts"
+		return migration
+	}
+
+	if rbm.distribute {
+		sites, err := getDistributedSites(dsManager, bundle.Name, bundle.Version)
+		if err != nil {
+			migration.Status = migrationStatusFailed
+			migration.Error = err.Error()
+			return migration
+		}
+		migration.Sites = sites
+	}
+	return migration
+}
+
+// markMigratedBundles marks the bundles which already exist as v2 bundles, so that a re-run completes the steps following their creation
+// instead of creating them again.
+func markMigratedBundles(migrations []ReleaseBundleMigration, releaseBundleExists func(name, version string) (bool, error)) {
+	for i := range migrations {
+		if migrations[i].Status != migrationStatusPlanned {
+			continue
+		}
+		exists, err := releaseBundleExists(migrations[i].Name, migrations[i].Version)
+		if err != nil {
+			migrations[i].Status = migrationStatusFailed
+			migrations[i].Error = err.Error()
+			continue
+		}
+		migrations[i].exists = exists
+	}
+}
+
+func toArtifactSources(artifacts []v1Artifact) []services.ArtifactSource {
+	sources := make([]services.ArtifactSource, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		sources = append(sources, services.ArtifactSource{
+			Path:   artifact.SourceRepoPath,
+			Sha256: artifact.Checksum,
+		})
+	}
+	return sources
+}
+
+// getDistributedSites returns the names of the sites the v1 bundle version was successfully distributed to.
+func getDistributedSites(dsManager *distributionClient.DistributionServicesManager, name, version string) ([]string, error) {
+	statuses, err := dsManager.GetDistributionStatus(distributionServices.DistributionStatusParams{Name: name, Version: version})
+	if err != nil || statuses == nil {
+		return nil, err
+	}
+	return completedSites(*statuses), nil
+}
+
+func completedSites(statuses []distribution.DistributionStatusResponse) []string {
+	var sites []string
+	seen := make(map[string]bool)
+	for _, status := range statuses {
+		if status.Type == distribution.DeleteReleaseBundleVersion {
+			continue
+		}
+		for _, site := range status.Sites {
+			siteName := site.TargetArtifactory.Name
+			if site.Status != distribution.Completed || siteName == "" || seen[siteName] {
+				continue
+			}
+			seen[siteName] = true
+			sites = append(sites, siteName)
+		}
+	}
+	return sites
+}
+
+func (rbm *ReleaseBundleMigrateCommand) migrate(lcManager *lifecycle.LifecycleServicesManager, migration *ReleaseBundleMigration) error {
+	rbDetails := services.ReleaseBundleDetails{
+		ReleaseBundleName:    migration.Name,
+		ReleaseBundleVersion: migration.Version,
+	}
+	// The bundle is created and promoted synchronously, since the following steps require it to be ready.
+	queryParams := services.CommonOptionalQueryParams{
+		ProjectKey: rbm.rbProjectKey,
+		Async:      false,
+	}
+	if migration.exists {
+		log.Info(fmt.Sprintf("Release bundle %s/%s was already migrated, resuming its migration...", migration.Name, migration.Version))
+		// A previous run may have created the bundle asynchronously.
+		status, err := lcManager.GetReleaseBundleCreationStatus(rbDetails, rbm.rbProjectKey, true)
+		if err != nil {
+			return err
+		}
+		if status.Status == services.Failed {
+			return errorutils.CheckErrorf("the creation of the v2 release bundle failed. Delete it and run the migration again")
+		}
+	} else {
+		log.Info(fmt.Sprintf("Migrating release bundle %s/%s...", migration.Name, migration.Version))
+		if err := lcManager.CreateReleaseBundleFromArtifactsDraft(rbDetails, queryParams, rbm.signingKeyName,
+			services.CreateFromArtifacts{Artifacts: migration.artifacts}, migration.Draft); err != nil {
+			return err
+		}
+	}
+	if err := rbm.annotateMigratedBundle(lcManager, rbDetails, queryParams, migration.description); err != nil {
+		return err
+	}
+	// Draft bundles cannot be promoted or distributed until they are finalized.
+	if migration.Draft {
+		return nil
+	}
+	if rbm.promoteEnvironment != "" {
+		promoted := false
+		if migration.exists {
+			promotions, err := lcManager.GetReleaseBundleVersionPromotions(rbDetails, services.GetPromotionsOptionalQueryParams{ProjectKey: rbm.rbProjectKey})
+			if err != nil {
+				return err
+			}
+			promoted = isPromotedTo(promotions, rbm.promoteEnvironment)
+		}
+		if !promoted {
+			if _, err := lcManager.PromoteReleaseBundle(rbDetails, queryParams, rbm.signingKeyName,
+				services.RbPromotionParams{Environment: rbm.promoteEnvironment}); err != nil {
+				return err
+			}
+		}
+	}
+	if rbm.distribute && len(migration.Sites) > 0 {
+		return lcManager.DistributeReleaseBundle(rbDetails, services.DistributeReleaseBundleParams{
+			Sync:              rbm.sync,
+			AutoCreateRepo:    rbm.autoCreateRepo,
+			MaxWaitMinutes:    rbm.maxWaitMinutes,
+			DistributionRules: sitesToDistRules(migration.Sites),
+			ProjectKey:        rbm.rbProjectKey,
+		})
+	}
+	return nil
+}
+
+func isPromotedTo(promotions services.RbPromotionsResponse, environment string) bool {
+	for _, promotion := range promotions.Promotions {
+		if promotion.Environment == environment && promotion.Status == services.Completed {
+			return true
+		}
+	}
+	return false
+}
+
+// annotateMigratedBundle keeps the v1 description, which has no equivalent field in v2, as a property of the new bundle.
+func (rbm *ReleaseBundleMigrateCommand) annotateMigratedBundle(lcManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, queryParams services.CommonOptionalQueryParams, description string) error {
+	props := map[string][]string{migratedFromProperty: {migratedFromValue}}
+	if description != "" {
+		props[descriptionProperty] = []string{description}
+	}
+	return lcManager.AnnotateReleaseBundle(services.AnnotateOperationParams{
+		RbProps:     services.RbAnnotationProps{Properties: props, Exist: true},
+		RbDetails:   rbDetails,
+		QueryParams: queryParams,
+		PropertyParams: services.CommonPropParams{
+			Path: buildManifestPath(queryParams.ProjectKey, rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion),
+		},
+		ArtifactoryUrl: services.ArtCommonParams{Url: rbm.serverDetails.ArtifactoryUrl},
+	})
+}
+
+func sitesToDistRules(sites []string) []*distribution.DistributionCommonParams {
+	rules := make([]*distribution.DistributionCommonParams, 0, len(sites))
+	for _, site := range sites {
+		rules = append(rules, &distribution.DistributionCommonParams{SiteName: site})
+	}
+	return rules
+}
+
+func getFromDistribution(dsManager *distributionClient.DistributionServicesManager, apiPath string, result interface{}) error {
+	serviceDetails := dsManager.Config().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := dsManager.Client().SendGet(serviceDetails.GetUrl()+apiPath, true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+func printMigrationReport(migrations []ReleaseBundleMigration, format string) error {
+	if format == jsonOutputFormat {
+		content, err := json.Marshal(migrations)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	}
+	rows := make([]migrationTableRow, 0, len(migrations))
+	for _, migration := range migrations {
+		rows = append(rows, migrationTableRow{
+			Name:      migration.Name,
+			Version:   migration.Version,
+			State:     migration.State,
+			Artifacts: strconv.Itoa(migration.Artifacts),
+			Draft:     strconv.FormatBool(migration.Draft),
+			Sites:     strings.Join(migration.Sites, ", "),
+			Status:    migration.Status,
+			Error:     migration.Error,
+		})
+	}
+	return coreutils.PrintTable(rows, "Release Bundles Migration", "No release bundles to migrate", false)
+}
+
+func migrationsError(migrations []ReleaseBundleMigration) error {
+	var failed []string
+	for _, migration := range migrations {
+		if migration.Status == migrationStatusFailed {
+			failed = append(failed, migration.Name+"/"+migration.Version)
+		}
+	}
+	if len(failed) > 0 {
+		return errorutils.CheckError(errors.New("failed migrating release bundles: " + strings.Join(failed, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterV1Bundles(t *testing.T) {
+	bundles := []v1ReleaseBundle{
+		{Name: "payments-api", Version: "1.0.0"},
+		{Name: "payments-api", Version: "1.1.0"},
+		{Name: "payments-ui", Version: "1.0.0"},
+		{Name: "billing", Version: "1.0.0"},
+	}
+
+	tests := []struct {
+		name          string
+		namePattern   string
+		version       string
+		expectedCount int
+	}{
+		{"wildcard all versions", "payments-*", "", 3},
+		{"wildcard single version", "payments-*", "1.0.0", 2},
+		{"exact name", "billing", "", 1},
+		{"no match", "orders-*", "", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matched, err := filterV1Bundles(bundles, test.namePattern, test.version)
+			assert.NoError(t, err)
+			assert.Len(t, matched, test.expectedCount)
+		})
+	}
+}
+
+func TestCompletedSites(t *testing.T) {
+	statuses := []distribution.DistributionStatusResponse{
+		{
+			Type: distribution.Distribute,
+			Sites: []distribution.DistributionSiteStatus{
+				{Status: distribution.Completed, TargetArtifactory: distribution.TargetArtifactory{Name: "edge-eu"}},
+				{Status: distribution.Failed, TargetArtifactory: distribution.TargetArtifactory{Name: "edge-us"}},
+			},
+		},
+		{
+			Type: distribution.Distribute,
+			Sites: []distribution.DistributionSiteStatus{
+				{Status: distribution.Completed, TargetArtifactory: distribution.TargetArtifactory{Name: "edge-eu"}},
+				{Status: distribution.Completed, TargetArtifactory: distribution.TargetArtifactory{Name: "edge-ap"}},
+			},
+		},
+		{
+			Type: distribution.DeleteReleaseBundleVersion,
+			Sites: []distribution.DistributionSiteStatus{
+				{Status: distribution.Completed, TargetArtifactory: distribution.TargetArtifactory{Name: "edge-old"}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"edge-eu", "edge-ap"}, completedSites(statuses))
+}
+
+func TestToArtifactSources(t *testing.T) {
+	artifacts := []v1Artifact{{Checksum: "abc", SourceRepoPath: "generic-local/a/b.zip"}}
+	assert.Equal(t, []services.ArtifactSource{{Path: "generic-local/a/b.zip", Sha256: "abc"}}, toArtifactSources(artifacts))
+}
+
+func TestMigrationsError(t *testing.T) {
+	assert.NoError(t, migrationsError([]ReleaseBundleMigration{{Name: "a", Version: "1", Status: migrationStatusMigrated}}))
+	err := migrationsError([]ReleaseBundleMigration{
+		{Name: "a", Version: "1", Status: migrationStatusMigrated},
+		{Name: "b", Version: "2", Status: migrationStatusFailed},
+	})
+	assert.ErrorContains(t, err, "b/2")
+}
+
+func TestMarkMigratedBundles(t *testing.T) {
+	migrations := []ReleaseBundleMigration{
+		{Name: "a", Version: "1", Status: migrationStatusPlanned},
+		{Name: "b", Version: "1", Status: migrationStatusPlanned},
+		{Name: "c", Version: "1", Status: migrationStatusSkipped},
+		{Name: "d", Version: "1", Status: migrationStatusPlanned},
+	}
+	markMigratedBundles(migrations, func(name, version string) (bool, error) {
+		switch name {
+		case "b":
+			return true, nil
+		case "c":
+			assert.Fail(t, "a skipped bundle should not be checked")
+		case "d":
+			return false, errors.New("connection refused")
+		}
+		return false, nil
+	})
+	assert.Equal(t, migrationStatusPlanned, migrations[0].Status)
+	assert.False(t, migrations[0].exists)
+	// An existing bundle is still planned, to resume the steps following its creation.
+	assert.Equal(t, migrationStatusPlanned, migrations[1].Status)
+	assert.True(t, migrations[1].exists)
+	assert.Equal(t, migrationStatusFailed, migrations[3].Status)
+	assert.Equal(t, "connection refused", migrations[3].Error)
+}
+
+func TestIsPromotedTo(t *testing.T) {
+	promotions := services.RbPromotionsResponse{Promotions: []services.RbPromotion{
+		{Environment: "QA", Status: services.Completed},
+		{Environment: "PROD", Status: services.Failed},
+	}}
+	assert.True(t, isPromotedTo(promotions, "QA"))
+	assert.False(t, isPromotedTo(promotions, "PROD"))
+	assert.False(t, isPromotedTo(promotions, "DEV"))
+}
@@ -75,8 +75,8 @@ func (rbu *ReleaseBundleUpdateCommand) Run() error {
 	}
 
 	// Validate Artifactory version supports draft bundle operations (update only works on draft bundles)
-	if err := ValidateFeatureSupportedVersion(rbu.serverDetails, minArtifactoryVersionForDraftBundleSupport); err != nil {
-		return errorutils.CheckErrorf("release bundle update requires Artifactory version %s or higher", minArtifactoryVersionForDraftBundleSupport)
+	if err := ValidateFeatureSupportedVersion(rbu.serverDetails, MinArtifactoryVersionForDraftBundleSupport); err != nil {
+		return errorutils.CheckErrorf("release bundle update requires Artifactory version %s or higher", MinArtifactoryVersionForDraftBundleSupport)
 	}
 
 	servicesManager, rbDetails, queryParams, err := rbu.getPrerequisites()
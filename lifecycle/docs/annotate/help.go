@@ -2,7 +2,8 @@ package annotate
 
 import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 
-var Usage = []string{"rba [command options] <release bundle name> <release bundle version>"}
+var Usage = []string{"rba [command options] <release bundle name> <release bundle version>",
+	"rba [command options] --query-name=<pattern> [--query-created-from=<date>] [--query-created-to=<date>] [--query-env=<environment>] [--query-sha256=<checksum>]"}
 
 func GetDescription() string {
 	return "Annotate a release bundle"
@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rbd [command options] <release bundle name> <release bundle version>"}
 
 func GetDescription() string {
-	return "Distribute a release bundle."
+	return "Distribute a release bundle. Use --dry-run to print the distribution plan of every target site without distributing."
 }
 
 func GetArguments() []components.Argument {
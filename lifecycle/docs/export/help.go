@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rbe <release bundle name> <release bundle version> [target pattern]"}
 
 func GetDescription() string {
-	return "Triggers the Export process and downloads the Release Bundle archive"
+	return "Triggers the Export process and downloads the Release Bundle archive. Use --base-archives or --known-checksums to also create a delta archive, which includes only the content missing on the import side."
 }
 
 func GetArguments() []components.Argument {
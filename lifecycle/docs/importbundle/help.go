@@ -5,11 +5,11 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rbi [command options] <path to archive>"}
 
 func GetDescription() string {
-	return "Import a local release bundle archive to Artifactory"
+	return "Import a local release bundle archive to Artifactory. Delta archives are restored first, using the archives provided by --base-archives or the matching artifacts in Artifactory."
 }
 
 func GetArguments() []components.Argument {
 	return []components.Argument{
-		{Name: "path to archive", Description: "Path to the release bundle archive on the filesystem"},
+		{Name: "path to archive", Description: "Path to the release bundle archive or delta archive on the filesystem"},
 	}
 }
@@ -0,0 +1,16 @@
+package migrate
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbm [command options] <release bundle name> [release bundle version]"}
+
+func GetDescription() string {
+	return "Migrate Distribution v1 release bundles to Release Bundles v2."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Distribution v1 Release Bundle to migrate. Wildcards are supported to migrate all the matching release bundles."},
+		{Name: "release bundle version", Description: "Version of the Distribution v1 Release Bundle to migrate. If omitted, all versions are migrated."},
+	}
+}